@@ -20,12 +20,19 @@ const VersionAnnotation = "keptn.sh/version"
 const AppAnnotation = "keptn.sh/app"
 const PreDeploymentTaskAnnotation = "keptn.sh/pre-deployment-tasks"
 const PostDeploymentTaskAnnotation = "keptn.sh/post-deployment-tasks"
+const PreDeploymentTaskDependenciesAnnotation = "keptn.sh/pre-deployment-task-dependencies"
+const PostDeploymentTaskDependenciesAnnotation = "keptn.sh/post-deployment-task-dependencies"
 const K8sRecommendedWorkloadAnnotations = "app.kubernetes.io/name"
 const K8sRecommendedVersionAnnotations = "app.kubernetes.io/version"
 const K8sRecommendedAppAnnotations = "app.kubernetes.io/part-of"
 const K8sRecommendedManagedByAnnotations = "app.kubernetes.io/managed-by"
 const PreDeploymentEvaluationAnnotation = "keptn.sh/pre-deployment-evaluations"
 const PostDeploymentEvaluationAnnotation = "keptn.sh/post-deployment-evaluations"
+const PreDeploymentAnalysisAnnotation = "keptn.sh/pre-deployment-analyses"
+const PostDeploymentAnalysisAnnotation = "keptn.sh/post-deployment-analyses"
+const ReadinessCheckAnnotation = "keptn.sh/readiness-check"
+const ObservabilityTimeoutAnnotation = "keptn.sh/observability-timeout"
+const AnalysisTimeframeAnnotation = "keptn.sh/analysis-timeframe"
 const SchedulingGateRemoved = "keptn.sh/scheduling-gate-removed"
 const TaskNameAnnotation = "keptn.sh/task-name"
 const NamespaceEnabledAnnotation = "keptn.sh/lifecycle-toolkit"
@@ -37,6 +44,9 @@ const AppTypeAnnotation = "keptn.sh/app-type"
 const KeptnGate = "keptn-prechecks-gate"
 const ContainerNameAnnotation = "keptn.sh/container"
 const MetadataAnnotation = "keptn.sh/metadata"
+const SourceURLAnnotation = "keptn.sh/source-url"
+const SourceDigestAnnotation = "keptn.sh/source-digest"
+const FetchedAtAnnotation = "keptn.sh/fetched-at"
 
 const MinKeptnNameLen = 80
 const MaxK8sObjectLength = 253
@@ -48,7 +58,7 @@ const (
 	AppTypeMultiService  AppType = "multi-service"
 )
 
-// KeptnState  is a string containing current Phase state  (Progressing/Succeeded/Failed/Unknown/Pending/Deprecated/Warning)
+// KeptnState  is a string containing current Phase state  (Progressing/Succeeded/Failed/Unknown/Pending/Deprecated/Warning/Skipped)
 type KeptnState string
 
 const (
@@ -59,10 +69,11 @@ const (
 	StatePending     KeptnState = "Pending"
 	StateDeprecated  KeptnState = "Deprecated"
 	StateWarning     KeptnState = "Warning"
+	StateSkipped     KeptnState = "Skipped"
 )
 
 func (k KeptnState) IsCompleted() bool {
-	return k == StateSucceeded || k == StateFailed || k == StateDeprecated || k == StateWarning
+	return k == StateSucceeded || k == StateFailed || k == StateDeprecated || k == StateWarning || k == StateSkipped
 }
 
 func (k KeptnState) IsSucceeded() bool {
@@ -85,6 +96,10 @@ func (k KeptnState) IsWarning() bool {
 	return k == StateWarning
 }
 
+func (k KeptnState) IsSkipped() bool {
+	return k == StateSkipped
+}
+
 type StatusSummary struct {
 	Total       int
 	Progressing int
@@ -93,6 +108,8 @@ type StatusSummary struct {
 	Pending     int
 	Unknown     int
 	Deprecated  int
+	Skipped     int
+	Warning     int
 }
 
 func UpdateStatusSummary(status KeptnState, summary StatusSummary) StatusSummary {
@@ -109,12 +126,33 @@ func UpdateStatusSummary(status KeptnState, summary StatusSummary) StatusSummary
 		summary.Pending++
 	case StateUnknown:
 		summary.Unknown++
+	case StateSkipped:
+		summary.Skipped++
+	case StateWarning:
+		summary.Warning++
 	}
 	return summary
 }
 
 func (s StatusSummary) GetTotalCount() int {
-	return s.Failed + s.Succeeded + s.Progressing + s.Pending + s.Unknown + s.Deprecated
+	return s.Failed + s.Succeeded + s.Progressing + s.Pending + s.Unknown + s.Deprecated + s.Skipped + s.Warning
+}
+
+// MergeStatusSummaries returns a StatusSummary containing the sum of the given StatusSummaries
+func MergeStatusSummaries(summaries ...StatusSummary) StatusSummary {
+	result := StatusSummary{}
+	for _, s := range summaries {
+		result.Total += s.Total
+		result.Progressing += s.Progressing
+		result.Failed += s.Failed
+		result.Succeeded += s.Succeeded
+		result.Pending += s.Pending
+		result.Unknown += s.Unknown
+		result.Deprecated += s.Deprecated
+		result.Skipped += s.Skipped
+		result.Warning += s.Warning
+	}
+	return result
 }
 
 func GetOverallState(s StatusSummary) KeptnState {
@@ -133,10 +171,32 @@ func GetOverallState(s StatusSummary) KeptnState {
 	if s.GetTotalCount() != s.Total {
 		return StatePending
 	}
+	if s.Warning > 0 {
+		return StateWarning
+	}
 	return StateSucceeded
 }
 
+// GetOverallStateBlockedDeployment returns the overall state of the given StatusSummary.
+// If the deployment is blocking, a warning is considered as a failure,
+// otherwise failures are downgraded to warnings.
 func GetOverallStateBlockedDeployment(s StatusSummary, blockedDeployment bool) KeptnState {
+	state := GetOverallState(s)
+	if blockedDeployment && state == StateWarning {
+		return StateFailed
+	}
+	if !blockedDeployment && state == StateFailed {
+		return StateWarning
+	}
+	return state
+}
+
+// GetOverallEvaluationState returns the overall state of the given StatusSummary
+// of evaluations and analyses.
+// Their warnings come from a score that only reached the warning threshold,
+// so unlike in GetOverallStateBlockedDeployment they never block the deployment.
+// If the deployment is not blocking, failures are downgraded to warnings.
+func GetOverallEvaluationState(s StatusSummary, blockedDeployment bool) KeptnState {
 	state := GetOverallState(s)
 	if !blockedDeployment && state == StateFailed {
 		return StateWarning
@@ -178,6 +238,12 @@ type KeptnMeters struct {
 	PromotionCount     metric.Int64Counter
 }
 
+type CloudEventMeters struct {
+	SentCount    metric.Int64Counter
+	FailedCount  metric.Int64Counter
+	DroppedCount metric.Int64Counter
+}
+
 const (
 	AppName                 attribute.Key = attribute.Key("keptn.deployment.app.name")
 	AppVersion              attribute.Key = attribute.Key("keptn.deployment.app.version")
@@ -192,6 +258,10 @@ const (
 	TaskStatus              attribute.Key = attribute.Key("keptn.deployment.task.status")
 	TaskName                attribute.Key = attribute.Key("keptn.deployment.task.name")
 	TaskType                attribute.Key = attribute.Key("keptn.deployment.task.type")
+	TaskTerminationReason   attribute.Key = attribute.Key("keptn.deployment.task.terminationreason")
+	TaskLogs                attribute.Key = attribute.Key("keptn.deployment.task.logs")
+	TaskNamespace           attribute.Key = attribute.Key("keptn.deployment.task.namespace")
+	TaskDefinition          attribute.Key = attribute.Key("keptn.deployment.task.definition")
 	EvaluationStatus        attribute.Key = attribute.Key("keptn.deployment.evaluation.status")
 	EvaluationName          attribute.Key = attribute.Key("keptn.deployment.evaluation.name")
 	EvaluationType          attribute.Key = attribute.Key("keptn.deployment.evaluation.type")
@@ -227,5 +297,5 @@ func MergeMaps(m1 map[string]string, m2 map[string]string) map[string]string {
 
 // IsOwnerSupported returns whether the owner of the given object is supported to be considered a KeptnWorkload
 func IsOwnerSupported(owner metav1.OwnerReference) bool {
-	return owner.Kind == "ReplicaSet" || owner.Kind == "Deployment" || owner.Kind == "StatefulSet" || owner.Kind == "DaemonSet" || owner.Kind == "Rollout"
+	return owner.Kind == "ReplicaSet" || owner.Kind == "Deployment" || owner.Kind == "StatefulSet" || owner.Kind == "DaemonSet" || owner.Kind == "Rollout" || owner.Kind == "Job" || owner.Kind == "CronJob"
 }
@@ -31,6 +31,10 @@ func TestKeptnState_IsCompleted(t *testing.T) {
 			State: StateDeprecated,
 			Want:  true,
 		},
+		{
+			State: StateSkipped,
+			Want:  true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
@@ -148,6 +152,11 @@ func TestKeptnState_IsDeprecated(t *testing.T) {
 	}
 }
 
+func TestKeptnState_IsSkipped(t *testing.T) {
+	require.True(t, StateSkipped.IsSkipped())
+	require.False(t, StateFailed.IsSkipped())
+}
+
 func TestKeptnKeptnState_IsPending(t *testing.T) {
 	tests := []struct {
 		State KeptnState
@@ -170,38 +179,46 @@ func TestKeptnKeptnState_IsPending(t *testing.T) {
 }
 
 func Test_UpdateStatusSummary(t *testing.T) {
-	emmptySummary := StatusSummary{0, 0, 0, 0, 0, 0, 0}
+	emmptySummary := StatusSummary{0, 0, 0, 0, 0, 0, 0, 0, 0}
 	tests := []struct {
 		State KeptnState
 		Want  StatusSummary
 	}{
 		{
 			State: StateProgressing,
-			Want:  StatusSummary{0, 1, 0, 0, 0, 0, 0},
+			Want:  StatusSummary{0, 1, 0, 0, 0, 0, 0, 0, 0},
 		},
 		{
 			State: StateFailed,
-			Want:  StatusSummary{0, 0, 1, 0, 0, 0, 0},
+			Want:  StatusSummary{0, 0, 1, 0, 0, 0, 0, 0, 0},
 		},
 		{
 			State: StateSucceeded,
-			Want:  StatusSummary{0, 0, 0, 1, 0, 0, 0},
+			Want:  StatusSummary{0, 0, 0, 1, 0, 0, 0, 0, 0},
 		},
 		{
 			State: StatePending,
-			Want:  StatusSummary{0, 0, 0, 0, 1, 0, 0},
+			Want:  StatusSummary{0, 0, 0, 0, 1, 0, 0, 0, 0},
 		},
 		{
 			State: "",
-			Want:  StatusSummary{0, 0, 0, 0, 1, 0, 0},
+			Want:  StatusSummary{0, 0, 0, 0, 1, 0, 0, 0, 0},
 		},
 		{
 			State: StateUnknown,
-			Want:  StatusSummary{0, 0, 0, 0, 0, 1, 0},
+			Want:  StatusSummary{0, 0, 0, 0, 0, 1, 0, 0, 0},
 		},
 		{
 			State: StateDeprecated,
-			Want:  StatusSummary{0, 0, 0, 0, 0, 0, 1},
+			Want:  StatusSummary{0, 0, 0, 0, 0, 0, 1, 0, 0},
+		},
+		{
+			State: StateSkipped,
+			Want:  StatusSummary{0, 0, 0, 0, 0, 0, 0, 1, 0},
+		},
+		{
+			State: StateWarning,
+			Want:  StatusSummary{0, 0, 0, 0, 0, 0, 0, 0, 1},
 		},
 	}
 	for _, tt := range tests {
@@ -212,8 +229,16 @@ func Test_UpdateStatusSummary(t *testing.T) {
 }
 
 func Test_GetTotalCount(t *testing.T) {
-	summary := StatusSummary{2, 0, 2, 1, 0, 3, 5}
-	require.Equal(t, summary.GetTotalCount(), 11)
+	summary := StatusSummary{2, 0, 2, 1, 0, 3, 5, 1, 2}
+	require.Equal(t, summary.GetTotalCount(), 14)
+}
+
+func Test_MergeStatusSummaries(t *testing.T) {
+	require.Equal(t, StatusSummary{}, MergeStatusSummaries())
+	require.Equal(t,
+		StatusSummary{3, 1, 1, 2, 1, 1, 1, 2, 1},
+		MergeStatusSummaries(StatusSummary{2, 1, 0, 1, 0, 1, 0, 1, 0}, StatusSummary{1, 0, 1, 1, 1, 0, 1, 1, 1}),
+	)
 }
 
 func Test_GeOverallState(t *testing.T) {
@@ -224,44 +249,59 @@ func Test_GeOverallState(t *testing.T) {
 	}{
 		{
 			Name:    "failed",
-			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0},
+			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0, 0, 0},
 			Want:    StateFailed,
 		},
 		{
 			Name:    "deprecated",
-			Summary: StatusSummary{0, 0, 0, 0, 0, 0, 1},
+			Summary: StatusSummary{0, 0, 0, 0, 0, 0, 1, 0, 0},
 			Want:    StateFailed,
 		},
 		{
 			Name:    "progressing",
-			Summary: StatusSummary{0, 1, 0, 0, 0, 0, 0},
+			Summary: StatusSummary{0, 1, 0, 0, 0, 0, 0, 0, 0},
 			Want:    StateProgressing,
 		},
 		{
 			Name:    "pending",
-			Summary: StatusSummary{0, 0, 0, 0, 1, 0, 0},
+			Summary: StatusSummary{0, 0, 0, 0, 1, 0, 0, 0, 0},
 			Want:    StatePending,
 		},
 		{
 			Name:    "unknown",
-			Summary: StatusSummary{0, 0, 0, 0, 0, 1, 0},
+			Summary: StatusSummary{0, 0, 0, 0, 0, 1, 0, 0, 0},
 			Want:    StateUnknown,
 		},
 		{
 			Name:    "unknown totalcount",
-			Summary: StatusSummary{5, 0, 0, 0, 0, 1, 0},
+			Summary: StatusSummary{5, 0, 0, 0, 0, 1, 0, 0, 0},
 			Want:    StateUnknown,
 		},
 		{
 			Name:    "succeeded",
-			Summary: StatusSummary{1, 0, 0, 1, 0, 0, 0},
+			Summary: StatusSummary{1, 0, 0, 1, 0, 0, 0, 0, 0},
+			Want:    StateSucceeded,
+		},
+		{
+			Name:    "succeeded with skipped",
+			Summary: StatusSummary{2, 0, 0, 1, 0, 0, 0, 1, 0},
 			Want:    StateSucceeded,
 		},
 		{
 			Name:    "pending total count",
-			Summary: StatusSummary{2, 0, 0, 1, 0, 0, 0},
+			Summary: StatusSummary{2, 0, 0, 1, 0, 0, 0, 0, 0},
 			Want:    StatePending,
 		},
+		{
+			Name:    "warning",
+			Summary: StatusSummary{2, 0, 0, 1, 0, 0, 0, 0, 1},
+			Want:    StateWarning,
+		},
+		{
+			Name:    "failed with warning",
+			Summary: StatusSummary{2, 0, 1, 0, 0, 0, 0, 0, 1},
+			Want:    StateFailed,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
@@ -279,28 +319,40 @@ func Test_GeOverallStateBlockedDeployment(t *testing.T) {
 	}{
 		{
 			Name:    "failed blocking",
-			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0},
+			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0, 0, 0},
 			Block:   true,
 			Want:    StateFailed,
 		},
 		{
 			Name:    "succeeded blocking",
-			Summary: StatusSummary{1, 0, 0, 1, 0, 0, 0},
+			Summary: StatusSummary{1, 0, 0, 1, 0, 0, 0, 0, 0},
 			Block:   true,
 			Want:    StateSucceeded,
 		},
 		{
 			Name:    "failed non-blocking",
-			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0},
+			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0, 0, 0},
 			Block:   false,
 			Want:    StateWarning,
 		},
 		{
 			Name:    "succeeded non-blocking",
-			Summary: StatusSummary{1, 0, 0, 1, 0, 0, 0},
+			Summary: StatusSummary{1, 0, 0, 1, 0, 0, 0, 0, 0},
 			Block:   false,
 			Want:    StateSucceeded,
 		},
+		{
+			Name:    "warning blocking",
+			Summary: StatusSummary{1, 0, 0, 0, 0, 0, 0, 0, 1},
+			Block:   true,
+			Want:    StateFailed,
+		},
+		{
+			Name:    "warning non-blocking",
+			Summary: StatusSummary{1, 0, 0, 0, 0, 0, 0, 0, 1},
+			Block:   false,
+			Want:    StateWarning,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
@@ -309,6 +361,51 @@ func Test_GeOverallStateBlockedDeployment(t *testing.T) {
 	}
 }
 
+func Test_GetOverallEvaluationState(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Summary StatusSummary
+		Block   bool
+		Want    KeptnState
+	}{
+		{
+			Name:    "failed blocking",
+			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0, 0, 0},
+			Block:   true,
+			Want:    StateFailed,
+		},
+		{
+			Name:    "failed non-blocking",
+			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0, 0, 0},
+			Block:   false,
+			Want:    StateWarning,
+		},
+		{
+			Name:    "warning blocking",
+			Summary: StatusSummary{2, 0, 0, 1, 0, 0, 0, 0, 1},
+			Block:   true,
+			Want:    StateWarning,
+		},
+		{
+			Name:    "warning non-blocking",
+			Summary: StatusSummary{2, 0, 0, 1, 0, 0, 0, 0, 1},
+			Block:   false,
+			Want:    StateWarning,
+		},
+		{
+			Name:    "warning and failed blocking",
+			Summary: StatusSummary{2, 0, 1, 0, 0, 0, 0, 0, 1},
+			Block:   true,
+			Want:    StateFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			require.Equal(t, tt.Want, GetOverallEvaluationState(tt.Summary, tt.Block))
+		})
+	}
+}
+
 func Test_TruncateString(t *testing.T) {
 	tests := []struct {
 		Input string
@@ -533,12 +630,30 @@ func TestIsOwnerSupported(t *testing.T) {
 			want: true,
 		},
 		{
-			name: "Job-> false",
+			name: "Job-> true",
 			args: args{
 				owner: v1.OwnerReference{
 					Kind: "Job",
 				},
 			},
+			want: true,
+		},
+		{
+			name: "CronJob-> true",
+			args: args{
+				owner: v1.OwnerReference{
+					Kind: "CronJob",
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Pod-> false",
+			args: args{
+				owner: v1.OwnerReference{
+					Kind: "Pod",
+				},
+			},
 			want: false,
 		},
 	}
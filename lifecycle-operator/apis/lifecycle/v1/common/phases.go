@@ -23,7 +23,9 @@ var phases = []KeptnPhaseType{
 	PhaseAppPostDeployment,
 	PhaseAppPreEvaluation,
 	PhaseAppPostEvaluation,
+	PhaseAppApproval,
 	PhasePromotion,
+	PhaseAppRollback,
 	PhaseAppDeployment,
 	PhaseReconcileEvaluation,
 	PhaseReconcileTask,
@@ -96,8 +98,10 @@ var (
 	PhaseAppPostDeployment        = KeptnPhaseType{LongName: "App Post-Deployment Tasks", ShortName: "AppPostDeployTasks"}
 	PhaseAppPreEvaluation         = KeptnPhaseType{LongName: "App Pre-Deployment Evaluations", ShortName: "AppPreDeployEvaluations"}
 	PhaseAppPostEvaluation        = KeptnPhaseType{LongName: "App Post-Deployment Evaluations", ShortName: "AppPostDeployEvaluations"}
+	PhaseAppApproval              = KeptnPhaseType{LongName: "App Approval", ShortName: "AppApproval"}
 	PhasePromotion                = KeptnPhaseType{LongName: "Promotion Tasks", ShortName: "PromotionTasks"}
 	PhaseAppDeployment            = KeptnPhaseType{LongName: "App Deployment", ShortName: "AppDeploy"}
+	PhaseAppRollback              = KeptnPhaseType{LongName: "App Rollback", ShortName: "AppRollback"}
 	PhaseReconcileEvaluation      = KeptnPhaseType{LongName: "Reconcile Evaluation", ShortName: "ReconcileEvaluation"}
 	PhaseReconcileTask            = KeptnPhaseType{LongName: "Reconcile Task", ShortName: "ReconcileTask"}
 	PhaseReconcileWorkload        = KeptnPhaseType{LongName: "Reconcile Workloads", ShortName: "ReconcileWorkload"}
@@ -17,9 +17,14 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// DefaultAnalysisTimeframe is the length of the timeframe evaluated by pre- and post-deployment analyses if none is set
+const DefaultAnalysisTimeframe = 5 * time.Minute
+
 type DeploymentTaskSpec struct {
 	// PreDeploymentTasks is a list of all tasks to be performed during the pre-deployment phase of the KeptnApp.
 	// The items of this list refer to the names of KeptnTaskDefinitions
@@ -43,6 +48,139 @@ type DeploymentTaskSpec struct {
 	// The items of this list refer to the names of KeptnTaskDefinitions
 	// located in the same namespace as the KeptnApp, or in the Keptn namespace.
 	PromotionTasks []string `json:"promotionTasks,omitempty"`
+	// PreDeploymentTaskRefs is a structured list of tasks to be performed during the pre-deployment phase of the KeptnApp.
+	// In contrast to PreDeploymentTasks, each item can declare the tasks it depends on
+	// and a condition for its execution.
+	// The tasks of both lists are executed during the pre-deployment phase.
+	// +optional
+	PreDeploymentTaskRefs []TaskReference `json:"preDeploymentTaskRefs,omitempty"`
+	// PostDeploymentTaskRefs is a structured list of tasks to be performed during the post-deployment phase of the KeptnApp.
+	// In contrast to PostDeploymentTasks, each item can declare the tasks it depends on
+	// and a condition for its execution.
+	// The tasks of both lists are executed during the post-deployment phase.
+	// +optional
+	PostDeploymentTaskRefs []TaskReference `json:"postDeploymentTaskRefs,omitempty"`
+	// PromotionTaskRefs is a structured list of tasks to be performed during the promotion phase of the KeptnApp.
+	// In contrast to PromotionTasks, each item can declare the tasks it depends on
+	// and a condition for its execution.
+	// The tasks of both lists are executed during the promotion phase.
+	// +optional
+	PromotionTaskRefs []TaskReference `json:"promotionTaskRefs,omitempty"`
+	// PreDeploymentEvaluationRefs is a structured list of evaluations to be performed during the pre-deployment phase of the KeptnApp.
+	// In contrast to PreDeploymentEvaluations, each item can declare a condition for its execution.
+	// The evaluations of both lists are executed during the pre-deployment phase.
+	// +optional
+	PreDeploymentEvaluationRefs []EvaluationReference `json:"preDeploymentEvaluationRefs,omitempty"`
+	// PostDeploymentEvaluationRefs is a structured list of evaluations to be performed during the post-deployment phase of the KeptnApp.
+	// In contrast to PostDeploymentEvaluations, each item can declare a condition for its execution.
+	// The evaluations of both lists are executed during the post-deployment phase.
+	// +optional
+	PostDeploymentEvaluationRefs []EvaluationReference `json:"postDeploymentEvaluationRefs,omitempty"`
+	// PreDeploymentAnalyses is a list of all analyses to be performed
+	// during the pre-deployment evaluation phase of the KeptnApp.
+	// The items of this list refer to the names of AnalysisDefinitions
+	// located in the same namespace as the KeptnApp, or in the Keptn namespace.
+	// +optional
+	PreDeploymentAnalyses []string `json:"preDeploymentAnalyses,omitempty"`
+	// PostDeploymentAnalyses is a list of all analyses to be performed
+	// during the post-deployment evaluation phase of the KeptnApp.
+	// The items of this list refer to the names of AnalysisDefinitions
+	// located in the same namespace as the KeptnApp, or in the Keptn namespace.
+	// +optional
+	PostDeploymentAnalyses []string `json:"postDeploymentAnalyses,omitempty"`
+}
+
+// TaskReference refers to a KeptnTaskDefinition that is executed during a phase of a KeptnApp or KeptnWorkload
+type TaskReference struct {
+	// Name is the name of the referenced KeptnTaskDefinition,
+	// located in the same namespace as the KeptnApp, or in the Keptn namespace.
+	// KeptnTaskDefinitions of a task definition library can be referenced as <namespace>/<name>.
+	Name string `json:"name"`
+	// DependsOn is a list of names of tasks of the same phase that need to succeed before this task is started.
+	// If one of these tasks fails or is skipped, this task is skipped as well.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// When is a CEL expression that needs to evaluate to true for the task to be executed.
+	// If the expression evaluates to false, the task is skipped.
+	// If it cannot be evaluated, e.g. because a metadata key is not set, the task fails.
+	// Optional metadata keys can be checked with has(metadata.<key>) or "<key>" in metadata.
+	// The expression can refer to the variables appName, appVersion, workloadName, workloadVersion,
+	// version, previousVersion, objectType, checkType and metadata.
+	// +optional
+	When string `json:"when,omitempty"`
+}
+
+// EvaluationReference refers to a KeptnEvaluationDefinition that is executed during a phase of a KeptnApp or KeptnWorkload
+type EvaluationReference struct {
+	// Name is the name of the referenced KeptnEvaluationDefinition,
+	// located in the same namespace as the KeptnApp, or in the Keptn namespace.
+	Name string `json:"name"`
+	// When is a CEL expression that needs to evaluate to true for the evaluation to be executed.
+	// If the expression evaluates to false, the evaluation is skipped.
+	// If it cannot be evaluated, e.g. because a metadata key is not set, the evaluation fails.
+	// Optional metadata keys can be checked with has(metadata.<key>) or "<key>" in metadata.
+	// The expression can refer to the variables appName, appVersion, workloadName, workloadVersion,
+	// version, previousVersion, objectType, checkType and metadata.
+	// +optional
+	When string `json:"when,omitempty"`
+}
+
+// mergeTaskReferences returns the tasks of a phase defined as plain list of task names and as structured task references.
+// Structured task references take precedence over plain task names.
+func mergeTaskReferences(tasks []string, taskRefs []TaskReference) []TaskReference {
+	result := make([]TaskReference, 0, len(tasks)+len(taskRefs))
+	for _, task := range tasks {
+		if !containsTaskReference(taskRefs, task) {
+			result = append(result, TaskReference{Name: task})
+		}
+	}
+	return append(result, taskRefs...)
+}
+
+func containsTaskReference(taskRefs []TaskReference, name string) bool {
+	for _, ref := range taskRefs {
+		if ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeEvaluationReferences returns the evaluations of a phase defined as plain list of evaluation names and as structured evaluation references.
+// Structured evaluation references take precedence over plain evaluation names.
+func mergeEvaluationReferences(evaluations []string, evaluationRefs []EvaluationReference) []EvaluationReference {
+	result := make([]EvaluationReference, 0, len(evaluations)+len(evaluationRefs))
+	for _, evaluation := range evaluations {
+		if !containsEvaluationReference(evaluationRefs, evaluation) {
+			result = append(result, EvaluationReference{Name: evaluation})
+		}
+	}
+	return append(result, evaluationRefs...)
+}
+
+func containsEvaluationReference(evaluationRefs []EvaluationReference, name string) bool {
+	for _, ref := range evaluationRefs {
+		if ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func getEvaluationNames(evaluationRefs []EvaluationReference) []string {
+	result := make([]string, 0, len(evaluationRefs))
+	for _, ref := range evaluationRefs {
+		result = append(result, ref.Name)
+	}
+	return result
+}
+
+func getTaskNames(taskRefs []TaskReference) []string {
+	result := make([]string, 0, len(taskRefs))
+	for _, ref := range taskRefs {
+		result = append(result, ref.Name)
+	}
+	return result
 }
 
 // KeptnAppContextSpec defines the desired state of KeptnAppContext
@@ -57,6 +195,51 @@ type KeptnAppContextSpec struct {
 	// SpanLinks are links to OpenTelemetry span IDs for tracking. These links establish relationships between spans across different services, enabling distributed tracing.
 	// For more information on OpenTelemetry span links, refer to the documentation: https://opentelemetry.io/docs/concepts/signals/traces/#span-links
 	SpanLinks []string `json:"spanLinks,omitempty"`
+
+	// +optional
+	// RollbackOnFailure enables the automatic rollback of the workloads of a KeptnApp to the previously deployed version
+	// if the post-deployment evaluations of a new KeptnAppVersion fail.
+	// The workloads are rolled back to the state described by the KeptnWorkloadVersions of the previous KeptnAppVersion.
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty"`
+
+	// +optional
+	// Approval defines a manual sign-off that is required after the post-deployment evaluations of a KeptnAppVersion
+	// succeeded and before its promotion phase is started.
+	// If not set, no approval is required.
+	Approval *ApprovalSpec `json:"approval,omitempty"`
+
+	// +optional
+	// ObservabilityTimeout specifies the maximum time to observe the deployment phase of the KeptnWorkloads of the KeptnApp.
+	// It takes precedence over the ObservabilityTimeout of the KeptnConfig,
+	// and can be overridden by the ObservabilityTimeout of each KeptnWorkload.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	ObservabilityTimeout *metav1.Duration `json:"observabilityTimeout,omitempty"`
+
+	// +optional
+	// AnalysisTimeframe specifies the length of the timeframe evaluated by the pre- and post-deployment analyses of the KeptnApp.
+	// Pre-deployment analyses evaluate the timeframe right before the deployment of the KeptnAppVersion started,
+	// post-deployment analyses evaluate the timeframe right after all KeptnWorkloads have been deployed.
+	// If not set, a timeframe of 5m is used.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	AnalysisTimeframe *metav1.Duration `json:"analysisTimeframe,omitempty"`
+}
+
+// ApprovalSpec defines the approvals a KeptnAppVersion requires before being promoted
+type ApprovalSpec struct {
+	// RequiredApprovals is the number of distinct approvers that need to approve the KeptnAppVersion.
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	RequiredApprovals int `json:"requiredApprovals,omitempty"`
+	// Timeout specifies the maximum time to wait for the required approvals.
+	// If the KeptnAppVersion is not approved within this time frame, the approval phase fails.
+	// If not set, the approval phase waits indefinitely.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
 }
 
 // KeptnAppContextStatus defines the observed state of KeptnAppContext
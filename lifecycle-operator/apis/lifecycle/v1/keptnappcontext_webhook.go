@@ -0,0 +1,176 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"strings"
+
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/common/expression"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var keptnappcontextlog = logf.Log.WithName("keptnappcontext-resource")
+
+func (r *KeptnAppContext) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-lifecycle-keptn-sh-v1-keptnappcontext,mutating=false,failurePolicy=fail,sideEffects=None,groups=lifecycle.keptn.sh,resources=keptnappcontexts,verbs=create;update,versions=v1,name=vkeptnappcontext.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &KeptnAppContext{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnAppContext) ValidateCreate() (admission.Warnings, error) {
+	keptnappcontextlog.Info("validate create", "name", r.Name)
+
+	return []string{}, r.validateKeptnAppContext()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnAppContext) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	keptnappcontextlog.Info("validate update", "name", r.Name)
+
+	return []string{}, r.validateKeptnAppContext()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnAppContext) ValidateDelete() (admission.Warnings, error) {
+	keptnappcontextlog.Info("validate delete", "name", r.Name)
+
+	return []string{}, nil
+}
+
+func (r *KeptnAppContext) validateKeptnAppContext() error {
+	var allErrs field.ErrorList //defined as a list to allow returning multiple validation errors
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateTaskReferences(specPath.Child("preDeploymentTaskRefs"), r.Spec.PreDeploymentTasks, r.Spec.PreDeploymentTaskRefs)...)
+	allErrs = append(allErrs, validateTaskReferences(specPath.Child("postDeploymentTaskRefs"), r.Spec.PostDeploymentTasks, r.Spec.PostDeploymentTaskRefs)...)
+	allErrs = append(allErrs, validateTaskReferences(specPath.Child("promotionTaskRefs"), r.Spec.PromotionTasks, r.Spec.PromotionTaskRefs)...)
+	allErrs = append(allErrs, validateEvaluationReferences(specPath.Child("preDeploymentEvaluationRefs"), r.Spec.PreDeploymentEvaluationRefs)...)
+	allErrs = append(allErrs, validateEvaluationReferences(specPath.Child("postDeploymentEvaluationRefs"), r.Spec.PostDeploymentEvaluationRefs)...)
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnAppContext"},
+		r.Name,
+		allErrs)
+}
+
+// validateTaskReferences checks that the dependencies of the tasks of a phase refer to tasks of the same phase
+// and do not contain any cycles, and that the conditions of the tasks are valid expressions
+func validateTaskReferences(path *field.Path, tasks []string, taskRefs []TaskReference) field.ErrorList {
+	var allErrs field.ErrorList
+	merged := mergeTaskReferences(tasks, taskRefs)
+	dependencies := make(map[string][]string, len(merged))
+	for _, ref := range merged {
+		dependencies[ref.Name] = ref.DependsOn
+	}
+
+	for i, ref := range taskRefs {
+		allErrs = append(allErrs, validateCondition(path.Index(i).Child("when"), ref.When)...)
+		for j, dependency := range ref.DependsOn {
+			if _, ok := dependencies[dependency]; !ok {
+				allErrs = append(allErrs, field.Invalid(
+					path.Index(i).Child("dependsOn").Index(j),
+					dependency,
+					"the task must be part of the same phase",
+				))
+			}
+		}
+	}
+
+	if cycle := findDependencyCycle(merged, dependencies); len(cycle) > 0 {
+		allErrs = append(allErrs, field.Invalid(
+			path,
+			strings.Join(cycle, " -> "),
+			"the dependencies of the tasks must not contain cycles",
+		))
+	}
+	return allErrs
+}
+
+// validateEvaluationReferences checks that the conditions of the evaluations of a phase are valid expressions
+func validateEvaluationReferences(path *field.Path, evaluationRefs []EvaluationReference) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, ref := range evaluationRefs {
+		allErrs = append(allErrs, validateCondition(path.Index(i).Child("when"), ref.When)...)
+	}
+	return allErrs
+}
+
+func validateCondition(path *field.Path, condition string) field.ErrorList {
+	if condition == "" {
+		return nil
+	}
+	if err := expression.Validate(condition); err != nil {
+		return field.ErrorList{field.Invalid(path, condition, err.Error())}
+	}
+	return nil
+}
+
+// findDependencyCycle returns the names of the tasks forming a dependency cycle, or nil if there is none
+func findDependencyCycle(tasks []TaskReference, dependencies map[string][]string) []string {
+	const (
+		inProgress = iota + 1
+		done
+	)
+	state := make(map[string]int, len(tasks))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case inProgress:
+			for i, n := range path {
+				if n == name {
+					return append(append([]string{}, path[i:]...), name)
+				}
+			}
+		case done:
+			return nil
+		}
+		state[name] = inProgress
+		path = append(path, name)
+		for _, dependency := range dependencies[name] {
+			if cycle := visit(dependency); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		return nil
+	}
+
+	for _, task := range tasks {
+		if cycle := visit(task.Name); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
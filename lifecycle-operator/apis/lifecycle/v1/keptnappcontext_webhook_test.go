@@ -0,0 +1,161 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/common/expression"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestKeptnAppContext_ValidateTaskReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		spec DeploymentTaskSpec
+		want error
+	}{
+		{
+			name: "plain tasks",
+			spec: DeploymentTaskSpec{
+				PreDeploymentTasks: []string{"task-1", "task-2"},
+			},
+		},
+		{
+			name: "valid dependencies",
+			spec: DeploymentTaskSpec{
+				PreDeploymentTasks: []string{"task-1"},
+				PreDeploymentTaskRefs: []TaskReference{
+					{Name: "task-2", DependsOn: []string{"task-1"}},
+					{Name: "task-3", DependsOn: []string{"task-1", "task-2"}},
+				},
+			},
+		},
+		{
+			name: "dependency of another phase",
+			spec: DeploymentTaskSpec{
+				PreDeploymentTasks: []string{"task-1"},
+				PostDeploymentTaskRefs: []TaskReference{
+					{Name: "task-2", DependsOn: []string{"task-1"}},
+				},
+			},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnAppContext"},
+				"dependency of another phase",
+				field.ErrorList{
+					field.Invalid(
+						field.NewPath("spec").Child("postDeploymentTaskRefs").Index(0).Child("dependsOn").Index(0),
+						"task-1",
+						"the task must be part of the same phase",
+					),
+				},
+			),
+		},
+		{
+			name: "dependency cycle",
+			spec: DeploymentTaskSpec{
+				PromotionTaskRefs: []TaskReference{
+					{Name: "task-1", DependsOn: []string{"task-3"}},
+					{Name: "task-2", DependsOn: []string{"task-1"}},
+					{Name: "task-3", DependsOn: []string{"task-2"}},
+				},
+			},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnAppContext"},
+				"dependency cycle",
+				field.ErrorList{
+					field.Invalid(
+						field.NewPath("spec").Child("promotionTaskRefs"),
+						"task-1 -> task-3 -> task-2 -> task-1",
+						"the dependencies of the tasks must not contain cycles",
+					),
+				},
+			),
+		},
+		{
+			name: "task depending on itself",
+			spec: DeploymentTaskSpec{
+				PreDeploymentTaskRefs: []TaskReference{
+					{Name: "task-1", DependsOn: []string{"task-1"}},
+				},
+			},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnAppContext"},
+				"task depending on itself",
+				field.ErrorList{
+					field.Invalid(
+						field.NewPath("spec").Child("preDeploymentTaskRefs"),
+						"task-1 -> task-1",
+						"the dependencies of the tasks must not contain cycles",
+					),
+				},
+			),
+		},
+		{
+			name: "valid conditions",
+			spec: DeploymentTaskSpec{
+				PreDeploymentTaskRefs: []TaskReference{
+					{Name: "task-1", When: `metadata["env"] == "prod"`},
+				},
+				PostDeploymentEvaluationRefs: []EvaluationReference{
+					{Name: "evaluation-1", When: "majorVersion(version) > majorVersion(previousVersion)"},
+				},
+			},
+		},
+		{
+			name: "invalid conditions",
+			spec: DeploymentTaskSpec{
+				PromotionTaskRefs: []TaskReference{
+					{Name: "task-1", When: "appName"},
+				},
+				PreDeploymentEvaluationRefs: []EvaluationReference{
+					{Name: "evaluation-1", When: "unknown == 'value'"},
+				},
+			},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnAppContext"},
+				"invalid conditions",
+				field.ErrorList{
+					field.Invalid(
+						field.NewPath("spec").Child("promotionTaskRefs").Index(0).Child("when"),
+						"appName",
+						expression.Validate("appName").Error(),
+					),
+					field.Invalid(
+						field.NewPath("spec").Child("preDeploymentEvaluationRefs").Index(0).Child("when"),
+						"unknown == 'value'",
+						expression.Validate("unknown == 'value'").Error(),
+					),
+				},
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appContext := &KeptnAppContext{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.name},
+				Spec: KeptnAppContextSpec{
+					DeploymentTaskSpec: tt.spec,
+				},
+			}
+			_, err := appContext.ValidateCreate()
+			if tt.want == nil {
+				require.Nil(t, err)
+			} else {
+				require.Equal(t, tt.want, err)
+			}
+
+			_, err = appContext.ValidateUpdate(&KeptnAppContext{})
+			if tt.want == nil {
+				require.Nil(t, err)
+			} else {
+				require.Equal(t, tt.want, err)
+			}
+
+			_, err = appContext.ValidateDelete()
+			require.Nil(t, err)
+		})
+	}
+}
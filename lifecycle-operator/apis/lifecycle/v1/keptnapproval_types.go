@@ -0,0 +1,82 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApprovalDecision is the decision taken by an approver
+type ApprovalDecision string
+
+const (
+	ApprovalDecisionApproved ApprovalDecision = "Approved"
+	ApprovalDecisionRejected ApprovalDecision = "Rejected"
+)
+
+// KeptnApprovalSpec defines the desired state of KeptnApproval
+type KeptnApprovalSpec struct {
+	// AppVersion is the name of the KeptnAppVersion the KeptnApproval refers to.
+	AppVersion string `json:"appVersion"`
+	// Approver is the user name of the person or system taking the decision.
+	// Unless the KeptnApproval is created by the lifecycle-operator, it has to match the user creating it.
+	Approver string `json:"approver"`
+	// Decision is either Approved or Rejected.
+	// A single rejection fails the approval phase of the KeptnAppVersion.
+	// +kubebuilder:validation:Enum:=Approved;Rejected
+	// +kubebuilder:default:=Approved
+	// +optional
+	Decision ApprovalDecision `json:"decision,omitempty"`
+	// Comment contains an optional justification of the decision.
+	// +optional
+	Comment string `json:"comment,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="AppVersion",type=string,JSONPath=`.spec.appVersion`
+// +kubebuilder:printcolumn:name="Approver",type=string,JSONPath=`.spec.approver`
+// +kubebuilder:printcolumn:name="Decision",type=string,JSONPath=`.spec.decision`
+
+// KeptnApproval is the Schema for the keptnapprovals API
+type KeptnApproval struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec describes the desired state of the KeptnApproval.
+	// +optional
+	Spec KeptnApprovalSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KeptnApprovalList contains a list of KeptnApproval
+type KeptnApprovalList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KeptnApproval `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeptnApproval{}, &KeptnApprovalList{})
+}
+
+func (a KeptnApproval) IsRejected() bool {
+	return a.Spec.Decision == ApprovalDecisionRejected
+}
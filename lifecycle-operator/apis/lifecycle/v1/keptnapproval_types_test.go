@@ -0,0 +1,21 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeptnApproval_IsRejected(t *testing.T) {
+	approval := KeptnApproval{
+		Spec: KeptnApprovalSpec{
+			AppVersion: "my-app-1.0.0-6b86b273",
+			Approver:   "alice",
+			Decision:   ApprovalDecisionApproved,
+		},
+	}
+	require.False(t, approval.IsRejected())
+
+	approval.Spec.Decision = ApprovalDecisionRejected
+	require.True(t, approval.IsRejected())
+}
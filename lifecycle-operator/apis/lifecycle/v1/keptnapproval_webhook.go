@@ -0,0 +1,114 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var keptnapprovallog = logf.Log.WithName("keptnapproval-resource")
+
+// SetupWebhookWithManager registers the validation of KeptnApprovals.
+// The operatorUsername is the user name of the lifecycle-operator, which creates KeptnApprovals
+// on behalf of the callers it authenticated itself and may therefore set any approver.
+func (r *KeptnApproval) SetupWebhookWithManager(mgr ctrl.Manager, operatorUsername string) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&KeptnApprovalValidator{OperatorUsername: operatorUsername}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-lifecycle-keptn-sh-v1-keptnapproval,mutating=false,failurePolicy=fail,sideEffects=None,groups=lifecycle.keptn.sh,resources=keptnapprovals,verbs=create;update,versions=v1,name=vkeptnapproval.kb.io,admissionReviewVersions=v1
+
+// KeptnApprovalValidator binds the approver of a KeptnApproval to the user creating it,
+// so that a single user cannot fulfill the required approvals of a KeptnAppVersion on its own
+// +kubebuilder:object:generate=false
+type KeptnApprovalValidator struct {
+	OperatorUsername string
+}
+
+var _ webhook.CustomValidator = &KeptnApprovalValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *KeptnApprovalValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	approval, ok := obj.(*KeptnApproval)
+	if !ok {
+		return nil, fmt.Errorf("expected a KeptnApproval but got %T", obj)
+	}
+	keptnapprovallog.Info("validate create", "name", approval.Name)
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	username := req.UserInfo.Username
+	if username == v.OperatorUsername || approval.Spec.Approver == username {
+		return []string{}, nil
+	}
+
+	return []string{}, newKeptnApprovalError(approval.Name, field.Forbidden(
+		field.NewPath("spec").Child("approver"),
+		fmt.Sprintf("must match the user name %s of the requester", username),
+	))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *KeptnApprovalValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldApproval, ok := oldObj.(*KeptnApproval)
+	if !ok {
+		return nil, fmt.Errorf("expected a KeptnApproval but got %T", oldObj)
+	}
+	approval, ok := newObj.(*KeptnApproval)
+	if !ok {
+		return nil, fmt.Errorf("expected a KeptnApproval but got %T", newObj)
+	}
+	keptnapprovallog.Info("validate update", "name", approval.Name)
+
+	if reflect.DeepEqual(oldApproval.Spec, approval.Spec) {
+		return []string{}, nil
+	}
+
+	return []string{}, newKeptnApprovalError(approval.Name, field.Forbidden(
+		field.NewPath("spec"),
+		"is immutable",
+	))
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *KeptnApprovalValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return []string{}, nil
+}
+
+func newKeptnApprovalError(name string, err *field.Error) error {
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnApproval"},
+		name,
+		field.ErrorList{err},
+	)
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestKeptnApprovalValidator_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		requester string
+		approver  string
+		wantErr   bool
+	}{
+		{
+			name:      "approver is the requester",
+			requester: "alice",
+			approver:  "alice",
+		},
+		{
+			name:      "approver is someone else",
+			requester: "alice",
+			approver:  "bob",
+			wantErr:   true,
+		},
+		{
+			name:      "created by the lifecycle-operator",
+			requester: "system:serviceaccount:keptn-system:lifecycle-operator",
+			approver:  "bob",
+		},
+	}
+
+	validator := &KeptnApprovalValidator{OperatorUsername: "system:serviceaccount:keptn-system:lifecycle-operator"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					UserInfo: authenticationv1.UserInfo{Username: tt.requester},
+				},
+			})
+			approval := &KeptnApproval{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-approval"},
+				Spec: KeptnApprovalSpec{
+					AppVersion: "my-app-1.0.0",
+					Approver:   tt.approver,
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, approval)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.Contains(t, err.Error(), "spec.approver")
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestKeptnApprovalValidator_ValidateUpdate(t *testing.T) {
+	validator := &KeptnApprovalValidator{}
+	approval := &KeptnApproval{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-approval"},
+		Spec: KeptnApprovalSpec{
+			AppVersion: "my-app-1.0.0",
+			Approver:   "alice",
+			Decision:   ApprovalDecisionRejected,
+		},
+	}
+
+	updated := approval.DeepCopy()
+	updated.Labels = map[string]string{"team": "a"}
+	_, err := validator.ValidateUpdate(context.TODO(), approval, updated)
+	require.Nil(t, err)
+
+	updated.Spec.Decision = ApprovalDecisionApproved
+	_, err = validator.ValidateUpdate(context.TODO(), approval, updated)
+	require.NotNil(t, err)
+
+	_, err = validator.ValidateDelete(context.TODO(), approval)
+	require.Nil(t, err)
+}
@@ -64,6 +64,20 @@ type KeptnAppVersionStatus struct {
 	// +kubebuilder:default:=Pending
 	// +optional
 	PostDeploymentEvaluationStatus common.KeptnState `json:"postDeploymentEvaluationStatus,omitempty"`
+	// RollbackStatus indicates the current status of the KeptnAppVersion's Rollback phase.
+	// +kubebuilder:default:=Pending
+	// +optional
+	RollbackStatus common.KeptnState `json:"rollbackStatus,omitempty"`
+	// ApprovalStatus indicates the current status of the KeptnAppVersion's Approval phase.
+	// +kubebuilder:default:=Pending
+	// +optional
+	ApprovalStatus common.KeptnState `json:"approvalStatus,omitempty"`
+	// Approvers contains the names of the approvers that approved the KeptnAppVersion.
+	// +optional
+	Approvers []string `json:"approvers,omitempty"`
+	// ApprovalStartTime represents the time at which the KeptnAppVersion started to wait for approvals.
+	// +optional
+	ApprovalStartTime metav1.Time `json:"approvalStartTime,omitempty"`
 	// WorkloadOverallStatus indicates the current status of the KeptnAppVersion's Workload deployment phase.
 	// +kubebuilder:default:=Pending
 	// +optional
@@ -89,6 +103,12 @@ type KeptnAppVersionStatus struct {
 	// PostDeploymentEvaluationTaskStatus indicates the current state of each postDeploymentEvaluation of the KeptnAppVersion.
 	// +optional
 	PostDeploymentEvaluationTaskStatus []ItemStatus `json:"postDeploymentEvaluationTaskStatus,omitempty"`
+	// PreDeploymentAnalysisStatus indicates the current state of each preDeploymentAnalysis of the KeptnAppVersion.
+	// +optional
+	PreDeploymentAnalysisStatus []ItemStatus `json:"preDeploymentAnalysisStatus,omitempty"`
+	// PostDeploymentAnalysisStatus indicates the current state of each postDeploymentAnalysis of the KeptnAppVersion.
+	// +optional
+	PostDeploymentAnalysisStatus []ItemStatus `json:"postDeploymentAnalysisStatus,omitempty"`
 	// PhaseTraceIDs contains the trace IDs of the OpenTelemetry spans of each phase of the KeptnAppVersion.
 	// +optional
 	PhaseTraceIDs common.PhaseTraceID `json:"phaseTraceIDs,omitempty"`
@@ -103,6 +123,9 @@ type KeptnAppVersionStatus struct {
 	// EndTime represents the time at which the deployment of the KeptnAppVersion finished.
 	// +optional
 	EndTime metav1.Time `json:"endTime,omitempty"`
+	// WorkloadDeploymentEndTime represents the time at which the deployment of all KeptnWorkloads of the KeptnAppVersion finished.
+	// +optional
+	WorkloadDeploymentEndTime metav1.Time `json:"workloadDeploymentEndTime,omitempty"`
 }
 
 type WorkloadStatus struct {
@@ -127,7 +150,9 @@ type WorkloadStatus struct {
 // +kubebuilder:printcolumn:name="WorkloadOverallStatus",priority=1,type=string,JSONPath=`.status.workloadOverallStatus`
 // +kubebuilder:printcolumn:name="PostDeploymentStatus",priority=1,type=string,JSONPath=`.status.postDeploymentStatus`
 // +kubebuilder:printcolumn:name="PostDeploymentEvaluationStatus",priority=1,type=string,JSONPath=`.status.postDeploymentEvaluationStatus`
+// +kubebuilder:printcolumn:name="ApprovalStatus",priority=1,type=string,JSONPath=`.status.approvalStatus`
 // +kubebuilder:printcolumn:name="PromotionStatus",priority=1,type=string,JSONPath=`.status.promotionStatus`
+// +kubebuilder:printcolumn:name="RollbackStatus",priority=1,type=string,JSONPath=`.status.rollbackStatus`
 
 // KeptnAppVersion is the Schema for the keptnappversions API
 type KeptnAppVersion struct {
@@ -247,6 +272,33 @@ func (a KeptnAppVersion) IsPromotionSucceeded() bool {
 	return a.Status.PromotionStatus.IsSucceeded()
 }
 
+func (a KeptnAppVersion) IsRollbackEnabled() bool {
+	return a.Spec.RollbackOnFailure && a.Spec.PreviousVersion != ""
+}
+
+func (a KeptnAppVersion) IsRollbackCompleted() bool {
+	return a.Status.RollbackStatus.IsCompleted()
+}
+
+func (a KeptnAppVersion) IsApprovalRequired() bool {
+	return a.Spec.Approval != nil
+}
+
+func (a KeptnAppVersion) IsApprovalCompleted() bool {
+	return a.Status.ApprovalStatus.IsCompleted()
+}
+
+func (a KeptnAppVersion) IsApprovalSucceeded() bool {
+	return a.Status.ApprovalStatus.IsSucceeded()
+}
+
+func (a KeptnAppVersion) GetRequiredApprovals() int {
+	if a.Spec.Approval == nil || a.Spec.Approval.RequiredApprovals < 1 {
+		return 1
+	}
+	return a.Spec.Approval.RequiredApprovals
+}
+
 func (a KeptnAppVersion) AreWorkloadsCompleted() bool {
 	return a.Status.WorkloadOverallStatus.IsCompleted()
 }
@@ -287,6 +339,23 @@ func (a *KeptnAppVersion) IsEndTimeSet() bool {
 	return !a.Status.EndTime.IsZero()
 }
 
+func (a *KeptnAppVersion) SetWorkloadDeploymentEndTime() {
+	if a.Status.WorkloadDeploymentEndTime.IsZero() {
+		a.Status.WorkloadDeploymentEndTime = metav1.NewTime(time.Now().UTC())
+	}
+}
+
+func (a KeptnAppVersion) GetDeploymentEndTime() time.Time {
+	return a.Status.WorkloadDeploymentEndTime.Time
+}
+
+func (a KeptnAppVersion) GetAnalysisTimeframe() time.Duration {
+	if a.Spec.AnalysisTimeframe != nil {
+		return a.Spec.AnalysisTimeframe.Duration
+	}
+	return DefaultAnalysisTimeframe
+}
+
 func (a *KeptnAppVersion) Complete() {
 	a.SetEndTime()
 }
@@ -322,15 +391,27 @@ func (a KeptnAppVersion) GetState() common.KeptnState {
 }
 
 func (a KeptnAppVersion) GetPreDeploymentTasks() []string {
-	return a.Spec.PreDeploymentTasks
+	return getTaskNames(a.GetTaskReferences(common.PreDeploymentCheckType))
 }
 
 func (a KeptnAppVersion) GetPostDeploymentTasks() []string {
-	return a.Spec.PostDeploymentTasks
+	return getTaskNames(a.GetTaskReferences(common.PostDeploymentCheckType))
 }
 
 func (a KeptnAppVersion) GetPromotionTasks() []string {
-	return a.Spec.PromotionTasks
+	return getTaskNames(a.GetTaskReferences(common.PromotionCheckType))
+}
+
+func (a KeptnAppVersion) GetTaskReferences(checkType common.CheckType) []TaskReference {
+	switch checkType {
+	case common.PreDeploymentCheckType:
+		return mergeTaskReferences(a.Spec.PreDeploymentTasks, a.Spec.PreDeploymentTaskRefs)
+	case common.PostDeploymentCheckType:
+		return mergeTaskReferences(a.Spec.PostDeploymentTasks, a.Spec.PostDeploymentTaskRefs)
+	case common.PromotionCheckType:
+		return mergeTaskReferences(a.Spec.PromotionTasks, a.Spec.PromotionTaskRefs)
+	}
+	return []TaskReference{}
 }
 
 func (a KeptnAppVersion) GetPreDeploymentTaskStatus() []ItemStatus {
@@ -342,11 +423,21 @@ func (a KeptnAppVersion) GetPostDeploymentTaskStatus() []ItemStatus {
 }
 
 func (a KeptnAppVersion) GetPreDeploymentEvaluations() []string {
-	return a.Spec.PreDeploymentEvaluations
+	return getEvaluationNames(a.GetEvaluationReferences(common.PreDeploymentEvaluationCheckType))
 }
 
 func (a KeptnAppVersion) GetPostDeploymentEvaluations() []string {
-	return a.Spec.PostDeploymentEvaluations
+	return getEvaluationNames(a.GetEvaluationReferences(common.PostDeploymentEvaluationCheckType))
+}
+
+func (a KeptnAppVersion) GetEvaluationReferences(checkType common.CheckType) []EvaluationReference {
+	switch checkType {
+	case common.PreDeploymentEvaluationCheckType:
+		return mergeEvaluationReferences(a.Spec.PreDeploymentEvaluations, a.Spec.PreDeploymentEvaluationRefs)
+	case common.PostDeploymentEvaluationCheckType:
+		return mergeEvaluationReferences(a.Spec.PostDeploymentEvaluations, a.Spec.PostDeploymentEvaluationRefs)
+	}
+	return []EvaluationReference{}
 }
 
 func (a KeptnAppVersion) GetPreDeploymentEvaluationTaskStatus() []ItemStatus {
@@ -357,6 +448,26 @@ func (a KeptnAppVersion) GetPostDeploymentEvaluationTaskStatus() []ItemStatus {
 	return a.Status.PostDeploymentEvaluationTaskStatus
 }
 
+func (a KeptnAppVersion) GetAnalyses(checkType common.CheckType) []string {
+	switch checkType {
+	case common.PreDeploymentEvaluationCheckType:
+		return a.Spec.PreDeploymentAnalyses
+	case common.PostDeploymentEvaluationCheckType:
+		return a.Spec.PostDeploymentAnalyses
+	}
+	return []string{}
+}
+
+func (a KeptnAppVersion) GetAnalysisStatus(checkType common.CheckType) []ItemStatus {
+	switch checkType {
+	case common.PreDeploymentEvaluationCheckType:
+		return a.Status.PreDeploymentAnalysisStatus
+	case common.PostDeploymentEvaluationCheckType:
+		return a.Status.PostDeploymentAnalysisStatus
+	}
+	return []ItemStatus{}
+}
+
 func (a KeptnAppVersion) GetPromotionTaskStatus() []ItemStatus {
 	return a.Status.PromotionTaskStatus
 }
@@ -414,6 +525,7 @@ func (a KeptnAppVersion) GenerateTask(taskDefinition KeptnTaskDefinition, checkT
 			Type:             checkType,
 			Retries:          taskDefinition.Spec.Retries,
 			Timeout:          taskDefinition.Spec.Timeout,
+			RetryPolicy:      taskDefinition.Spec.RetryPolicy,
 		},
 	}
 }
@@ -429,6 +541,7 @@ func (a KeptnAppVersion) GenerateEvaluation(evaluationDefinition KeptnEvaluation
 			AppName:              a.Spec.AppName,
 			EvaluationDefinition: evaluationDefinition.Name,
 			Type:                 checkType,
+			PreviousVersion:      a.Spec.PreviousVersion,
 			FailureConditions: FailureConditions{
 				RetryInterval: evaluationDefinition.Spec.FailureConditions.RetryInterval,
 				Retries:       evaluationDefinition.Spec.FailureConditions.Retries,
@@ -470,19 +583,26 @@ func (a *KeptnAppVersion) DeprecateRemainingPhases(phase common.KeptnPhaseType)
 	if phase == common.PhasePromotion {
 		return
 	}
-	// deprecate promotion tasks when post evaluation failed
+	// deprecate promotion tasks when approval failed
+	if phase == common.PhaseAppApproval {
+		a.Status.PromotionStatus = common.StateDeprecated
+	}
+	// deprecate approval and promotion tasks when post evaluation failed
 	if phase == common.PhaseAppPostEvaluation {
+		a.Status.ApprovalStatus = common.StateDeprecated
 		a.Status.PromotionStatus = common.StateDeprecated
 	}
 	// deprecate post evaluation when post tasks failed
 	if phase == common.PhaseAppPostDeployment {
 		a.Status.PostDeploymentEvaluationStatus = common.StateDeprecated
+		a.Status.ApprovalStatus = common.StateDeprecated
 		a.Status.PromotionStatus = common.StateDeprecated
 	}
 	// deprecate post evaluation and tasks when app deployment failed
 	if phase == common.PhaseAppDeployment {
 		a.Status.PostDeploymentStatus = common.StateDeprecated
 		a.Status.PostDeploymentEvaluationStatus = common.StateDeprecated
+		a.Status.ApprovalStatus = common.StateDeprecated
 		a.Status.PromotionStatus = common.StateDeprecated
 	}
 	// deprecate app deployment, post tasks and evaluations if app pre-eval failed
@@ -490,6 +610,7 @@ func (a *KeptnAppVersion) DeprecateRemainingPhases(phase common.KeptnPhaseType)
 		a.Status.PostDeploymentStatus = common.StateDeprecated
 		a.Status.PostDeploymentEvaluationStatus = common.StateDeprecated
 		a.Status.WorkloadOverallStatus = common.StateDeprecated
+		a.Status.ApprovalStatus = common.StateDeprecated
 		a.Status.PromotionStatus = common.StateDeprecated
 	}
 	// deprecate pre evaluations, app deployment and post tasks and evaluations when pre-tasks failed
@@ -498,6 +619,7 @@ func (a *KeptnAppVersion) DeprecateRemainingPhases(phase common.KeptnPhaseType)
 		a.Status.PostDeploymentEvaluationStatus = common.StateDeprecated
 		a.Status.WorkloadOverallStatus = common.StateDeprecated
 		a.Status.PreDeploymentEvaluationStatus = common.StateDeprecated
+		a.Status.ApprovalStatus = common.StateDeprecated
 		a.Status.PromotionStatus = common.StateDeprecated
 	}
 	// deprecate completely everything
@@ -507,6 +629,7 @@ func (a *KeptnAppVersion) DeprecateRemainingPhases(phase common.KeptnPhaseType)
 		a.Status.WorkloadOverallStatus = common.StateDeprecated
 		a.Status.PreDeploymentEvaluationStatus = common.StateDeprecated
 		a.Status.PreDeploymentStatus = common.StateDeprecated
+		a.Status.ApprovalStatus = common.StateDeprecated
 		a.Status.PromotionStatus = common.StateDeprecated
 		a.Status.Status = common.StateDeprecated
 		return
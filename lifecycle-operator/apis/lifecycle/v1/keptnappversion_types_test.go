@@ -101,6 +101,23 @@ func TestKeptnAppVersion(t *testing.T) {
 	require.False(t, app.IsPromotionSucceeded())
 	require.True(t, app.IsPromotionFailed())
 
+	require.False(t, app.IsRollbackEnabled())
+	require.False(t, app.IsRollbackCompleted())
+	app.Spec.RollbackOnFailure = true
+	app.Status.RollbackStatus = common.StateSucceeded
+	require.True(t, app.IsRollbackEnabled())
+	require.True(t, app.IsRollbackCompleted())
+
+	require.False(t, app.IsApprovalRequired())
+	require.Equal(t, 1, app.GetRequiredApprovals())
+	app.Spec.Approval = &ApprovalSpec{RequiredApprovals: 2}
+	require.True(t, app.IsApprovalRequired())
+	require.Equal(t, 2, app.GetRequiredApprovals())
+	require.False(t, app.IsApprovalCompleted())
+	app.Status.ApprovalStatus = common.StateSucceeded
+	require.True(t, app.IsApprovalCompleted())
+	require.True(t, app.IsApprovalSucceeded())
+
 	require.True(t, app.AreWorkloadsCompleted())
 	require.False(t, app.AreWorkloadsSucceeded())
 	require.True(t, app.AreWorkloadsFailed())
@@ -227,6 +244,7 @@ func TestKeptnAppVersion(t *testing.T) {
 	require.Equal(t, "trace1.appname.namespace.version.phase", app.GetSpanKey("phase"))
 
 	retries := int32(5)
+	retryPolicy := &RetryPolicy{Backoff: BackoffStrategyLinear}
 	task := app.GenerateTask(KeptnTaskDefinition{
 		ObjectMeta: v1.ObjectMeta{
 			Name: "task-def",
@@ -241,7 +259,8 @@ func TestKeptnAppVersion(t *testing.T) {
 			Timeout: v1.Duration{
 				Duration: 5 * time.Second,
 			},
-			Retries: &retries,
+			Retries:     &retries,
+			RetryPolicy: retryPolicy,
 		},
 	}, common.PostDeploymentCheckType)
 	require.Equal(t, KeptnTaskSpec{
@@ -258,7 +277,8 @@ func TestKeptnAppVersion(t *testing.T) {
 		Timeout: v1.Duration{
 			Duration: 5 * time.Second,
 		},
-		Retries: &retries,
+		Retries:     &retries,
+		RetryPolicy: retryPolicy,
 	}, task.Spec)
 
 	require.Equal(t, map[string]string{
@@ -287,6 +307,7 @@ func TestKeptnAppVersion(t *testing.T) {
 		AppName:              app.GetParentName(),
 		EvaluationDefinition: "eval-def",
 		Type:                 common.PostDeploymentCheckType,
+		PreviousVersion:      "prev",
 		FailureConditions: FailureConditions{
 			RetryInterval: v1.Duration{
 				Duration: 5 * time.Second,
@@ -354,6 +375,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 			PreDeploymentEvaluationStatus:  common.StatePending,
 			PostDeploymentStatus:           common.StatePending,
 			PostDeploymentEvaluationStatus: common.StatePending,
+			ApprovalStatus:                 common.StatePending,
 			PromotionStatus:                common.StatePending,
 			WorkloadOverallStatus:          common.StatePending,
 			Status:                         common.StatePending,
@@ -374,12 +396,29 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PreDeploymentEvaluationStatus:  common.StatePending,
 					PostDeploymentStatus:           common.StatePending,
 					PostDeploymentEvaluationStatus: common.StatePending,
+					ApprovalStatus:                 common.StatePending,
 					PromotionStatus:                common.StatePending,
 					WorkloadOverallStatus:          common.StatePending,
 					Status:                         common.StatePending,
 				},
 			},
 		},
+		{
+			app:   app,
+			phase: common.PhaseAppApproval,
+			want: KeptnAppVersion{
+				Status: KeptnAppVersionStatus{
+					PreDeploymentStatus:            common.StatePending,
+					PreDeploymentEvaluationStatus:  common.StatePending,
+					PostDeploymentStatus:           common.StatePending,
+					PostDeploymentEvaluationStatus: common.StatePending,
+					ApprovalStatus:                 common.StatePending,
+					PromotionStatus:                common.StateDeprecated,
+					WorkloadOverallStatus:          common.StatePending,
+					Status:                         common.StateFailed,
+				},
+			},
+		},
 		{
 			app:   app,
 			phase: common.PhaseAppPostEvaluation,
@@ -389,6 +428,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PreDeploymentEvaluationStatus:  common.StatePending,
 					PostDeploymentStatus:           common.StatePending,
 					PostDeploymentEvaluationStatus: common.StatePending,
+					ApprovalStatus:                 common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StatePending,
 					Status:                         common.StateFailed,
@@ -404,6 +444,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PreDeploymentEvaluationStatus:  common.StatePending,
 					PostDeploymentStatus:           common.StatePending,
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
+					ApprovalStatus:                 common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StatePending,
 					Status:                         common.StateFailed,
@@ -419,6 +460,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PreDeploymentEvaluationStatus:  common.StatePending,
 					PostDeploymentStatus:           common.StateDeprecated,
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
+					ApprovalStatus:                 common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StatePending,
 					Status:                         common.StateFailed,
@@ -434,6 +476,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PreDeploymentEvaluationStatus:  common.StatePending,
 					PostDeploymentStatus:           common.StateDeprecated,
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
+					ApprovalStatus:                 common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StateDeprecated,
 					Status:                         common.StateFailed,
@@ -449,6 +492,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PreDeploymentEvaluationStatus:  common.StateDeprecated,
 					PostDeploymentStatus:           common.StateDeprecated,
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
+					ApprovalStatus:                 common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StateDeprecated,
 					Status:                         common.StateFailed,
@@ -464,6 +508,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PreDeploymentEvaluationStatus:  common.StateDeprecated,
 					PostDeploymentStatus:           common.StateDeprecated,
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
+					ApprovalStatus:                 common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StateDeprecated,
 					Status:                         common.StateDeprecated,
@@ -479,6 +524,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PreDeploymentEvaluationStatus:  common.StatePending,
 					PostDeploymentStatus:           common.StatePending,
 					PostDeploymentEvaluationStatus: common.StatePending,
+					ApprovalStatus:                 common.StatePending,
 					PromotionStatus:                common.StatePending,
 					WorkloadOverallStatus:          common.StatePending,
 					Status:                         common.StateFailed,
@@ -532,6 +578,34 @@ func TestKeptnAppVersion_SetPhaseTraceID(t *testing.T) {
 	}, app)
 }
 
+func TestKeptnAppVersion_GetTaskReferences(t *testing.T) {
+	app := KeptnAppVersion{
+		Spec: KeptnAppVersionSpec{
+			KeptnAppContextSpec: KeptnAppContextSpec{
+				DeploymentTaskSpec: DeploymentTaskSpec{
+					PreDeploymentTasks: []string{"task1", "task2"},
+					PreDeploymentTaskRefs: []TaskReference{
+						{Name: "task2", DependsOn: []string{"task1"}},
+						{Name: "task3", DependsOn: []string{"task2"}},
+					},
+					PromotionTaskRefs: []TaskReference{
+						{Name: "promote"},
+					},
+				},
+			},
+		},
+	}
+
+	require.Equal(t, []TaskReference{
+		{Name: "task1"},
+		{Name: "task2", DependsOn: []string{"task1"}},
+		{Name: "task3", DependsOn: []string{"task2"}},
+	}, app.GetTaskReferences(common.PreDeploymentCheckType))
+	require.Equal(t, []string{"task1", "task2", "task3"}, app.GetPreDeploymentTasks())
+	require.Empty(t, app.GetTaskReferences(common.PostDeploymentCheckType))
+	require.Equal(t, []string{"promote"}, app.GetPromotionTasks())
+}
+
 func TestKeptnAppVersionList(t *testing.T) {
 	list := KeptnAppVersionList{
 		Items: []KeptnAppVersion{
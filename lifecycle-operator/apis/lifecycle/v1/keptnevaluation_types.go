@@ -48,6 +48,11 @@ type KeptnEvaluationSpec struct {
 	// Type indicates whether the KeptnEvaluation is part of the pre- or postDeployment phase.
 	// +optional
 	Type common.CheckType `json:"checkType,omitempty"`
+	// PreviousVersion defines the version of the KeptnApp or KeptnWorkload that has been deployed prior
+	// to the one the KeptnEvaluation is done for.
+	// It is used to look up the values of objectives with a relative evaluation target.
+	// +optional
+	PreviousVersion string `json:"previousVersion,omitempty"`
 	// FailureConditions represent the failure conditions (number of retries and retry interval)
 	// for the evaluation to be considered as failed
 	FailureConditions `json:",inline"`
@@ -66,6 +71,10 @@ type KeptnEvaluationStatus struct {
 	// referenced by the KeptnEvaluation.
 	// +kubebuilder:default:=Pending
 	OverallStatus common.KeptnState `json:"overallStatus"`
+	// Score represents the percentage of the weighted objectives that have been met in the latest attempt.
+	// It is only set if the referenced KeptnEvaluationDefinition defines a TotalScore.
+	// +optional
+	Score string `json:"score,omitempty"`
 	// StartTime represents the time at which the KeptnEvaluation started.
 	// +optional
 	StartTime metav1.Time `json:"startTime,omitempty"`
@@ -97,6 +106,7 @@ type EvaluationStatusItem struct {
 // +kubebuilder:printcolumn:name="RetryCount",type=string,JSONPath=`.status.retryCount`
 // +kubebuilder:printcolumn:name="EvaluationStatus",type=string,JSONPath=`.status.evaluationStatus`
 // +kubebuilder:printcolumn:name="OverallStatus",type=string,JSONPath=`.status.overallStatus`
+// +kubebuilder:printcolumn:name="Score",type=string,JSONPath=`.status.score`,priority=1
 
 // KeptnEvaluation is the Schema for the keptnevaluations API
 type KeptnEvaluation struct {
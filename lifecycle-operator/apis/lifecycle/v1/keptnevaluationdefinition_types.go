@@ -25,6 +25,11 @@ type KeptnEvaluationDefinitionSpec struct {
 	// Objectives is a list of objectives that have to be met for a KeptnEvaluation referencing this
 	// KeptnEvaluationDefinition to be successful.
 	Objectives []Objective `json:"objectives"`
+	// TotalScore defines the percentage of the weighted objectives a KeptnEvaluation referencing this
+	// KeptnEvaluationDefinition has to reach in order to pass, or to finish with a warning.
+	// If not set, all objectives have to be met for the KeptnEvaluation to be successful.
+	// +optional
+	TotalScore *TotalScore `json:"totalScore,omitempty"`
 	// FailureConditions represent the failure conditions (number of retries and retry interval)
 	// for the evaluation to be considered as failed
 	FailureConditions `json:",inline"`
@@ -51,8 +56,35 @@ type Objective struct {
 	// KeptnMetricRef references the KeptnMetric that should be evaluated.
 	KeptnMetricRef KeptnMetricReference `json:"keptnMetricRef"`
 	// EvaluationTarget specifies the target value for the references KeptnMetric.
-	// Needs to start with either '<' or '>', followed by the target value (e.g. '<10').
+	// A condition starts with one of '<', '<=', '>', '>=', '==' or '!=', followed by the target value (e.g. '<10'),
+	// or is an inclusive range (e.g. '10..50').
+	// If the target value is followed by '%', it is relative to the value of the previous version
+	// (e.g. '<=+10%' means at most 10% more than the previous value).
+	// Conditions can be combined with '&&' and '||' (e.g. '>10 && <50'), where '&&' takes precedence.
 	EvaluationTarget string `json:"evaluationTarget"`
+	// Weight can be used to emphasize the importance of one Objective over the others
+	// when computing the score of the KeptnEvaluation.
+	// +kubebuilder:default:=1
+	// +optional
+	Weight int `json:"weight,omitempty"`
+	// KeyObjective defines whether the whole KeptnEvaluation fails when this objective's target is not met,
+	// regardless of the score that has been reached.
+	// +kubebuilder:default:=false
+	// +optional
+	KeyObjective bool `json:"keyObjective,omitempty"`
+}
+
+// TotalScore defines the required score for a KeptnEvaluation to pass, or to finish with a warning
+type TotalScore struct {
+	// PassPercentage defines the threshold to reach for a KeptnEvaluation to pass.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	PassPercentage int `json:"passPercentage"`
+	// WarningPercentage defines the threshold to reach for a KeptnEvaluation to finish with a warning
+	// once all retries have been used up.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	WarningPercentage int `json:"warningPercentage"`
 }
 
 type KeptnMetricReference struct {
@@ -95,3 +127,11 @@ type KeptnEvaluationDefinitionList struct {
 func init() {
 	SchemeBuilder.Register(&KeptnEvaluationDefinition{}, &KeptnEvaluationDefinitionList{})
 }
+
+// GetWeight returns the weight of the Objective, defaulting to 1 if no weight is set.
+func (o Objective) GetWeight() int {
+	if o.Weight <= 0 {
+		return 1
+	}
+	return o.Weight
+}
@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestKeptnEvaluationDefinition_ValidateObjectives(t *testing.T) {
+	objectives := func(targets ...string) []Objective {
+		result := []Objective{}
+		for _, target := range targets {
+			result = append(result, Objective{
+				KeptnMetricRef:   KeptnMetricReference{Name: "metric"},
+				EvaluationTarget: target,
+			})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name    string
+		spec    KeptnEvaluationDefinitionSpec
+		want    error
+		verb    string
+		oldSpec runtime.Object
+	}{
+		{
+			name: "valid-targets",
+			spec: KeptnEvaluationDefinitionSpec{
+				Objectives: objectives("<10", ">=1 && <=2.5", "10..50", "<=+10%", "<5 || >100"),
+			},
+			verb: "create",
+		},
+		{
+			name: "invalid-targets",
+			spec: KeptnEvaluationDefinitionSpec{
+				Objectives: objectives("<10", "10", ">10 &&"),
+			},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnEvaluationDefinition"},
+				"invalid-targets",
+				[]*field.Error{
+					field.Invalid(
+						field.NewPath("spec").Child("objectives").Index(1).Child("evaluationTarget"),
+						"10",
+						"invalid evaluation target '10': condition '10' must start with one of <=, >=, ==, !=, <, > or be a range such as '10..50'",
+					),
+					field.Invalid(
+						field.NewPath("spec").Child("objectives").Index(2).Child("evaluationTarget"),
+						">10 &&",
+						"invalid evaluation target '>10 &&': empty condition",
+					),
+				},
+			),
+			verb: "create",
+		},
+		{
+			name: "update-with-invalid-target",
+			spec: KeptnEvaluationDefinitionSpec{
+				Objectives: objectives("=<10"),
+			},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnEvaluationDefinition"},
+				"update-with-invalid-target",
+				[]*field.Error{
+					field.Invalid(
+						field.NewPath("spec").Child("objectives").Index(0).Child("evaluationTarget"),
+						"=<10",
+						"invalid evaluation target '=<10': condition '=<10' must start with one of <=, >=, ==, !=, <, > or be a range such as '10..50'",
+					),
+				},
+			),
+			oldSpec: &KeptnEvaluationDefinition{},
+			verb:    "update",
+		},
+		{
+			name: "valid-total-score",
+			spec: KeptnEvaluationDefinitionSpec{
+				Objectives: objectives("<10"),
+				TotalScore: &TotalScore{PassPercentage: 90, WarningPercentage: 70},
+			},
+			verb: "create",
+		},
+		{
+			name: "invalid-total-score",
+			spec: KeptnEvaluationDefinitionSpec{
+				Objectives: objectives("<10"),
+				TotalScore: &TotalScore{PassPercentage: 70, WarningPercentage: 70},
+			},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnEvaluationDefinition"},
+				"invalid-total-score",
+				[]*field.Error{
+					field.Invalid(
+						field.NewPath("spec").Child("totalScore").Child("warningPercentage"),
+						70,
+						"warn percentage score cannot be higher or equal than Pass percentage score",
+					),
+				},
+			),
+			verb: "create",
+		},
+		{
+			name: "delete",
+			verb: "delete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ked := &KeptnEvaluationDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.name},
+				Spec:       tt.spec,
+			}
+
+			var got error
+			switch tt.verb {
+			case "create":
+				_, got = ked.ValidateCreate()
+			case "update":
+				_, got = ked.ValidateUpdate(tt.oldSpec)
+			case "delete":
+				_, got = ked.ValidateDelete()
+			}
+
+			if tt.want != nil {
+				require.NotNil(t, got)
+				require.EqualValues(t, tt.want, got)
+			} else {
+				require.Nil(t, got)
+			}
+		})
+	}
+}
@@ -27,12 +27,19 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// TaskQueuedReason is the reason of KeptnTasks waiting for a free slot before their Job is created
+const TaskQueuedReason = "Queued"
+
+// TaskFinishedByEventReason is the reason of KeptnTasks marked as finished by an external system
+const TaskFinishedByEventReason = "FinishedByEvent"
+
 // KeptnTaskSpec defines the desired state of KeptnTask
 type KeptnTaskSpec struct {
 	// TaskDefinition refers to the name of the KeptnTaskDefinition
 	// which includes the specification for the task to be performed.
 	// The KeptnTaskDefinition can be
 	// located in the same namespace as the KeptnTask, or in the Keptn namespace.
+	// KeptnTaskDefinitions of a task definition library are referenced as <namespace>/<name>.
 	TaskDefinition string `json:"taskDefinition"`
 	// Context contains contextual information about the task execution.
 	// +optional
@@ -44,6 +51,11 @@ type KeptnTaskSpec struct {
 	// These will be stored and accessed as secrets in the cluster.
 	// +optional
 	SecureParameters SecureParameters `json:"secureParameters,omitempty"`
+	// Mounts contains Secrets and ConfigMaps that will be made available to the job that executes the task,
+	// either as environment variables or as files.
+	// They replace the mounts with the same name of the KeptnTaskDefinition.
+	// +optional
+	Mounts []TaskMount `json:"mounts,omitempty"`
 	// Type indicates whether the KeptnTask is part of the pre- or postDeployment phase.
 	// +optional
 	Type common.CheckType `json:"checkType,omitempty"`
@@ -60,6 +72,12 @@ type KeptnTaskSpec struct {
 	// +kubebuilder:validation:Type:=string
 	// +optional
 	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// RetryPolicy defines the delay between the attempts of the KeptnTask
+	// and the exit codes for which it is retried.
+	// If set, each attempt is executed in a separate Job.
+	// If the KeptnTask does not complete successfully within the Timeout, only the current attempt fails.
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
 }
 
 type TaskContext struct {
@@ -84,6 +102,10 @@ type TaskContext struct {
 	// +optional
 	// Metadata contains additional key-value pairs for contextual information.
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// Outputs contains the outputs of the KeptnTasks that succeeded before this KeptnTask was created,
+	// indexed by the name of their KeptnTaskDefinition.
+	// +optional
+	Outputs map[string]map[string]string `json:"outputs,omitempty"`
 }
 
 type TaskParameters struct {
@@ -104,6 +126,29 @@ type SecureParameters struct {
 	Secret string `json:"secret,omitempty"`
 }
 
+// TaskMount makes the keys of a Secret or a ConfigMap available to the job executing the KeptnTask.
+type TaskMount struct {
+	// Name identifies the mount. If the keys are mounted as files, it is also used as name of the volume.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Secret is the name of the referenced Secret.
+	// Exactly one of Secret and ConfigMap must be set.
+	// +optional
+	Secret string `json:"secret,omitempty"`
+	// ConfigMap is the name of the referenced ConfigMap.
+	// Exactly one of Secret and ConfigMap must be set.
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
+	// Env maps the names of environment variables to keys of the referenced Secret or ConfigMap.
+	// If neither Env nor MountPath are set, all keys are made available as environment variables.
+	// +optional
+	Env map[string]string `json:"env,omitempty"`
+	// MountPath is the directory the keys of the referenced Secret or ConfigMap are mounted to as files.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+}
+
 // KeptnTaskStatus defines the observed state of KeptnTask
 type KeptnTaskStatus struct {
 	// JobName is the name of the Job executing the Task.
@@ -125,6 +170,24 @@ type KeptnTaskStatus struct {
 	// Reason contains more information about the reason for the last transition of the Job executing the KeptnTask.
 	// +optional
 	Reason string `json:"reason,omitempty"`
+	// TerminationReason contains the reason for the termination of the failed container
+	// of the Job executing the KeptnTask, e.g. Error or OOMKilled.
+	// +optional
+	TerminationReason string `json:"terminationReason,omitempty"`
+	// Logs contains the tail of the log of the failed container of the Job executing the KeptnTask.
+	// The excerpt is limited to 2048 bytes.
+	// +optional
+	Logs string `json:"logs,omitempty"`
+	// Attempt is the number of Jobs that have been created to execute the KeptnTask.
+	// +optional
+	Attempt int32 `json:"attempt,omitempty"`
+	// NextAttemptTime represents the time after which the next attempt of the KeptnTask is started.
+	// +optional
+	NextAttemptTime metav1.Time `json:"nextAttemptTime,omitempty"`
+	// Outputs contains the result document written by the Job executing the KeptnTask
+	// to its termination message file.
+	// +optional
+	Outputs map[string]string `json:"outputs,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -185,6 +248,16 @@ func (t *KeptnTask) SetEndTime() {
 	}
 }
 
+// IsQueued returns true if the KeptnTask waits for a free slot before its Job is created
+func (t KeptnTask) IsQueued() bool {
+	return t.Status.Status == common.StatePending && t.Status.Reason == TaskQueuedReason && t.Status.JobName == ""
+}
+
+// IsRunning returns true if a Job has been created for the KeptnTask and the KeptnTask is not completed yet
+func (t KeptnTask) IsRunning() bool {
+	return t.Status.JobName != "" && !t.Status.Status.IsCompleted()
+}
+
 func (t *KeptnTask) IsStartTimeSet() bool {
 	return !t.Status.StartTime.IsZero()
 }
@@ -270,6 +343,14 @@ func (t KeptnTask) GetEventAnnotations() map[string]string {
 	}
 }
 
+// GetRetries returns the number of times the KeptnTask can be retried, which defaults to 10
+func (t KeptnTask) GetRetries() int32 {
+	if t.Spec.Retries == nil {
+		return 10
+	}
+	return *t.Spec.Retries
+}
+
 func (t KeptnTask) GetActiveDeadlineSeconds() *int64 {
 	deadline, _ := time.ParseDuration(t.Spec.Timeout.Duration.String())
 	seconds := int64(deadline.Seconds())
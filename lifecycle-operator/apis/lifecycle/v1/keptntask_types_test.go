@@ -133,6 +133,10 @@ func TestKeptnTask(t *testing.T) {
 
 	require.Equal(t, int64(300), *task.GetActiveDeadlineSeconds())
 
+	require.Equal(t, int32(10), task.GetRetries())
+	retries := int32(3)
+	task.Spec.Retries = &retries
+	require.Equal(t, int32(3), task.GetRetries())
 }
 
 func TestKeptnTaskList(t *testing.T) {
@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -29,6 +31,10 @@ type KeptnTaskDefinitionSpec struct {
 	// Deno contains the definition for the Deno function that is to be executed in KeptnTasks.
 	// +optional
 	Deno *RuntimeSpec `json:"deno,omitempty"`
+	// Shell contains the definition for the shell script that is to be executed in KeptnTasks.
+	// The script is executed with bash, curl and jq are available.
+	// +optional
+	Shell *RuntimeSpec `json:"shell,omitempty"`
 	// Container contains the definition for the container that is to be used in Job.
 	// +optional
 	Container *ContainerSpec `json:"container,omitempty"`
@@ -60,6 +66,101 @@ type KeptnTaskDefinitionSpec struct {
 	// ImagePullSecrets is an optional field to specify the names of secrets to use for pulling container images
 	// +optional
 	ImagePullSecrets []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// RetryPolicy defines the delay between the attempts of KeptnTasks based on this KeptnTaskDefinition
+	// and the exit codes for which they are retried.
+	// If set, each attempt is executed in a separate Job and Retries specifies the number of retries.
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+	// MaxParallel is the maximum number of KeptnTasks based on this KeptnTaskDefinition that are executed in parallel.
+	// Further KeptnTasks stay Pending until one of the running KeptnTasks is completed.
+	// If not set, the number of KeptnTasks executed in parallel is not limited.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MaxParallel *int32 `json:"maxParallel,omitempty"`
+	// PodTemplate is strategically merged into the pod template of the Jobs executing the KeptnTasks
+	// based on this KeptnTaskDefinition. It can be used to set e.g. node selectors, tolerations, security contexts,
+	// resource limits of the task container (named keptn-function-runner for Deno, Python and shell tasks),
+	// additional volumes or sidecar containers.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	// +optional
+	PodTemplate *v1.PodTemplateSpec `json:"podTemplate,omitempty"`
+	// AllowFinishByEvent allows external systems to set the result of the KeptnTasks based on this KeptnTaskDefinition
+	// with a sh.keptn.task.finished event or a taskrun.finished CDEvent sent to the Cloud Events receiver.
+	// The Job of a KeptnTask finished this way is deleted.
+	// +optional
+	AllowFinishByEvent bool `json:"allowFinishByEvent,omitempty"`
+}
+
+// BackoffStrategy defines how the delay between two attempts of a KeptnTask grows.
+// +kubebuilder:validation:Enum=linear;exponential
+type BackoffStrategy string
+
+const (
+	BackoffStrategyLinear      BackoffStrategy = "linear"
+	BackoffStrategyExponential BackoffStrategy = "exponential"
+)
+
+const (
+	defaultRetryDelay    = 10 * time.Second
+	defaultMaxRetryDelay = 5 * time.Minute
+)
+
+// RetryPolicy defines how failed attempts of a KeptnTask are retried.
+type RetryPolicy struct {
+	// Backoff defines how the delay between two attempts grows.
+	// With the linear strategy, the delay grows by Delay after each failed attempt,
+	// with the exponential strategy, the delay doubles after each failed attempt.
+	// +kubebuilder:default:=exponential
+	// +optional
+	Backoff BackoffStrategy `json:"backoff,omitempty"`
+	// Delay specifies the time to wait before the first retry.
+	// +kubebuilder:default:="10s"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Delay metav1.Duration `json:"delay,omitempty"`
+	// MaxDelay specifies the maximum time to wait between two attempts.
+	// +kubebuilder:default:="5m"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	MaxDelay metav1.Duration `json:"maxDelay,omitempty"`
+	// OnExitCodes limits the retries to attempts that failed with one of the given exit codes.
+	// Attempts failing with any other exit code fail the KeptnTask immediately.
+	// If empty, all failed attempts are retried.
+	// +kubebuilder:validation:MaxItems=255
+	// +optional
+	OnExitCodes []int32 `json:"onExitCodes,omitempty"`
+}
+
+// GetDelay returns the time to wait after the given number of failed attempts
+func (p RetryPolicy) GetDelay(failedAttempts int32) time.Duration {
+	delay := p.Delay.Duration
+	if delay <= 0 {
+		delay = defaultRetryDelay
+	}
+	maxDelay := p.MaxDelay.Duration
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxRetryDelay
+	}
+	if failedAttempts < 1 {
+		failedAttempts = 1
+	}
+
+	result := delay
+	for i := int32(1); i < failedAttempts && result < maxDelay; i++ {
+		if p.Backoff == BackoffStrategyLinear {
+			result += delay
+		} else {
+			result *= 2
+		}
+	}
+	if result > maxDelay {
+		return maxDelay
+	}
+	return result
 }
 
 type RuntimeSpec struct {
@@ -87,6 +188,11 @@ type RuntimeSpec struct {
 	// These will be stored and accessed as secrets in the cluster.
 	// +optional
 	SecureParameters SecureParameters `json:"secureParameters,omitempty"`
+	// Mounts contains Secrets and ConfigMaps that will be made available to the job that executes the task,
+	// either as environment variables or as files.
+	// They replace the mounts with the same name of the KeptnTaskDefinition referenced in FunctionReference.
+	// +optional
+	Mounts []TaskMount `json:"mounts,omitempty"`
 	// CmdParameters contains parameters that will be passed to the command
 	// +optional
 	CmdParameters string `json:"cmdParameters,omitempty"`
@@ -114,6 +220,22 @@ type HttpReference struct {
 	// Url is the URL containing the code of the function.
 	// +optional
 	Url string `json:"url,omitempty"`
+	// Sha256 is the expected SHA-256 checksum of the code, encoded as hex string.
+	// If set, the code is verified before it is executed and the KeptnTask fails if the checksum does not match.
+	// +kubebuilder:validation:Pattern=`^[a-fA-F0-9]{64}$`
+	// +optional
+	Sha256 string `json:"sha256,omitempty"`
+	// Cache specifies whether the code is fetched once by the KeptnTaskDefinition controller and stored in a ConfigMap
+	// owned by the KeptnTaskDefinition, so that KeptnTasks do not depend on the availability of the URL.
+	// The code is fetched again if the URL or the checksum changes.
+	// +optional
+	Cache bool `json:"cache,omitempty"`
+	// RefreshInterval specifies how often the cached code is fetched again.
+	// If not set, the cached code is only refreshed if the URL or the checksum changes.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	RefreshInterval metav1.Duration `json:"refreshInterval,omitempty"`
 }
 
 type ContainerSpec struct {
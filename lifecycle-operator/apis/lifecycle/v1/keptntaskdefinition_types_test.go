@@ -2,8 +2,10 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestTaskDefinition_GetServiceAccountNoName(t *testing.T) {
@@ -38,3 +40,54 @@ func TestTaskDefinition_GetAutomountServiceAccountToken(t *testing.T) {
 	}
 	require.True(t, *d.GetAutomountServiceAccountToken())
 }
+
+func TestRetryPolicy_GetDelay(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         RetryPolicy
+		failedAttempts int32
+		want           time.Duration
+	}{
+		{
+			name:           "defaults",
+			policy:         RetryPolicy{},
+			failedAttempts: 1,
+			want:           10 * time.Second,
+		},
+		{
+			name:           "exponential",
+			policy:         RetryPolicy{Backoff: BackoffStrategyExponential, Delay: metav1.Duration{Duration: 5 * time.Second}},
+			failedAttempts: 4,
+			want:           40 * time.Second,
+		},
+		{
+			name:           "exponential capped by max delay",
+			policy:         RetryPolicy{Backoff: BackoffStrategyExponential, Delay: metav1.Duration{Duration: 5 * time.Second}, MaxDelay: metav1.Duration{Duration: 30 * time.Second}},
+			failedAttempts: 4,
+			want:           30 * time.Second,
+		},
+		{
+			name:           "exponential capped by default max delay",
+			policy:         RetryPolicy{Delay: metav1.Duration{Duration: time.Minute}},
+			failedAttempts: 100,
+			want:           5 * time.Minute,
+		},
+		{
+			name:           "linear",
+			policy:         RetryPolicy{Backoff: BackoffStrategyLinear, Delay: metav1.Duration{Duration: 5 * time.Second}},
+			failedAttempts: 4,
+			want:           20 * time.Second,
+		},
+		{
+			name:           "no failed attempts",
+			policy:         RetryPolicy{Backoff: BackoffStrategyLinear, Delay: metav1.Duration{Duration: 5 * time.Second}},
+			failedAttempts: 0,
+			want:           5 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.policy.GetDelay(tt.failedAttempts))
+		})
+	}
+}
@@ -68,6 +68,7 @@ func (r *KeptnTaskDefinition) validateKeptnTaskDefinition() error {
 	if err = r.validateFields(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	allErrs = append(allErrs, r.validateMounts()...)
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -83,7 +84,7 @@ func (r *KeptnTaskDefinition) validateFields() *field.Error {
 		return field.Invalid(
 			field.NewPath("spec"),
 			r.Spec,
-			errors.New("Forbidden! Either Container, Python, or Deno field must be defined").Error(),
+			errors.New("Forbidden! Either Container, Python, Deno, or Shell field must be defined").Error(),
 		)
 	}
 
@@ -91,7 +92,7 @@ func (r *KeptnTaskDefinition) validateFields() *field.Error {
 		return field.Invalid(
 			field.NewPath("spec"),
 			r.Spec,
-			errors.New("Forbidden! Only one of Container, Python, or Deno field can be defined").Error(),
+			errors.New("Forbidden! Only one of Container, Python, Deno, or Shell field can be defined").Error(),
 		)
 	}
 
@@ -109,5 +110,35 @@ func countSpec(r *KeptnTaskDefinition) int {
 	if r.Spec.Deno != nil {
 		count++
 	}
+	if r.Spec.Shell != nil {
+		count++
+	}
 	return count
 }
+
+func (r *KeptnTaskDefinition) validateMounts() field.ErrorList {
+	var allErrs field.ErrorList
+	runtimes := []struct {
+		name string
+		spec *RuntimeSpec
+	}{
+		{name: "python", spec: r.Spec.Python},
+		{name: "deno", spec: r.Spec.Deno},
+		{name: "shell", spec: r.Spec.Shell},
+	}
+	for _, entry := range runtimes {
+		if entry.spec == nil {
+			continue
+		}
+		for i, mount := range entry.spec.Mounts {
+			if (mount.Secret == "") == (mount.ConfigMap == "") {
+				allErrs = append(allErrs, field.Invalid(
+					field.NewPath("spec").Child(entry.name).Child("mounts").Index(i),
+					mount,
+					errors.New("Forbidden! Either Secret or ConfigMap must be defined").Error(),
+				))
+			}
+		}
+	}
+	return allErrs
+}
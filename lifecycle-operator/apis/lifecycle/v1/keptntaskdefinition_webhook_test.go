@@ -29,6 +29,11 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 		Deno:   &RuntimeSpec{},
 	}
 
+	specWithShellAndDeno := KeptnTaskDefinitionSpec{
+		Shell: &RuntimeSpec{},
+		Deno:  &RuntimeSpec{},
+	}
+
 	emptySpec := KeptnTaskDefinitionSpec{}
 
 	tests := []struct {
@@ -47,7 +52,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					emptySpec,
-					errors.New("Forbidden! Either Container, Python, or Deno field must be defined").Error(),
+					errors.New("Forbidden! Either Container, Python, Deno, or Shell field must be defined").Error(),
 				)},
 			),
 			verb: "create",
@@ -73,6 +78,13 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 			},
 			verb: "create",
 		},
+		{
+			name: "with-shell-only",
+			spec: KeptnTaskDefinitionSpec{
+				Shell: &RuntimeSpec{},
+			},
+			verb: "create",
+		},
 		{
 			name: "with-both-container-and-python",
 			spec: specWithContainerAndPython,
@@ -83,7 +95,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithContainerAndPython,
-					errors.New("Forbidden! Only one of Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Container, Python, Deno, or Shell field can be defined").Error(),
 				)},
 			),
 		},
@@ -96,7 +108,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithContainerAndPython,
-					errors.New("Forbidden! Only one of Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Container, Python, Deno, or Shell field can be defined").Error(),
 				)},
 			),
 			oldSpec: &KeptnTaskDefinition{
@@ -115,7 +127,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithContainerAndDeno,
-					errors.New("Forbidden! Only one of Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Container, Python, Deno, or Shell field can be defined").Error(),
 				)},
 			),
 		},
@@ -128,7 +140,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithContainerAndDeno,
-					errors.New("Forbidden! Only one of Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Container, Python, Deno, or Shell field can be defined").Error(),
 				)},
 			),
 			oldSpec: &KeptnTaskDefinition{
@@ -146,7 +158,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithPythonAndDeno,
-					errors.New("Forbidden! Only one of Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Container, Python, Deno, or Shell field can be defined").Error(),
 				)},
 			),
 		},
@@ -159,7 +171,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithPythonAndDeno,
-					errors.New("Forbidden! Only one of Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Container, Python, Deno, or Shell field can be defined").Error(),
 				)},
 			),
 			oldSpec: &KeptnTaskDefinition{
@@ -167,6 +179,41 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 			},
 			verb: "update",
 		},
+		{
+			name: "with-both-shell-and-deno",
+			spec: specWithShellAndDeno,
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnTaskDefinition"},
+				"with-both-shell-and-deno",
+				[]*field.Error{field.Invalid(
+					field.NewPath("spec"),
+					specWithShellAndDeno,
+					errors.New("Forbidden! Only one of Container, Python, Deno, or Shell field can be defined").Error(),
+				)},
+			),
+		},
+		{
+			name: "with-invalid-mount",
+			spec: KeptnTaskDefinitionSpec{
+				Deno: &RuntimeSpec{
+					Mounts: []TaskMount{
+						{Name: "valid", Secret: "my-secret"},
+						{Name: "invalid", Secret: "my-secret", ConfigMap: "my-configmap"},
+					},
+				},
+			},
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnTaskDefinition"},
+				"with-invalid-mount",
+				[]*field.Error{field.Invalid(
+					field.NewPath("spec").Child("deno").Child("mounts").Index(1),
+					TaskMount{Name: "invalid", Secret: "my-secret", ConfigMap: "my-configmap"},
+					errors.New("Forbidden! Either Secret or ConfigMap must be defined").Error(),
+				)},
+			),
+		},
 
 		{
 			name: "delete",
@@ -43,6 +43,18 @@ type KeptnWorkloadSpec struct {
 	// located in the same namespace as the KeptnWorkload, or in the Keptn namespace.
 	// +optional
 	PostDeploymentTasks []string `json:"postDeploymentTasks,omitempty"`
+	// PreDeploymentTaskRefs is a structured list of tasks to be performed during the pre-deployment phase of the KeptnWorkload.
+	// In contrast to PreDeploymentTasks, each item can declare the tasks it depends on
+	// and a condition for its execution.
+	// The tasks of both lists are executed during the pre-deployment phase.
+	// +optional
+	PreDeploymentTaskRefs []TaskReference `json:"preDeploymentTaskRefs,omitempty"`
+	// PostDeploymentTaskRefs is a structured list of tasks to be performed during the post-deployment phase of the KeptnWorkload.
+	// In contrast to PostDeploymentTasks, each item can declare the tasks it depends on
+	// and a condition for its execution.
+	// The tasks of both lists are executed during the post-deployment phase.
+	// +optional
+	PostDeploymentTaskRefs []TaskReference `json:"postDeploymentTaskRefs,omitempty"`
 	// PreDeploymentEvaluations is a list of all evaluations to be performed
 	// during the pre-deployment phase of the KeptnWorkload.
 	// The items of this list refer to the names of KeptnEvaluationDefinitions
@@ -55,9 +67,51 @@ type KeptnWorkloadSpec struct {
 	// located in the same namespace as the KeptnWorkload, or in the Keptn namespace.
 	// +optional
 	PostDeploymentEvaluations []string `json:"postDeploymentEvaluations,omitempty"`
+	// PreDeploymentEvaluationRefs is a structured list of evaluations to be performed during the pre-deployment phase of the KeptnWorkload.
+	// In contrast to PreDeploymentEvaluations, each item can declare a condition for its execution.
+	// The evaluations of both lists are executed during the pre-deployment phase.
+	// +optional
+	PreDeploymentEvaluationRefs []EvaluationReference `json:"preDeploymentEvaluationRefs,omitempty"`
+	// PostDeploymentEvaluationRefs is a structured list of evaluations to be performed during the post-deployment phase of the KeptnWorkload.
+	// In contrast to PostDeploymentEvaluations, each item can declare a condition for its execution.
+	// The evaluations of both lists are executed during the post-deployment phase.
+	// +optional
+	PostDeploymentEvaluationRefs []EvaluationReference `json:"postDeploymentEvaluationRefs,omitempty"`
+	// PreDeploymentAnalyses is a list of all analyses to be performed
+	// during the pre-deployment evaluation phase of the KeptnWorkload.
+	// The items of this list refer to the names of AnalysisDefinitions
+	// located in the same namespace as the KeptnWorkload, or in the Keptn namespace.
+	// +optional
+	PreDeploymentAnalyses []string `json:"preDeploymentAnalyses,omitempty"`
+	// PostDeploymentAnalyses is a list of all analyses to be performed
+	// during the post-deployment evaluation phase of the KeptnWorkload.
+	// The items of this list refer to the names of AnalysisDefinitions
+	// located in the same namespace as the KeptnWorkload, or in the Keptn namespace.
+	// +optional
+	PostDeploymentAnalyses []string `json:"postDeploymentAnalyses,omitempty"`
 	// ResourceReference is a reference to the Kubernetes resource
-	// (Deployment, DaemonSet, StatefulSet or ReplicaSet) the KeptnWorkload is representing.
+	// (Deployment, DaemonSet, StatefulSet, ReplicaSet or Job) the KeptnWorkload is representing.
 	ResourceReference ResourceReference `json:"resourceReference"`
+	// ReadinessCheck defines how Keptn determines whether the KeptnWorkload has been deployed.
+	// If not set, all replicas of the workload must be available.
+	// +optional
+	ReadinessCheck *ReadinessCheck `json:"readinessCheck,omitempty"`
+	// ObservabilityTimeout specifies the maximum time to observe the deployment phase of the KeptnWorkload.
+	// If the workload does not deploy successfully within this time frame, it will be
+	// considered as failed.
+	// If not set, the ObservabilityTimeout of the KeptnAppContext or, if not set either, of the KeptnConfig is used.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	ObservabilityTimeout *metav1.Duration `json:"observabilityTimeout,omitempty"`
+	// AnalysisTimeframe specifies the length of the timeframe evaluated by the pre- and post-deployment analyses of the KeptnWorkload.
+	// Pre-deployment analyses evaluate the timeframe right before the deployment of the KeptnWorkloadVersion started,
+	// post-deployment analyses evaluate the timeframe right after the workload has been deployed.
+	// If not set, a timeframe of 5m is used.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	AnalysisTimeframe *metav1.Duration `json:"analysisTimeframe,omitempty"`
 	// +optional
 	// Metadata contains additional key-value pairs for contextual information.
 	Metadata map[string]string `json:"metadata,omitempty"`
@@ -100,6 +154,72 @@ type KeptnWorkloadList struct {
 	Items           []KeptnWorkload `json:"items"`
 }
 
+// ReadinessStrategy is the strategy used to determine whether a workload has been deployed
+type ReadinessStrategy string
+
+const (
+	// ReadinessStrategyReplicas requires all replicas of the workload to be available.
+	ReadinessStrategyReplicas ReadinessStrategy = "replicas"
+	// ReadinessStrategyMinAvailability requires a minimum percentage of the replicas of the workload to be available.
+	ReadinessStrategyMinAvailability ReadinessStrategy = "minAvailability"
+	// ReadinessStrategyProgressDeadline requires the Deployment or Rollout managing the workload
+	// to report that it finished progressing within its progress deadline.
+	ReadinessStrategyProgressDeadline ReadinessStrategy = "progressDeadline"
+	// ReadinessStrategyPodReadiness requires all pods of the workload to be ready
+	// without their containers exceeding a maximum number of restarts.
+	ReadinessStrategyPodReadiness ReadinessStrategy = "podReadiness"
+)
+
+// DefaultMaxRestarts is the maximum number of container restarts tolerated by the podReadiness strategy if none is set
+const DefaultMaxRestarts int32 = 3
+
+// ReadinessCheck defines how Keptn determines whether a workload has been deployed
+type ReadinessCheck struct {
+	// Strategy is the strategy used to determine whether the workload has been deployed.
+	// +kubebuilder:validation:Enum=replicas;minAvailability;progressDeadline;podReadiness
+	// +kubebuilder:default:=replicas
+	// +optional
+	Strategy ReadinessStrategy `json:"strategy,omitempty"`
+	// MinAvailablePercentage is the percentage of replicas that must be available
+	// when using the minAvailability strategy.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=100
+	// +kubebuilder:default:=100
+	// +optional
+	MinAvailablePercentage int `json:"minAvailablePercentage,omitempty"`
+	// MaxRestarts is the maximum number of restarts of a container of the workload
+	// when using the podReadiness strategy.
+	// If a container restarts more often, the deployment of the workload fails.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:default:=3
+	// +optional
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+}
+
+// GetStrategy returns the configured strategy, or the replicas strategy if none is set
+func (r *ReadinessCheck) GetStrategy() ReadinessStrategy {
+	if r == nil || r.Strategy == "" {
+		return ReadinessStrategyReplicas
+	}
+	return r.Strategy
+}
+
+// GetMinAvailablePercentage returns the configured percentage of replicas that must be available, or 100 if none is set
+func (r *ReadinessCheck) GetMinAvailablePercentage() int {
+	if r == nil || r.MinAvailablePercentage <= 0 {
+		return 100
+	}
+	return r.MinAvailablePercentage
+}
+
+// GetMaxRestarts returns the configured maximum number of container restarts, or 3 if none is set
+func (r *ReadinessCheck) GetMaxRestarts() int32 {
+	if r == nil || r.MaxRestarts == nil {
+		return DefaultMaxRestarts
+	}
+	return *r.MaxRestarts
+}
+
 // ResourceReference represents the parent resource of Workload
 type ResourceReference struct {
 	UID  types.UID `json:"uid"`
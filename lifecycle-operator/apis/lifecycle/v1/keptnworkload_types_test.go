@@ -69,3 +69,30 @@ func TestKeptnWorkload_GetNameWithoutAppPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestReadinessCheck_Defaults(t *testing.T) {
+	var nilCheck *ReadinessCheck
+	require.Equal(t, ReadinessStrategyReplicas, nilCheck.GetStrategy())
+	require.Equal(t, 100, nilCheck.GetMinAvailablePercentage())
+	require.Equal(t, int32(3), nilCheck.GetMaxRestarts())
+
+	check := &ReadinessCheck{}
+	require.Equal(t, ReadinessStrategyReplicas, check.GetStrategy())
+	require.Equal(t, 100, check.GetMinAvailablePercentage())
+	require.Equal(t, int32(3), check.GetMaxRestarts())
+
+	check = &ReadinessCheck{
+		Strategy:               ReadinessStrategyMinAvailability,
+		MinAvailablePercentage: 80,
+	}
+	require.Equal(t, ReadinessStrategyMinAvailability, check.GetStrategy())
+	require.Equal(t, 80, check.GetMinAvailablePercentage())
+
+	// an explicit zero does not tolerate any restarts
+	maxRestarts := int32(0)
+	check = &ReadinessCheck{
+		Strategy:    ReadinessStrategyPodReadiness,
+		MaxRestarts: &maxRestarts,
+	}
+	require.Equal(t, int32(0), check.GetMaxRestarts())
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var keptnworkloadlog = logf.Log.WithName("keptnworkload-resource")
+
+func (r *KeptnWorkload) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-lifecycle-keptn-sh-v1-keptnworkload,mutating=false,failurePolicy=fail,sideEffects=None,groups=lifecycle.keptn.sh,resources=keptnworkloads,verbs=create;update,versions=v1,name=vkeptnworkload.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &KeptnWorkload{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnWorkload) ValidateCreate() (admission.Warnings, error) {
+	keptnworkloadlog.Info("validate create", "name", r.Name)
+
+	return []string{}, r.validateKeptnWorkload()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnWorkload) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	keptnworkloadlog.Info("validate update", "name", r.Name)
+
+	return []string{}, r.validateKeptnWorkload()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnWorkload) ValidateDelete() (admission.Warnings, error) {
+	keptnworkloadlog.Info("validate delete", "name", r.Name)
+
+	return []string{}, nil
+}
+
+func (r *KeptnWorkload) validateKeptnWorkload() error {
+	var allErrs field.ErrorList //defined as a list to allow returning multiple validation errors
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateTaskReferences(specPath.Child("preDeploymentTaskRefs"), r.Spec.PreDeploymentTasks, r.Spec.PreDeploymentTaskRefs)...)
+	allErrs = append(allErrs, validateTaskReferences(specPath.Child("postDeploymentTaskRefs"), r.Spec.PostDeploymentTasks, r.Spec.PostDeploymentTaskRefs)...)
+	allErrs = append(allErrs, validateEvaluationReferences(specPath.Child("preDeploymentEvaluationRefs"), r.Spec.PreDeploymentEvaluationRefs)...)
+	allErrs = append(allErrs, validateEvaluationReferences(specPath.Child("postDeploymentEvaluationRefs"), r.Spec.PostDeploymentEvaluationRefs)...)
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnWorkload"},
+		r.Name,
+		allErrs)
+}
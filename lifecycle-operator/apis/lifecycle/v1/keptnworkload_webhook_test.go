@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestKeptnWorkload_ValidateTaskReferences(t *testing.T) {
+	workload := &KeptnWorkload{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-workload"},
+		Spec: KeptnWorkloadSpec{
+			PreDeploymentTasks: []string{"task-1"},
+			PreDeploymentTaskRefs: []TaskReference{
+				{Name: "task-2", DependsOn: []string{"task-1"}},
+			},
+			PostDeploymentTaskRefs: []TaskReference{
+				{Name: "task-3", DependsOn: []string{"task-4"}},
+				{Name: "task-4", DependsOn: []string{"task-3"}},
+			},
+		},
+	}
+
+	want := apierrors.NewInvalid(
+		schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnWorkload"},
+		"my-workload",
+		field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec").Child("postDeploymentTaskRefs"),
+				"task-3 -> task-4 -> task-3",
+				"the dependencies of the tasks must not contain cycles",
+			),
+		},
+	)
+
+	_, err := workload.ValidateCreate()
+	require.Equal(t, want, err)
+
+	_, err = workload.ValidateUpdate(&KeptnWorkload{})
+	require.Equal(t, want, err)
+
+	_, err = workload.ValidateDelete()
+	require.Nil(t, err)
+
+	workload.Spec.PostDeploymentTaskRefs = nil
+	_, err = workload.ValidateCreate()
+	require.Nil(t, err)
+}
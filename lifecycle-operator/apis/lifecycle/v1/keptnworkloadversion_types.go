@@ -93,6 +93,12 @@ type KeptnWorkloadVersionStatus struct {
 	// PostDeploymentEvaluationTaskStatus indicates the current state of each postDeploymentEvaluation of the KeptnWorkloadVersion.
 	// +optional
 	PostDeploymentEvaluationTaskStatus []ItemStatus `json:"postDeploymentEvaluationTaskStatus,omitempty"`
+	// PreDeploymentAnalysisStatus indicates the current state of each preDeploymentAnalysis of the KeptnWorkloadVersion.
+	// +optional
+	PreDeploymentAnalysisStatus []ItemStatus `json:"preDeploymentAnalysisStatus,omitempty"`
+	// PostDeploymentAnalysisStatus indicates the current state of each postDeploymentAnalysis of the KeptnWorkloadVersion.
+	// +optional
+	PostDeploymentAnalysisStatus []ItemStatus `json:"postDeploymentAnalysisStatus,omitempty"`
 	// StartTime represents the time at which the deployment of the KeptnWorkloadVersion started.
 	// +optional
 	StartTime metav1.Time `json:"startTime,omitempty"`
@@ -120,6 +126,18 @@ type KeptnWorkloadVersionStatus struct {
 	// DeploymentStartTime represents the start time of the deployment phase
 	// +optional
 	DeploymentStartTime metav1.Time `json:"deploymentStartTime,omitempty"`
+	// DeploymentEndTime represents the end time of the deployment phase
+	// +optional
+	DeploymentEndTime metav1.Time `json:"deploymentEndTime,omitempty"`
+	// DeployedRevision is the name of the ControllerRevision of the StatefulSet or DaemonSet
+	// that has been deployed with the KeptnWorkloadVersion.
+	// +optional
+	DeployedRevision string `json:"deployedRevision,omitempty"`
+	// DeploymentDeadline represents the time at which the deployment phase is considered as failed
+	// if the workload has not been deployed successfully.
+	// It is derived from the effective ObservabilityTimeout when the deployment phase starts.
+	// +optional
+	DeploymentDeadline metav1.Time `json:"deploymentDeadline,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -322,11 +340,22 @@ func (w KeptnWorkloadVersion) GetState() common.KeptnState {
 }
 
 func (w KeptnWorkloadVersion) GetPreDeploymentTasks() []string {
-	return w.Spec.PreDeploymentTasks
+	return getTaskNames(w.GetTaskReferences(common.PreDeploymentCheckType))
 }
 
 func (w KeptnWorkloadVersion) GetPostDeploymentTasks() []string {
-	return w.Spec.PostDeploymentTasks
+	return getTaskNames(w.GetTaskReferences(common.PostDeploymentCheckType))
+}
+
+func (w KeptnWorkloadVersion) GetTaskReferences(checkType common.CheckType) []TaskReference {
+	switch checkType {
+	case common.PreDeploymentCheckType:
+		return mergeTaskReferences(w.Spec.PreDeploymentTasks, w.Spec.PreDeploymentTaskRefs)
+	case common.PostDeploymentCheckType:
+		return mergeTaskReferences(w.Spec.PostDeploymentTasks, w.Spec.PostDeploymentTaskRefs)
+	}
+	// promotion tasks are not included in Workloads
+	return []TaskReference{}
 }
 
 func (w KeptnWorkloadVersion) GetPreDeploymentTaskStatus() []ItemStatus {
@@ -338,11 +367,21 @@ func (w KeptnWorkloadVersion) GetPostDeploymentTaskStatus() []ItemStatus {
 }
 
 func (w KeptnWorkloadVersion) GetPreDeploymentEvaluations() []string {
-	return w.Spec.PreDeploymentEvaluations
+	return getEvaluationNames(w.GetEvaluationReferences(common.PreDeploymentEvaluationCheckType))
 }
 
 func (w KeptnWorkloadVersion) GetPostDeploymentEvaluations() []string {
-	return w.Spec.PostDeploymentEvaluations
+	return getEvaluationNames(w.GetEvaluationReferences(common.PostDeploymentEvaluationCheckType))
+}
+
+func (w KeptnWorkloadVersion) GetEvaluationReferences(checkType common.CheckType) []EvaluationReference {
+	switch checkType {
+	case common.PreDeploymentEvaluationCheckType:
+		return mergeEvaluationReferences(w.Spec.PreDeploymentEvaluations, w.Spec.PreDeploymentEvaluationRefs)
+	case common.PostDeploymentEvaluationCheckType:
+		return mergeEvaluationReferences(w.Spec.PostDeploymentEvaluations, w.Spec.PostDeploymentEvaluationRefs)
+	}
+	return []EvaluationReference{}
 }
 
 func (w KeptnWorkloadVersion) GetPreDeploymentEvaluationTaskStatus() []ItemStatus {
@@ -353,6 +392,26 @@ func (w KeptnWorkloadVersion) GetPostDeploymentEvaluationTaskStatus() []ItemStat
 	return w.Status.PostDeploymentEvaluationTaskStatus
 }
 
+func (w KeptnWorkloadVersion) GetAnalyses(checkType common.CheckType) []string {
+	switch checkType {
+	case common.PreDeploymentEvaluationCheckType:
+		return w.Spec.PreDeploymentAnalyses
+	case common.PostDeploymentEvaluationCheckType:
+		return w.Spec.PostDeploymentAnalyses
+	}
+	return []string{}
+}
+
+func (w KeptnWorkloadVersion) GetAnalysisStatus(checkType common.CheckType) []ItemStatus {
+	switch checkType {
+	case common.PreDeploymentEvaluationCheckType:
+		return w.Status.PreDeploymentAnalysisStatus
+	case common.PostDeploymentEvaluationCheckType:
+		return w.Status.PostDeploymentAnalysisStatus
+	}
+	return []ItemStatus{}
+}
+
 func (w KeptnWorkloadVersion) GetPromotionTasks() []string {
 	// promotion tasks are not included in Workloads, but we need the implementation of this method to fulfil the PhaseItem interface
 	return []string{}
@@ -417,6 +476,7 @@ func (w KeptnWorkloadVersion) GenerateTask(taskDefinition KeptnTaskDefinition, c
 			Type:             checkType,
 			Retries:          taskDefinition.Spec.Retries,
 			Timeout:          taskDefinition.Spec.Timeout,
+			RetryPolicy:      taskDefinition.Spec.RetryPolicy,
 		},
 	}
 }
@@ -433,6 +493,7 @@ func (w KeptnWorkloadVersion) GenerateEvaluation(evaluationDefinition KeptnEvalu
 			Workload:             w.GetParentName(),
 			EvaluationDefinition: evaluationDefinition.Name,
 			Type:                 checkType,
+			PreviousVersion:      w.Spec.PreviousVersion,
 			FailureConditions: FailureConditions{
 				RetryInterval: evaluationDefinition.Spec.RetryInterval,
 				Retries:       evaluationDefinition.Spec.Retries,
@@ -530,3 +591,20 @@ func (w *KeptnWorkloadVersion) SetDeploymentStartTime() {
 func (w *KeptnWorkloadVersion) IsDeploymentStartTimeSet() bool {
 	return !w.Status.DeploymentStartTime.IsZero()
 }
+
+func (w *KeptnWorkloadVersion) SetDeploymentEndTime() {
+	if w.Status.DeploymentEndTime.IsZero() {
+		w.Status.DeploymentEndTime = metav1.NewTime(time.Now().UTC())
+	}
+}
+
+func (w KeptnWorkloadVersion) GetDeploymentEndTime() time.Time {
+	return w.Status.DeploymentEndTime.Time
+}
+
+func (w KeptnWorkloadVersion) GetAnalysisTimeframe() time.Duration {
+	if w.Spec.AnalysisTimeframe != nil {
+		return w.Spec.AnalysisTimeframe.Duration
+	}
+	return DefaultAnalysisTimeframe
+}
@@ -208,6 +208,7 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 	require.Equal(t, "trace1.workloadname.namespace.version.phase", workload.GetSpanKey("phase"))
 
 	retries := int32(5)
+	retryPolicy := &RetryPolicy{Backoff: BackoffStrategyLinear}
 	task := workload.GenerateTask(KeptnTaskDefinition{
 		ObjectMeta: v1.ObjectMeta{
 			Name: "task-def",
@@ -222,7 +223,8 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 			Timeout: v1.Duration{
 				Duration: 5 * time.Second,
 			},
-			Retries: &retries,
+			Retries:     &retries,
+			RetryPolicy: retryPolicy,
 		},
 	}, common.PostDeploymentCheckType)
 	require.Equal(t, KeptnTaskSpec{
@@ -240,7 +242,8 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 		Timeout: v1.Duration{
 			Duration: 5 * time.Second,
 		},
-		Retries: &retries,
+		Retries:     &retries,
+		RetryPolicy: retryPolicy,
 	}, task.Spec)
 
 	require.Equal(t, map[string]string{
@@ -270,6 +273,7 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 		Workload:             workload.GetParentName(),
 		EvaluationDefinition: "eval-def",
 		Type:                 common.PostDeploymentCheckType,
+		PreviousVersion:      "prev",
 		FailureConditions: FailureConditions{
 			RetryInterval: v1.Duration{
 				Duration: 5 * time.Second,
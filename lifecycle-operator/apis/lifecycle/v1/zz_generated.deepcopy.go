@@ -24,9 +24,26 @@ import (
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
 	"go.opentelemetry.io/otel/propagation"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalSpec) DeepCopyInto(out *ApprovalSpec) {
+	*out = *in
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalSpec.
+func (in *ApprovalSpec) DeepCopy() *ApprovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutomountServiceAccountTokenSpec) DeepCopyInto(out *AutomountServiceAccountTokenSpec) {
 	*out = *in
@@ -110,6 +127,47 @@ func (in *DeploymentTaskSpec) DeepCopyInto(out *DeploymentTaskSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PreDeploymentTaskRefs != nil {
+		in, out := &in.PreDeploymentTaskRefs, &out.PreDeploymentTaskRefs
+		*out = make([]TaskReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostDeploymentTaskRefs != nil {
+		in, out := &in.PostDeploymentTaskRefs, &out.PostDeploymentTaskRefs
+		*out = make([]TaskReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PromotionTaskRefs != nil {
+		in, out := &in.PromotionTaskRefs, &out.PromotionTaskRefs
+		*out = make([]TaskReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreDeploymentEvaluationRefs != nil {
+		in, out := &in.PreDeploymentEvaluationRefs, &out.PreDeploymentEvaluationRefs
+		*out = make([]EvaluationReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostDeploymentEvaluationRefs != nil {
+		in, out := &in.PostDeploymentEvaluationRefs, &out.PostDeploymentEvaluationRefs
+		*out = make([]EvaluationReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreDeploymentAnalyses != nil {
+		in, out := &in.PreDeploymentAnalyses, &out.PreDeploymentAnalyses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostDeploymentAnalyses != nil {
+		in, out := &in.PostDeploymentAnalyses, &out.PostDeploymentAnalyses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentTaskSpec.
@@ -122,6 +180,21 @@ func (in *DeploymentTaskSpec) DeepCopy() *DeploymentTaskSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvaluationReference) DeepCopyInto(out *EvaluationReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationReference.
+func (in *EvaluationReference) DeepCopy() *EvaluationReference {
+	if in == nil {
+		return nil
+	}
+	out := new(EvaluationReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EvaluationStatusItem) DeepCopyInto(out *EvaluationStatusItem) {
 	*out = *in
@@ -186,6 +259,7 @@ func (in *FunctionStatus) DeepCopy() *FunctionStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HttpReference) DeepCopyInto(out *HttpReference) {
 	*out = *in
+	out.RefreshInterval = in.RefreshInterval
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpReference.
@@ -332,6 +406,21 @@ func (in *KeptnAppContextSpec) DeepCopyInto(out *KeptnAppContextSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ApprovalSpec)
+		**out = **in
+	}
+	if in.ObservabilityTimeout != nil {
+		in, out := &in.ObservabilityTimeout, &out.ObservabilityTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.AnalysisTimeframe != nil {
+		in, out := &in.AnalysisTimeframe, &out.AnalysisTimeframe
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnAppContextSpec.
@@ -585,6 +674,12 @@ func (in *KeptnAppVersionSpec) DeepCopy() *KeptnAppVersionSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeptnAppVersionStatus) DeepCopyInto(out *KeptnAppVersionStatus) {
 	*out = *in
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.ApprovalStartTime.DeepCopyInto(&out.ApprovalStartTime)
 	if in.WorkloadStatus != nil {
 		in, out := &in.WorkloadStatus, &out.WorkloadStatus
 		*out = make([]WorkloadStatus, len(*in))
@@ -625,6 +720,20 @@ func (in *KeptnAppVersionStatus) DeepCopyInto(out *KeptnAppVersionStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PreDeploymentAnalysisStatus != nil {
+		in, out := &in.PreDeploymentAnalysisStatus, &out.PreDeploymentAnalysisStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostDeploymentAnalysisStatus != nil {
+		in, out := &in.PostDeploymentAnalysisStatus, &out.PostDeploymentAnalysisStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PhaseTraceIDs != nil {
 		in, out := &in.PhaseTraceIDs, &out.PhaseTraceIDs
 		*out = make(common.PhaseTraceID, len(*in))
@@ -645,6 +754,7 @@ func (in *KeptnAppVersionStatus) DeepCopyInto(out *KeptnAppVersionStatus) {
 	}
 	in.StartTime.DeepCopyInto(&out.StartTime)
 	in.EndTime.DeepCopyInto(&out.EndTime)
+	in.WorkloadDeploymentEndTime.DeepCopyInto(&out.WorkloadDeploymentEndTime)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnAppVersionStatus.
@@ -657,6 +767,79 @@ func (in *KeptnAppVersionStatus) DeepCopy() *KeptnAppVersionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnApproval) DeepCopyInto(out *KeptnApproval) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnApproval.
+func (in *KeptnApproval) DeepCopy() *KeptnApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeptnApproval) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnApprovalList) DeepCopyInto(out *KeptnApprovalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeptnApproval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnApprovalList.
+func (in *KeptnApprovalList) DeepCopy() *KeptnApprovalList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnApprovalList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeptnApprovalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnApprovalSpec) DeepCopyInto(out *KeptnApprovalSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnApprovalSpec.
+func (in *KeptnApprovalSpec) DeepCopy() *KeptnApprovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnApprovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeptnEvaluation) DeepCopyInto(out *KeptnEvaluation) {
 	*out = *in
@@ -750,6 +933,11 @@ func (in *KeptnEvaluationDefinitionSpec) DeepCopyInto(out *KeptnEvaluationDefini
 		*out = make([]Objective, len(*in))
 		copy(*out, *in)
 	}
+	if in.TotalScore != nil {
+		in, out := &in.TotalScore, &out.TotalScore
+		*out = new(TotalScore)
+		**out = **in
+	}
 	out.FailureConditions = in.FailureConditions
 }
 
@@ -949,6 +1137,11 @@ func (in *KeptnTaskDefinitionSpec) DeepCopyInto(out *KeptnTaskDefinitionSpec) {
 		*out = new(RuntimeSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Shell != nil {
+		in, out := &in.Shell, &out.Shell
+		*out = new(RuntimeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Container != nil {
 		in, out := &in.Container, &out.Container
 		*out = new(ContainerSpec)
@@ -980,6 +1173,21 @@ func (in *KeptnTaskDefinitionSpec) DeepCopyInto(out *KeptnTaskDefinitionSpec) {
 		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxParallel != nil {
+		in, out := &in.MaxParallel, &out.MaxParallel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTaskDefinitionSpec.
@@ -1046,12 +1254,24 @@ func (in *KeptnTaskSpec) DeepCopyInto(out *KeptnTaskSpec) {
 	in.Context.DeepCopyInto(&out.Context)
 	in.Parameters.DeepCopyInto(&out.Parameters)
 	out.SecureParameters = in.SecureParameters
+	if in.Mounts != nil {
+		in, out := &in.Mounts, &out.Mounts
+		*out = make([]TaskMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Retries != nil {
 		in, out := &in.Retries, &out.Retries
 		*out = new(int32)
 		**out = **in
 	}
 	out.Timeout = in.Timeout
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTaskSpec.
@@ -1069,6 +1289,14 @@ func (in *KeptnTaskStatus) DeepCopyInto(out *KeptnTaskStatus) {
 	*out = *in
 	in.StartTime.DeepCopyInto(&out.StartTime)
 	in.EndTime.DeepCopyInto(&out.EndTime)
+	in.NextAttemptTime.DeepCopyInto(&out.NextAttemptTime)
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTaskStatus.
@@ -1168,6 +1396,20 @@ func (in *KeptnWorkloadSpec) DeepCopyInto(out *KeptnWorkloadSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PreDeploymentTaskRefs != nil {
+		in, out := &in.PreDeploymentTaskRefs, &out.PreDeploymentTaskRefs
+		*out = make([]TaskReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostDeploymentTaskRefs != nil {
+		in, out := &in.PostDeploymentTaskRefs, &out.PostDeploymentTaskRefs
+		*out = make([]TaskReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PreDeploymentEvaluations != nil {
 		in, out := &in.PreDeploymentEvaluations, &out.PreDeploymentEvaluations
 		*out = make([]string, len(*in))
@@ -1178,7 +1420,42 @@ func (in *KeptnWorkloadSpec) DeepCopyInto(out *KeptnWorkloadSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PreDeploymentEvaluationRefs != nil {
+		in, out := &in.PreDeploymentEvaluationRefs, &out.PreDeploymentEvaluationRefs
+		*out = make([]EvaluationReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostDeploymentEvaluationRefs != nil {
+		in, out := &in.PostDeploymentEvaluationRefs, &out.PostDeploymentEvaluationRefs
+		*out = make([]EvaluationReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreDeploymentAnalyses != nil {
+		in, out := &in.PreDeploymentAnalyses, &out.PreDeploymentAnalyses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostDeploymentAnalyses != nil {
+		in, out := &in.PostDeploymentAnalyses, &out.PostDeploymentAnalyses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.ResourceReference = in.ResourceReference
+	if in.ReadinessCheck != nil {
+		in, out := &in.ReadinessCheck, &out.ReadinessCheck
+		*out = new(ReadinessCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObservabilityTimeout != nil {
+		in, out := &in.ObservabilityTimeout, &out.ObservabilityTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.AnalysisTimeframe != nil {
+		in, out := &in.AnalysisTimeframe, &out.AnalysisTimeframe
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = make(map[string]string, len(*in))
@@ -1326,6 +1603,20 @@ func (in *KeptnWorkloadVersionStatus) DeepCopyInto(out *KeptnWorkloadVersionStat
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PreDeploymentAnalysisStatus != nil {
+		in, out := &in.PreDeploymentAnalysisStatus, &out.PreDeploymentAnalysisStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostDeploymentAnalysisStatus != nil {
+		in, out := &in.PostDeploymentAnalysisStatus, &out.PostDeploymentAnalysisStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.StartTime.DeepCopyInto(&out.StartTime)
 	in.EndTime.DeepCopyInto(&out.EndTime)
 	if in.PhaseTraceIDs != nil {
@@ -1354,6 +1645,8 @@ func (in *KeptnWorkloadVersionStatus) DeepCopyInto(out *KeptnWorkloadVersionStat
 		}
 	}
 	in.DeploymentStartTime.DeepCopyInto(&out.DeploymentStartTime)
+	in.DeploymentEndTime.DeepCopyInto(&out.DeploymentEndTime)
+	in.DeploymentDeadline.DeepCopyInto(&out.DeploymentDeadline)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnWorkloadVersionStatus.
@@ -1382,6 +1675,26 @@ func (in *Objective) DeepCopy() *Objective {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessCheck) DeepCopyInto(out *ReadinessCheck) {
+	*out = *in
+	if in.MaxRestarts != nil {
+		in, out := &in.MaxRestarts, &out.MaxRestarts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessCheck.
+func (in *ReadinessCheck) DeepCopy() *ReadinessCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
 	*out = *in
@@ -1397,6 +1710,28 @@ func (in *ResourceReference) DeepCopy() *ResourceReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	out.Delay = in.Delay
+	out.MaxDelay = in.MaxDelay
+	if in.OnExitCodes != nil {
+		in, out := &in.OnExitCodes, &out.OnExitCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuntimeSpec) DeepCopyInto(out *RuntimeSpec) {
 	*out = *in
@@ -1406,6 +1741,13 @@ func (in *RuntimeSpec) DeepCopyInto(out *RuntimeSpec) {
 	out.ConfigMapReference = in.ConfigMapReference
 	in.Parameters.DeepCopyInto(&out.Parameters)
 	out.SecureParameters = in.SecureParameters
+	if in.Mounts != nil {
+		in, out := &in.Mounts, &out.Mounts
+		*out = make([]TaskMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeSpec.
@@ -1458,6 +1800,24 @@ func (in *TaskContext) DeepCopyInto(out *TaskContext) {
 			(*out)[key] = val
 		}
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskContext.
@@ -1470,6 +1830,28 @@ func (in *TaskContext) DeepCopy() *TaskContext {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskMount) DeepCopyInto(out *TaskMount) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskMount.
+func (in *TaskMount) DeepCopy() *TaskMount {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskParameters) DeepCopyInto(out *TaskParameters) {
 	*out = *in
@@ -1492,6 +1874,41 @@ func (in *TaskParameters) DeepCopy() *TaskParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskReference) DeepCopyInto(out *TaskReference) {
+	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskReference.
+func (in *TaskReference) DeepCopy() *TaskReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TotalScore) DeepCopyInto(out *TotalScore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TotalScore.
+func (in *TotalScore) DeepCopy() *TotalScore {
+	if in == nil {
+		return nil
+	}
+	out := new(TotalScore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadStatus) DeepCopyInto(out *WorkloadStatus) {
 	*out = *in
@@ -36,6 +36,16 @@ type KeptnConfigSpec struct {
 	// +optional
 	CloudEventsEndpoint string `json:"cloudEventsEndpoint,omitempty"`
 
+	// CloudEventsEndpoints is a list of further endpoints where Cloud Events are posted by the lifecycle operator.
+	// Each endpoint only receives the Cloud Events matching its filter.
+	// +optional
+	CloudEventsEndpoints []CloudEventsEndpoint `json:"cloudEventsEndpoints,omitempty"`
+
+	// CloudEventsDelivery configures how Cloud Events are delivered to the CloudEventsEndpoint and CloudEventsEndpoints
+	// +kubebuilder:default:={}
+	// +optional
+	CloudEventsDelivery CloudEventsDelivery `json:"cloudEventsDelivery,omitempty"`
+
 	// BlockDeployment is used to block the deployment of the application until the pre-deployment
 	// tasks and evaluations succeed
 	// +kubebuilder:default:=true
@@ -51,10 +61,169 @@ type KeptnConfigSpec struct {
 	// +optional
 	ObservabilityTimeout metav1.Duration `json:"observabilityTimeout,omitempty"`
 
-	// RestApiEnabled can be used to enable or disable the Keptn Rest Client
+	// RestApiEnabled can be used to enable or disable the read-only Keptn REST API served by the lifecycle-operator.
+	// The API can be toggled at runtime without restarting the lifecycle-operator.
 	// +kubebuilder:default:=false
 	// +optional
 	RestApiEnabled bool `json:"restApiEnabled,omitempty"`
+
+	// TaskDefinitionLibraries is a list of namespaces containing shared KeptnTaskDefinitions,
+	// together with the namespaces that are allowed to use them.
+	// KeptnTaskDefinitions of a library namespace can be referenced as <namespace>/<name>.
+	// +optional
+	TaskDefinitionLibraries []TaskDefinitionLibrary `json:"taskDefinitionLibraries,omitempty"`
+
+	// MaxParallelTasks is the maximum number of KeptnTasks that are executed in parallel in the cluster.
+	// Further KeptnTasks stay Pending until one of the running KeptnTasks is completed.
+	// A value of 0 means that the number of KeptnTasks is not limited.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	MaxParallelTasks int `json:"maxParallelTasks,omitempty"`
+
+	// MaxParallelTasksPerNamespace is the maximum number of KeptnTasks that are executed in parallel in a namespace.
+	// Further KeptnTasks stay Pending until one of the running KeptnTasks is completed.
+	// A value of 0 means that the number of KeptnTasks is not limited.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	MaxParallelTasksPerNamespace int `json:"maxParallelTasksPerNamespace,omitempty"`
+
+	// FunctionCodeSources restricts the URLs the lifecycle-operator fetches the code of
+	// KeptnTaskDefinitions from when their httpRef is cached.
+	// By default, the code is only fetched via HTTPS from public addresses.
+	// +kubebuilder:default:={}
+	// +optional
+	FunctionCodeSources FunctionCodeSources `json:"functionCodeSources,omitempty"`
+}
+
+// CloudEventsEndpoint defines an endpoint receiving the Cloud Events that match its filter.
+type CloudEventsEndpoint struct {
+	// URL is the URL where the Cloud Events are posted.
+	URL string `json:"url"`
+
+	// HeadersSecretName is the name of a Secret in the namespace of the KeptnConfig.
+	// Each key of the Secret is added as HTTP header with the corresponding value
+	// to the requests sent to the endpoint, e.g. to authenticate against it.
+	// +optional
+	HeadersSecretName string `json:"headersSecretName,omitempty"`
+
+	// Filter selects the Cloud Events sent to the endpoint.
+	// If no filter is set, the endpoint receives all Cloud Events.
+	// +optional
+	Filter CloudEventsFilter `json:"filter,omitempty"`
+
+	// Format is the format of the Cloud Events sent to the endpoint.
+	// The keptn format describes the Keptn phases, while the cdevents format
+	// produces events compliant with the CDEvents specification, e.g. service.deployed.
+	// +kubebuilder:validation:Enum:=keptn;cdevents
+	// +kubebuilder:default:=keptn
+	// +optional
+	Format string `json:"format,omitempty"`
+}
+
+// CloudEventsFilter selects Cloud Events.
+// A Cloud Event matches the filter if it matches all lists of the filter, an empty list matches all Cloud Events.
+type CloudEventsFilter struct {
+	// Namespaces is a list of namespaces of the resources the Cloud Events are emitted for.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Phases is a list of short names of the phases the Cloud Events are emitted in, e.g. AppPreDeployTasks.
+	// +optional
+	Phases []string `json:"phases,omitempty"`
+
+	// Statuses is a list of statuses of the phases the Cloud Events are emitted for, e.g. Failed.
+	// +optional
+	Statuses []string `json:"statuses,omitempty"`
+
+	// Types is a list of event types of the Cloud Events, i.e. Normal or Warning.
+	// +optional
+	Types []string `json:"types,omitempty"`
+}
+
+// CloudEventsDelivery defines how Cloud Events are queued, retried and batched
+// before they are sent to the CloudEventsEndpoint.
+type CloudEventsDelivery struct {
+	// QueueSize is the maximum number of Cloud Events waiting to be delivered.
+	// Further Cloud Events are dropped until the queue has free capacity again.
+	// +kubebuilder:default:=1000
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	QueueSize int `json:"queueSize,omitempty"`
+
+	// MaxRetries is the number of retries after a Cloud Event could not be delivered.
+	// A value of 0 disables retries.
+	// +kubebuilder:default:=5
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	MaxRetries *int `json:"maxRetries,omitempty"`
+
+	// RetryBackoff is the delay before the first retry, which is doubled with each further retry.
+	// +kubebuilder:default:="1s"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	RetryBackoff metav1.Duration `json:"retryBackoff,omitempty"`
+
+	// BatchSize is the maximum number of Cloud Events sent in a single request using the batched content mode.
+	// A value of 1 disables batching.
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	BatchSize int `json:"batchSize,omitempty"`
+
+	// DeadLetterSize is the maximum number of Cloud Events that are kept after all retries failed.
+	// They are sent again once the CloudEventsEndpoint is reachable again.
+	// If the dead-letter buffer is full, the oldest Cloud Events are dropped.
+	// +kubebuilder:default:=1000
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	DeadLetterSize int `json:"deadLetterSize,omitempty"`
+
+	// DeadLetterMaxAge is the maximum age of the Cloud Events in the dead-letter buffer.
+	// Older Cloud Events are dropped instead of being sent again.
+	// +kubebuilder:default:="1h"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	DeadLetterMaxAge metav1.Duration `json:"deadLetterMaxAge,omitempty"`
+
+	// HeadersSecretName is the name of a Secret in the namespace of the KeptnConfig.
+	// Each key of the Secret is added as HTTP header with the corresponding value
+	// to the requests sent to the CloudEventsEndpoint, e.g. to authenticate against it.
+	// The headers of the CloudEventsEndpoints are configured for each endpoint.
+	// +optional
+	HeadersSecretName string `json:"headersSecretName,omitempty"`
+}
+
+// TaskDefinitionLibrary defines a namespace containing shared KeptnTaskDefinitions
+// and the namespaces that are allowed to use them.
+type TaskDefinitionLibrary struct {
+	// Namespace is the namespace containing the shared KeptnTaskDefinitions.
+	Namespace string `json:"namespace"`
+	// AllowedNamespaces is the list of namespaces that are allowed to use the KeptnTaskDefinitions
+	// of the library. The value "*" allows all namespaces to use them.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+// FunctionCodeSources defines the URLs the code of KeptnTaskDefinitions can be fetched from.
+type FunctionCodeSources struct {
+	// Schemes is the list of allowed URL schemes.
+	// +kubebuilder:default:={https}
+	// +optional
+	Schemes []string `json:"schemes,omitempty"`
+
+	// Hosts is the list of allowed hosts. An entry starting with "*." allows all subdomains of the domain,
+	// e.g. *.example.com allows functions.example.com.
+	// If no hosts are set, the code can be fetched from all hosts.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+
+	// AllowPrivateAddresses allows fetching the code from loopback, private and link-local addresses,
+	// e.g. from Services in the cluster or from the metadata endpoint of the cloud provider.
+	// +kubebuilder:default:=false
+	// +optional
+	AllowPrivateAddresses bool `json:"allowPrivateAddresses,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -24,12 +24,110 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventsDelivery) DeepCopyInto(out *CloudEventsDelivery) {
+	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int)
+		**out = **in
+	}
+	out.RetryBackoff = in.RetryBackoff
+	out.DeadLetterMaxAge = in.DeadLetterMaxAge
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventsDelivery.
+func (in *CloudEventsDelivery) DeepCopy() *CloudEventsDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventsDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventsEndpoint) DeepCopyInto(out *CloudEventsEndpoint) {
+	*out = *in
+	in.Filter.DeepCopyInto(&out.Filter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventsEndpoint.
+func (in *CloudEventsEndpoint) DeepCopy() *CloudEventsEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventsEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventsFilter) DeepCopyInto(out *CloudEventsFilter) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Statuses != nil {
+		in, out := &in.Statuses, &out.Statuses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventsFilter.
+func (in *CloudEventsFilter) DeepCopy() *CloudEventsFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventsFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionCodeSources) DeepCopyInto(out *FunctionCodeSources) {
+	*out = *in
+	if in.Schemes != nil {
+		in, out := &in.Schemes, &out.Schemes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionCodeSources.
+func (in *FunctionCodeSources) DeepCopy() *FunctionCodeSources {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionCodeSources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeptnConfig) DeepCopyInto(out *KeptnConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnConfig.
@@ -85,7 +183,23 @@ func (in *KeptnConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeptnConfigSpec) DeepCopyInto(out *KeptnConfigSpec) {
 	*out = *in
+	if in.CloudEventsEndpoints != nil {
+		in, out := &in.CloudEventsEndpoints, &out.CloudEventsEndpoints
+		*out = make([]CloudEventsEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.CloudEventsDelivery.DeepCopyInto(&out.CloudEventsDelivery)
 	out.ObservabilityTimeout = in.ObservabilityTimeout
+	if in.TaskDefinitionLibraries != nil {
+		in, out := &in.TaskDefinitionLibraries, &out.TaskDefinitionLibraries
+		*out = make([]TaskDefinitionLibrary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.FunctionCodeSources.DeepCopyInto(&out.FunctionCodeSources)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnConfigSpec.
@@ -97,3 +211,23 @@ func (in *KeptnConfigSpec) DeepCopy() *KeptnConfigSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskDefinitionLibrary) DeepCopyInto(out *TaskDefinitionLibrary) {
+	*out = *in
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDefinitionLibrary.
+func (in *TaskDefinitionLibrary) DeepCopy() *TaskDefinitionLibrary {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskDefinitionLibrary)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,159 @@
+package evaluationtarget
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	andSeparator   = "&&"
+	orSeparator    = "||"
+	rangeSeparator = ".."
+	percentSuffix  = "%"
+)
+
+// operators are ordered so that two-character operators are matched before their one-character prefixes
+var operators = []string{"<=", ">=", "==", "!=", "<", ">"}
+
+// Target is a parsed evaluation target of an objective.
+// A Target is a disjunction (||) of conjunctions (&&) of conditions, where each condition is either
+// a comparison such as '<=10', a relative comparison against the previous value such as '<=+10%',
+// or an inclusive range such as '10..50'.
+type Target struct {
+	alternatives [][]condition
+}
+
+type condition struct {
+	operator string
+	value    float64
+	relative bool
+	// upper is only set for ranges, in which case value is the lower bound
+	upper float64
+}
+
+// Parse parses the given evaluation target
+func Parse(target string) (*Target, error) {
+	if strings.TrimSpace(target) == "" {
+		return nil, fmt.Errorf("evaluation target must not be empty")
+	}
+	t := &Target{}
+	for _, alternative := range strings.Split(target, orSeparator) {
+		var conditions []condition
+		for _, term := range strings.Split(alternative, andSeparator) {
+			c, err := parseCondition(strings.TrimSpace(term))
+			if err != nil {
+				return nil, fmt.Errorf("invalid evaluation target '%s': %w", target, err)
+			}
+			conditions = append(conditions, c)
+		}
+		t.alternatives = append(t.alternatives, conditions)
+	}
+	return t, nil
+}
+
+// IsRelative returns true if the target compares against the value of the previous version
+func (t Target) IsRelative() bool {
+	for _, alternative := range t.alternatives {
+		for _, c := range alternative {
+			if c.relative {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Evaluate checks whether the given value meets the target.
+// previous is the value measured for the previous version and is only required for relative targets.
+// If it is nil, relative conditions are skipped, i.e. considered as met, as there is no baseline to compare with.
+func (t Target) Evaluate(value float64, previous *float64) bool {
+	for _, alternative := range t.alternatives {
+		met := true
+		for _, c := range alternative {
+			if !c.evaluate(value, previous) {
+				met = false
+				break
+			}
+		}
+		if met {
+			return true
+		}
+	}
+	return false
+}
+
+func (c condition) evaluate(value float64, previous *float64) bool {
+	if c.operator == rangeSeparator {
+		return value >= c.value && value <= c.upper
+	}
+
+	compareValue := c.value
+	if c.relative {
+		if previous == nil {
+			return true
+		}
+		compareValue = *previous * (1 + c.value/100)
+	}
+
+	switch c.operator {
+	case "<=":
+		return value <= compareValue
+	case ">=":
+		return value >= compareValue
+	case "==":
+		return value == compareValue
+	case "!=":
+		return value != compareValue
+	case "<":
+		return value < compareValue
+	default:
+		return value > compareValue
+	}
+}
+
+func parseCondition(term string) (condition, error) {
+	if term == "" {
+		return condition{}, fmt.Errorf("empty condition")
+	}
+
+	for _, operator := range operators {
+		if !strings.HasPrefix(term, operator) {
+			continue
+		}
+		operand := strings.TrimSpace(strings.TrimPrefix(term, operator))
+		relative := strings.HasSuffix(operand, percentSuffix)
+		value, err := parseNumber(strings.TrimSuffix(operand, percentSuffix))
+		if err != nil {
+			return condition{}, err
+		}
+		return condition{operator: operator, value: value, relative: relative}, nil
+	}
+
+	if lower, upper, found := strings.Cut(term, rangeSeparator); found {
+		lowerValue, err := parseNumber(lower)
+		if err != nil {
+			return condition{}, err
+		}
+		upperValue, err := parseNumber(upper)
+		if err != nil {
+			return condition{}, err
+		}
+		if lowerValue > upperValue {
+			return condition{}, fmt.Errorf("lower bound of range '%s' is greater than its upper bound", term)
+		}
+		return condition{operator: rangeSeparator, value: lowerValue, upper: upperValue}, nil
+	}
+
+	return condition{}, fmt.Errorf("condition '%s' must start with one of %s or be a range such as '10..50'", term, strings.Join(operators, ", "))
+}
+
+func parseNumber(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("'%s' is not a valid number", s)
+	}
+	return value, nil
+}
@@ -0,0 +1,80 @@
+package evaluationtarget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTarget_Evaluate(t *testing.T) {
+	previous := 100.0
+
+	tests := []struct {
+		name     string
+		target   string
+		value    float64
+		previous *float64
+		want     bool
+	}{
+		{name: "greater than", target: ">10", value: 11, want: true},
+		{name: "not greater than", target: ">10", value: 10, want: false},
+		{name: "less than", target: "<10", value: 9, want: true},
+		{name: "less than or equal", target: "<=10", value: 10, want: true},
+		{name: "greater than or equal", target: ">= 10", value: 9.5, want: false},
+		{name: "equal", target: "==0", value: 0, want: true},
+		{name: "not equal", target: "!=0", value: 0, want: false},
+		{name: "negative number", target: ">-5", value: -4, want: true},
+		{name: "range", target: "10..50", value: 50, want: true},
+		{name: "outside range", target: "10..50", value: 50.1, want: false},
+		{name: "compound", target: ">10 && <50", value: 20, want: true},
+		{name: "compound not met", target: ">10 && <50", value: 50, want: false},
+		{name: "alternatives", target: "<10 || >50", value: 60, want: true},
+		{name: "alternatives not met", target: "<10 || >50", value: 20, want: false},
+		{name: "relative", target: "<=+10%", value: 110, previous: &previous, want: true},
+		{name: "relative not met", target: "<=10%", value: 111, previous: &previous, want: false},
+		{name: "relative decrease", target: ">=-5%", value: 95, previous: &previous, want: true},
+		{name: "relative without previous value", target: "<=+10%", value: 110, want: true},
+		{name: "relative without previous value in compound", target: "<100 && <=+10%", value: 110, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := Parse(tt.target)
+			require.Nil(t, err)
+
+			require.Equal(t, tt.want, target.Evaluate(tt.value, tt.previous))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       string
+		wantErr      bool
+		wantRelative bool
+	}{
+		{name: "simple", target: "<10"},
+		{name: "compound", target: ">=1 && <=2.5 || ==10"},
+		{name: "relative", target: "<=+10%", wantRelative: true},
+		{name: "relative in compound", target: "<100 && <=+10%", wantRelative: true},
+		{name: "empty", target: "", wantErr: true},
+		{name: "missing operator", target: "10", wantErr: true},
+		{name: "invalid operator", target: "=>10", wantErr: true},
+		{name: "invalid number", target: ">ten", wantErr: true},
+		{name: "nan", target: ">nan", wantErr: true},
+		{name: "empty condition", target: ">10 &&", wantErr: true},
+		{name: "inverted range", target: "50..10", wantErr: true},
+		{name: "invalid range", target: "10..", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := Parse(tt.target)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.wantRelative, target.IsRelative())
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package expression
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+// Variables contains the values that can be referenced in an expression
+type Variables struct {
+	// AppName is the name of the KeptnApp
+	AppName string
+	// AppVersion is the version of the KeptnApp, empty for workloads
+	AppVersion string
+	// WorkloadName is the name of the KeptnWorkload, empty for apps
+	WorkloadName string
+	// WorkloadVersion is the version of the KeptnWorkload, empty for apps
+	WorkloadVersion string
+	// Version is the version of the KeptnApp or KeptnWorkload the expression is evaluated for
+	Version string
+	// PreviousVersion is the version of the KeptnApp or KeptnWorkload deployed prior to Version
+	PreviousVersion string
+	// ObjectType is either "App" or "Workload"
+	ObjectType string
+	// CheckType is the type of the phase, e.g. "pre" or "post"
+	CheckType string
+	// Metadata contains the metadata of the KeptnAppContext and KeptnWorkload
+	Metadata map[string]string
+}
+
+func (v Variables) toActivation() map[string]any {
+	metadata := v.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	return map[string]any{
+		"appName":         v.AppName,
+		"appVersion":      v.AppVersion,
+		"workloadName":    v.WorkloadName,
+		"workloadVersion": v.WorkloadVersion,
+		"version":         v.Version,
+		"previousVersion": v.PreviousVersion,
+		"objectType":      v.ObjectType,
+		"checkType":       v.CheckType,
+		"metadata":        metadata,
+	}
+}
+
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("appName", cel.StringType),
+		cel.Variable("appVersion", cel.StringType),
+		cel.Variable("workloadName", cel.StringType),
+		cel.Variable("workloadVersion", cel.StringType),
+		cel.Variable("version", cel.StringType),
+		cel.Variable("previousVersion", cel.StringType),
+		cel.Variable("objectType", cel.StringType),
+		cel.Variable("checkType", cel.StringType),
+		cel.Variable("metadata", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Function("majorVersion",
+			cel.Overload("majorVersion_string", []*cel.Type{cel.StringType}, cel.IntType,
+				cel.UnaryBinding(versionPartBinding(0)),
+			),
+		),
+		cel.Function("minorVersion",
+			cel.Overload("minorVersion_string", []*cel.Type{cel.StringType}, cel.IntType,
+				cel.UnaryBinding(versionPartBinding(1)),
+			),
+		),
+	)
+}
+
+// Validate checks that the given expression is syntactically correct and evaluates to a boolean
+func Validate(expression string) error {
+	_, err := compile(expression)
+	return err
+}
+
+// Evaluate evaluates the given expression against the given variables.
+// An empty expression always evaluates to true.
+// An error is returned if the expression is invalid or cannot be evaluated, e.g. because it accesses a metadata key
+// that is not set or the major version of an empty version. Optional metadata keys can be checked with
+// has(metadata.<key>) or "<key>" in metadata before accessing them.
+func Evaluate(expression string, variables Variables) (bool, error) {
+	if strings.TrimSpace(expression) == "" {
+		return true, nil
+	}
+	program, err := compile(expression)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := program.Eval(variables.toActivation())
+	if err != nil {
+		return false, fmt.Errorf("could not evaluate expression '%s': %w", expression, err)
+	}
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression '%s' does not evaluate to a boolean", expression)
+	}
+	return result, nil
+}
+
+func compile(expression string) (cel.Program, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("could not compile expression '%s': %w", expression, issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("expression '%s' does not evaluate to a boolean", expression)
+	}
+	return env.Program(ast)
+}
+
+// versionPartBinding returns a function extracting the part with the given index
+// of a version in the format [v]<major>.<minor>.<patch>
+func versionPartBinding(index int) func(ref.Val) ref.Val {
+	return func(val ref.Val) ref.Val {
+		version, ok := val.Value().(string)
+		if !ok {
+			return types.MaybeNoSuchOverloadErr(val)
+		}
+		parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+		if len(parts) <= index {
+			return types.NewErr("version '%s' does not contain a part with index %d", version, index)
+		}
+		// ignore pre-release and build information, e.g. 1.2.3-rc.1+build
+		part := strings.FieldsFunc(parts[index], func(r rune) bool { return r == '-' || r == '+' })
+		if len(part) == 0 {
+			return types.NewErr("version '%s' is not a valid version", version)
+		}
+		number, err := strconv.Atoi(part[0])
+		if err != nil {
+			return types.NewErr("version '%s' is not a valid version", version)
+		}
+		return types.Int(number)
+	}
+}
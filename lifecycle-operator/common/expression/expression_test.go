@@ -0,0 +1,106 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	variables := Variables{
+		AppName:         "my-app",
+		AppVersion:      "2.0.0",
+		Version:         "2.0.0",
+		PreviousVersion: "v1.3.0",
+		ObjectType:      "App",
+		CheckType:       "pre",
+		Metadata: map[string]string{
+			"env": "prod",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		want       bool
+		wantErr    bool
+	}{
+		{
+			name:       "empty expression",
+			expression: "",
+			want:       true,
+		},
+		{
+			name:       "metadata matches",
+			expression: `metadata["env"] == "prod"`,
+			want:       true,
+		},
+		{
+			name:       "metadata does not match",
+			expression: `"env" in metadata && metadata["env"] == "dev"`,
+			want:       false,
+		},
+		{
+			name:       "major version bump",
+			expression: "majorVersion(version) > majorVersion(previousVersion)",
+			want:       true,
+		},
+		{
+			name:       "minor version",
+			expression: "minorVersion(previousVersion) == 3 && objectType == 'App'",
+			want:       true,
+		},
+		{
+			name:       "missing metadata key",
+			expression: `metadata["region"] == "eu"`,
+			wantErr:    true,
+		},
+		{
+			name:       "negated missing metadata key",
+			expression: `metadata["region"] != "eu"`,
+			wantErr:    true,
+		},
+		{
+			name:       "missing metadata key checked with has",
+			expression: `!has(metadata.region) || metadata.region == "eu"`,
+			want:       true,
+		},
+		{
+			name:       "missing metadata key checked with in",
+			expression: `"region" in metadata && metadata["region"] == "eu"`,
+			want:       false,
+		},
+		{
+			name:       "invalid version",
+			expression: `majorVersion(workloadVersion) > 1`,
+			wantErr:    true,
+		},
+		{
+			name:       "not a boolean",
+			expression: "appName",
+			wantErr:    true,
+		},
+		{
+			name:       "unknown variable",
+			expression: "foo == 'bar'",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Evaluate(tt.expression, variables)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	require.Nil(t, Validate(`metadata["env"] == "prod"`))
+	require.Error(t, Validate(`metadata["env"] ==`))
+	require.Error(t, Validate(`version`))
+}
@@ -0,0 +1,224 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const analysisStateCompleted = "Completed"
+
+// Handler creates the Analyses of the metrics-operator referenced by a KeptnAppVersion or KeptnWorkloadVersion
+// and tracks their results.
+type Handler struct {
+	client.Client
+	EventSender eventsender.IEvent
+	Log         logr.Logger
+	Scheme      *runtime.Scheme
+}
+
+// NewHandler creates a new instance of the Handler.
+func NewHandler(client client.Client, eventSender eventsender.IEvent, log logr.Logger, scheme *runtime.Scheme) Handler {
+	return Handler{
+		Client:      client,
+		EventSender: eventSender,
+		Log:         log,
+		Scheme:      scheme,
+	}
+}
+
+// ReconcileAnalyses creates an Analysis for each AnalysisDefinition referenced in the phase of the given check type
+// and returns their current state.
+func (r Handler) ReconcileAnalyses(ctx context.Context, reconcileObject client.Object, checkType apicommon.CheckType) ([]apilifecycle.ItemStatus, apicommon.StatusSummary, error) {
+	piWrapper, err := interfaces.NewPhaseItemWrapperFromClientObject(reconcileObject)
+	if err != nil {
+		return nil, apicommon.StatusSummary{}, err
+	}
+
+	analyses := piWrapper.GetAnalyses(checkType)
+	statuses := piWrapper.GetAnalysisStatus(checkType)
+
+	var summary apicommon.StatusSummary
+	summary.Total = len(analyses)
+	var newStatus []apilifecycle.ItemStatus
+	for _, definitionName := range analyses {
+		analysisStatus := common.GetItemStatus(definitionName, statuses)
+
+		// Check if analysis has already passed or failed
+		if analysisStatus.Status.IsCompleted() {
+			newStatus = append(newStatus, analysisStatus)
+			continue
+		}
+
+		analysis := &unstructured.Unstructured{}
+		analysis.SetGroupVersionKind(common.AnalysisGVK)
+		if analysisStatus.Name != "" {
+			err := r.Client.Get(ctx, types.NamespacedName{Name: analysisStatus.Name, Namespace: piWrapper.GetNamespace()}, analysis)
+			if err != nil && errors.IsNotFound(err) {
+				analysisStatus.Name = ""
+			} else if err != nil {
+				return nil, summary, err
+			}
+		}
+
+		if analysisStatus.Name == "" {
+			if _, to := getAnalysisTimeframe(piWrapper, checkType); to.After(time.Now()) {
+				// the timeframe to be evaluated has not elapsed yet
+				r.Log.Info("Waiting for the analysis timeframe to elapse",
+					"analysisDefinition", definitionName,
+					"namespace", piWrapper.GetNamespace(),
+					"until", to,
+				)
+				newStatus = append(newStatus, analysisStatus)
+				continue
+			}
+			analysisName, err := r.CreateAnalysis(ctx, reconcileObject, definitionName, checkType)
+			if err != nil {
+				// log the error, but continue to proceed with other analyses that may be created
+				r.Log.Error(err, "Could not create analysis",
+					"analysisDefinition", definitionName,
+					"namespace", piWrapper.GetNamespace(),
+				)
+				continue
+			}
+			analysisStatus.Name = analysisName
+			analysisStatus.SetStartTime()
+		} else {
+			r.updateAnalysisStatus(analysis, &analysisStatus, piWrapper, reconcileObject)
+		}
+		newStatus = append(newStatus, analysisStatus)
+	}
+
+	for _, ns := range newStatus {
+		summary = apicommon.UpdateStatusSummary(ns.Status, summary)
+	}
+
+	return newStatus, summary, nil
+}
+
+// CreateAnalysis creates an Analysis for the given AnalysisDefinition.
+// The timeframe of the Analysis is derived from the phase of the given check type, see getAnalysisTimeframe.
+func (r Handler) CreateAnalysis(ctx context.Context, reconcileObject client.Object, definitionName string, checkType apicommon.CheckType) (string, error) {
+	piWrapper, err := interfaces.NewPhaseItemWrapperFromClientObject(reconcileObject)
+	if err != nil {
+		return "", err
+	}
+
+	definition, err := common.GetAnalysisDefinition(r.Client, r.Log, ctx, definitionName, piWrapper.GetNamespace())
+	if err != nil {
+		return "", controllererrors.ErrCannotGetAnalysisDefinition
+	}
+
+	from, to := getAnalysisTimeframe(piWrapper, checkType)
+
+	args := map[string]interface{}{
+		"appName":   piWrapper.GetAppName(),
+		"name":      piWrapper.GetParentName(),
+		"namespace": piWrapper.GetNamespace(),
+		"version":   piWrapper.GetVersion(),
+	}
+	if previousVersion := piWrapper.GetPreviousVersion(); previousVersion != "" {
+		args["previousVersion"] = previousVersion
+	}
+
+	analysis := &unstructured.Unstructured{}
+	analysis.SetGroupVersionKind(common.AnalysisGVK)
+	analysis.SetName(apicommon.GenerateEvaluationName(checkType, definitionName))
+	analysis.SetNamespace(piWrapper.GetNamespace())
+	analysis.Object["spec"] = map[string]interface{}{
+		"timeframe": map[string]interface{}{
+			"from": from.Format(time.RFC3339),
+			"to":   to.Format(time.RFC3339),
+		},
+		"args": args,
+		"analysisDefinition": map[string]interface{}{
+			"name":      definition.GetName(),
+			"namespace": definition.GetNamespace(),
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(reconcileObject, analysis, r.Scheme); err != nil {
+		r.Log.Error(err, "could not set controller reference:")
+	}
+	if err := r.Client.Create(ctx, analysis); err != nil {
+		r.Log.Error(err, "could not create Analysis")
+		r.EventSender.Emit(apicommon.PhaseCreateEvaluation, "Warning", reconcileObject, apicommon.PhaseStateFailed, "could not create Analysis", piWrapper.GetVersion())
+		return "", err
+	}
+
+	return analysis.GetName(), nil
+}
+
+func (r Handler) updateAnalysisStatus(analysis *unstructured.Unstructured, analysisStatus *apilifecycle.ItemStatus, piWrapper *interfaces.PhaseItemWrapper, reconcileObject client.Object) {
+	oldStatus := analysisStatus.Status
+	analysisStatus.Status = getAnalysisState(analysis)
+	if oldStatus != analysisStatus.Status {
+		r.EventSender.Emit(apicommon.PhaseReconcileEvaluation, "Normal", reconcileObject, apicommon.PhaseStateStatusChanged, fmt.Sprintf("analysis %s status changed from %s to %s", analysis.GetName(), oldStatus, analysisStatus.Status), piWrapper.GetVersion())
+	}
+	if !analysisStatus.Status.IsCompleted() {
+		return
+	}
+	if analysisStatus.Status.IsFailed() {
+		raw, _, _ := unstructured.NestedString(analysis.Object, "status", "raw")
+		r.EventSender.Emit(apicommon.PhaseReconcileEvaluation, "Warning", reconcileObject, apicommon.PhaseStateFailed, fmt.Sprintf("analysis %s failed: %s", analysis.GetName(), raw), piWrapper.GetVersion())
+	}
+	analysisStatus.SetEndTime()
+}
+
+// getAnalysisState maps the result of an Analysis to a KeptnState
+func getAnalysisState(analysis *unstructured.Unstructured) apicommon.KeptnState {
+	state, _, _ := unstructured.NestedString(analysis.Object, "status", "state")
+	if state != analysisStateCompleted {
+		return apicommon.StateProgressing
+	}
+	if pass, _, _ := unstructured.NestedBool(analysis.Object, "status", "pass"); pass {
+		return apicommon.StateSucceeded
+	}
+	if warning, _, _ := unstructured.NestedBool(analysis.Object, "status", "warning"); warning {
+		return apicommon.StateWarning
+	}
+	return apicommon.StateFailed
+}
+
+// getAnalysisTimeframe returns the timeframe evaluated by the Analyses of the given check type.
+// Pre-deployment analyses evaluate the AnalysisTimeframe right before the KeptnAppVersion or KeptnWorkloadVersion started,
+// i.e. the version that is currently deployed.
+// Post-deployment analyses evaluate the AnalysisTimeframe right after the deployment phase finished,
+// so they can only be created once this timeframe has elapsed.
+func getAnalysisTimeframe(piWrapper *interfaces.PhaseItemWrapper, checkType apicommon.CheckType) (time.Time, time.Time) {
+	timeframe := piWrapper.GetAnalysisTimeframe()
+	if checkType == apicommon.PostDeploymentEvaluationCheckType {
+		deploymentEnd := piWrapper.GetDeploymentEndTime()
+		if deploymentEnd.IsZero() {
+			deploymentEnd = piWrapper.GetStartTime()
+		}
+		return getTimeframe(deploymentEnd, deploymentEnd.Add(timeframe))
+	}
+	start := piWrapper.GetStartTime()
+	return getTimeframe(start.Add(-timeframe), start)
+}
+
+// getTimeframe returns the timeframe between the given start and end times with a precision of one second,
+// as the metrics-operator requires the end of the timeframe to be after its start.
+func getTimeframe(start time.Time, end time.Time) (time.Time, time.Time) {
+	from := start.UTC().Truncate(time.Second)
+	to := end.UTC().Truncate(time.Second)
+	if !to.After(from) {
+		from = to.Add(-time.Second)
+	}
+	return from, to
+}
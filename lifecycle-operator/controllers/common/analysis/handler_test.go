@@ -0,0 +1,336 @@
+package analysis
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAnalysisHandler(t *testing.T) {
+	startTime := v1.NewTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name        string
+		object      client.Object
+		objects     []client.Object
+		checkType   apicommon.CheckType
+		wantStatus  []apilifecycle.ItemStatus
+		wantSummary apicommon.StatusSummary
+		wantErr     error
+		events      []string
+	}{
+		{
+			name:        "no analyses",
+			object:      newWorkloadVersion(nil, nil, startTime),
+			checkType:   apicommon.PreDeploymentEvaluationCheckType,
+			wantStatus:  nil,
+			wantSummary: apicommon.StatusSummary{},
+		},
+		{
+			name:        "analysis definition not found",
+			object:      newWorkloadVersion([]string{"my-analysis"}, nil, startTime),
+			checkType:   apicommon.PreDeploymentEvaluationCheckType,
+			wantStatus:  nil,
+			wantSummary: apicommon.StatusSummary{Total: 1},
+		},
+		{
+			name:      "analysis is created",
+			object:    newWorkloadVersion([]string{"my-analysis"}, nil, startTime),
+			objects:   []client.Object{newAnalysisDefinition("namespace")},
+			checkType: apicommon.PreDeploymentEvaluationCheckType,
+			wantStatus: []apilifecycle.ItemStatus{
+				{
+					DefinitionName: "my-analysis",
+					Status:         apicommon.StatePending,
+					Name:           "pre-eval-my-analysis-",
+				},
+			},
+			wantSummary: apicommon.StatusSummary{Total: 1, Pending: 1},
+		},
+		{
+			name:      "post-deployment analysis waits for its timeframe to elapse",
+			object:    withDeploymentEndTime(newWorkloadVersion(nil, []string{"my-analysis"}, startTime), v1.Now()),
+			objects:   []client.Object{newAnalysisDefinition("namespace")},
+			checkType: apicommon.PostDeploymentEvaluationCheckType,
+			wantStatus: []apilifecycle.ItemStatus{
+				{
+					DefinitionName: "my-analysis",
+					Status:         apicommon.StatePending,
+				},
+			},
+			wantSummary: apicommon.StatusSummary{Total: 1, Pending: 1},
+		},
+		{
+			name:      "post-deployment analysis is created after its timeframe elapsed",
+			object:    withDeploymentEndTime(newWorkloadVersion(nil, []string{"my-analysis"}, startTime), startTime),
+			objects:   []client.Object{newAnalysisDefinition("namespace")},
+			checkType: apicommon.PostDeploymentEvaluationCheckType,
+			wantStatus: []apilifecycle.ItemStatus{
+				{
+					DefinitionName: "my-analysis",
+					Status:         apicommon.StatePending,
+					Name:           "post-eval-my-analysis-",
+				},
+			},
+			wantSummary: apicommon.StatusSummary{Total: 1, Pending: 1},
+		},
+		{
+			name:      "analysis in progress",
+			object:    newWorkloadVersion(nil, []string{"my-analysis"}, startTime, newItemStatus("my-analysis", apicommon.StatePending)),
+			objects:   []client.Object{newAnalysis("Progressing", false, false)},
+			checkType: apicommon.PostDeploymentEvaluationCheckType,
+			wantStatus: []apilifecycle.ItemStatus{
+				newItemStatus("my-analysis", apicommon.StateProgressing),
+			},
+			wantSummary: apicommon.StatusSummary{Total: 1, Progressing: 1},
+			events: []string{
+				"analysis post-eval-my-analysis-12345 status changed from Pending to Progressing",
+			},
+		},
+		{
+			name:      "analysis passed",
+			object:    newWorkloadVersion(nil, []string{"my-analysis"}, startTime, newItemStatus("my-analysis", apicommon.StateProgressing)),
+			objects:   []client.Object{newAnalysis("Completed", true, false)},
+			checkType: apicommon.PostDeploymentEvaluationCheckType,
+			wantStatus: []apilifecycle.ItemStatus{
+				newItemStatus("my-analysis", apicommon.StateSucceeded),
+			},
+			wantSummary: apicommon.StatusSummary{Total: 1, Succeeded: 1},
+			events: []string{
+				"analysis post-eval-my-analysis-12345 status changed from Progressing to Succeeded",
+			},
+		},
+		{
+			name:      "analysis finished with warning",
+			object:    newWorkloadVersion(nil, []string{"my-analysis"}, startTime, newItemStatus("my-analysis", apicommon.StateProgressing)),
+			objects:   []client.Object{newAnalysis("Completed", false, true)},
+			checkType: apicommon.PostDeploymentEvaluationCheckType,
+			wantStatus: []apilifecycle.ItemStatus{
+				newItemStatus("my-analysis", apicommon.StateWarning),
+			},
+			wantSummary: apicommon.StatusSummary{Total: 1, Warning: 1},
+		},
+		{
+			name:      "analysis failed",
+			object:    newWorkloadVersion(nil, []string{"my-analysis"}, startTime, newItemStatus("my-analysis", apicommon.StateProgressing)),
+			objects:   []client.Object{newAnalysis("Completed", false, false)},
+			checkType: apicommon.PostDeploymentEvaluationCheckType,
+			wantStatus: []apilifecycle.ItemStatus{
+				newItemStatus("my-analysis", apicommon.StateFailed),
+			},
+			wantSummary: apicommon.StatusSummary{Total: 1, Failed: 1},
+			events: []string{
+				"analysis post-eval-my-analysis-12345 status changed from Progressing to Failed",
+				"analysis post-eval-my-analysis-12345 failed: some raw result",
+			},
+		},
+		{
+			name:      "completed analysis is not fetched again",
+			object:    newWorkloadVersion(nil, []string{"my-analysis"}, startTime, newItemStatus("my-analysis", apicommon.StateSucceeded)),
+			checkType: apicommon.PostDeploymentEvaluationCheckType,
+			wantStatus: []apilifecycle.ItemStatus{
+				newItemStatus("my-analysis", apicommon.StateSucceeded),
+			},
+			wantSummary: apicommon.StatusSummary{Total: 1, Succeeded: 1},
+		},
+	}
+
+	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := apilifecycle.AddToScheme(scheme.Scheme)
+			require.Nil(t, err)
+			fakeRecorder := record.NewFakeRecorder(100)
+			fakeClient := fake.NewClientBuilder().WithObjects(append(tt.objects, tt.object)...).Build()
+			handler := NewHandler(
+				fakeClient,
+				eventsender.NewK8sSender(fakeRecorder),
+				ctrl.Log.WithName("controller"),
+				scheme.Scheme,
+			)
+
+			status, summary, err := handler.ReconcileAnalyses(context.TODO(), tt.object, tt.checkType)
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantSummary, summary)
+			require.Len(t, status, len(tt.wantStatus))
+			for i, item := range status {
+				require.Equal(t, tt.wantStatus[i].DefinitionName, item.DefinitionName)
+				require.True(t, strings.HasPrefix(item.Name, tt.wantStatus[i].Name))
+				if tt.wantStatus[i].Name == "" {
+					require.Empty(t, item.Name)
+				}
+				require.Equal(t, tt.wantStatus[i].Status, item.Status)
+			}
+
+			for _, e := range tt.events {
+				event := <-fakeRecorder.Events
+				require.Contains(t, event, e)
+			}
+		})
+	}
+}
+
+func TestAnalysisHandler_CreateAnalysis(t *testing.T) {
+	err := apilifecycle.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
+
+	startTime := v1.NewTime(time.Now().UTC().Add(-5 * time.Minute))
+	workloadVersion := newWorkloadVersion([]string{"my-analysis"}, nil, startTime)
+	fakeClient := fake.NewClientBuilder().WithObjects(workloadVersion, newAnalysisDefinition(testcommon.KeptnNamespace)).Build()
+	handler := NewHandler(
+		fakeClient,
+		eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		ctrl.Log.WithName("controller"),
+		scheme.Scheme,
+	)
+
+	name, err := handler.CreateAnalysis(context.TODO(), workloadVersion, "my-analysis", apicommon.PreDeploymentEvaluationCheckType)
+	require.Nil(t, err)
+
+	analysis := &unstructured.Unstructured{}
+	analysis.SetGroupVersionKind(common.AnalysisGVK)
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "namespace", Name: name}, analysis)
+	require.Nil(t, err)
+
+	require.Len(t, analysis.GetOwnerReferences(), 1)
+	require.Equal(t, "KeptnWorkloadVersion", analysis.GetOwnerReferences()[0].Kind)
+
+	definitionName, _, _ := unstructured.NestedString(analysis.Object, "spec", "analysisDefinition", "name")
+	require.Equal(t, "my-analysis", definitionName)
+	definitionNamespace, _, _ := unstructured.NestedString(analysis.Object, "spec", "analysisDefinition", "namespace")
+	require.Equal(t, testcommon.KeptnNamespace, definitionNamespace)
+
+	from, _, _ := unstructured.NestedString(analysis.Object, "spec", "timeframe", "from")
+	require.Equal(t, startTime.Add(-apilifecycle.DefaultAnalysisTimeframe).Truncate(time.Second).Format(time.RFC3339), from)
+	to, _, _ := unstructured.NestedString(analysis.Object, "spec", "timeframe", "to")
+	require.Equal(t, startTime.Truncate(time.Second).Format(time.RFC3339), to)
+
+	args, _, _ := unstructured.NestedStringMap(analysis.Object, "spec", "args")
+	require.Equal(t, map[string]string{
+		"appName":         "my-app",
+		"name":            "my-workload",
+		"namespace":       "namespace",
+		"version":         "1.0.0",
+		"previousVersion": "0.9.0",
+	}, args)
+
+	_, err = handler.CreateAnalysis(context.TODO(), workloadVersion, "unknown", apicommon.PreDeploymentEvaluationCheckType)
+	require.ErrorIs(t, err, errors.ErrCannotGetAnalysisDefinition)
+}
+
+func Test_getTimeframe(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 500, time.UTC)
+
+	from, to := getTimeframe(start, start.Add(time.Minute))
+	require.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), from)
+	require.Equal(t, time.Date(2024, 1, 1, 10, 1, 0, 0, time.UTC), to)
+
+	from, to = getTimeframe(start, start.Add(time.Millisecond))
+	require.Equal(t, time.Date(2024, 1, 1, 9, 59, 59, 0, time.UTC), from)
+	require.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), to)
+}
+
+func Test_getAnalysisTimeframe(t *testing.T) {
+	startTime := v1.NewTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	deploymentEndTime := v1.NewTime(time.Date(2024, 1, 1, 10, 3, 0, 0, time.UTC))
+
+	workloadVersion := withDeploymentEndTime(newWorkloadVersion(nil, nil, startTime), deploymentEndTime)
+	workloadVersion.Spec.AnalysisTimeframe = &v1.Duration{Duration: 10 * time.Minute}
+	piWrapper, err := interfaces.NewPhaseItemWrapperFromClientObject(workloadVersion)
+	require.Nil(t, err)
+
+	// pre-deployment analyses evaluate the timeframe before the deployment started
+	from, to := getAnalysisTimeframe(piWrapper, apicommon.PreDeploymentEvaluationCheckType)
+	require.Equal(t, time.Date(2024, 1, 1, 9, 50, 0, 0, time.UTC), from)
+	require.Equal(t, startTime.Time, to)
+
+	// post-deployment analyses evaluate the timeframe after the deployment finished
+	from, to = getAnalysisTimeframe(piWrapper, apicommon.PostDeploymentEvaluationCheckType)
+	require.Equal(t, deploymentEndTime.Time, from)
+	require.Equal(t, time.Date(2024, 1, 1, 10, 13, 0, 0, time.UTC), to)
+
+	// the default timeframe is used if none is set
+	workloadVersion.Spec.AnalysisTimeframe = nil
+	from, to = getAnalysisTimeframe(piWrapper, apicommon.PreDeploymentEvaluationCheckType)
+	require.Equal(t, time.Date(2024, 1, 1, 9, 55, 0, 0, time.UTC), from)
+	require.Equal(t, startTime.Time, to)
+}
+
+func newWorkloadVersion(preAnalyses []string, postAnalyses []string, startTime v1.Time, analysisStatus ...apilifecycle.ItemStatus) *apilifecycle.KeptnWorkloadVersion {
+	return &apilifecycle.KeptnWorkloadVersion{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-app-my-workload-1.0.0",
+			Namespace: "namespace",
+		},
+		Spec: apilifecycle.KeptnWorkloadVersionSpec{
+			KeptnWorkloadSpec: apilifecycle.KeptnWorkloadSpec{
+				AppName:                "my-app",
+				Version:                "1.0.0",
+				PreDeploymentAnalyses:  preAnalyses,
+				PostDeploymentAnalyses: postAnalyses,
+			},
+			WorkloadName:    "my-workload",
+			PreviousVersion: "0.9.0",
+		},
+		Status: apilifecycle.KeptnWorkloadVersionStatus{
+			StartTime:                    startTime,
+			PostDeploymentAnalysisStatus: analysisStatus,
+		},
+	}
+}
+
+func withDeploymentEndTime(workloadVersion *apilifecycle.KeptnWorkloadVersion, deploymentEndTime v1.Time) *apilifecycle.KeptnWorkloadVersion {
+	workloadVersion.Status.DeploymentEndTime = deploymentEndTime
+	return workloadVersion
+}
+
+func newItemStatus(definitionName string, state apicommon.KeptnState) apilifecycle.ItemStatus {
+	return apilifecycle.ItemStatus{
+		DefinitionName: definitionName,
+		Status:         state,
+		Name:           "post-eval-" + definitionName + "-12345",
+	}
+}
+
+func newAnalysisDefinition(namespace string) *unstructured.Unstructured {
+	definition := &unstructured.Unstructured{}
+	definition.SetGroupVersionKind(common.AnalysisDefinitionGVK)
+	definition.SetName("my-analysis")
+	definition.SetNamespace(namespace)
+	return definition
+}
+
+func newAnalysis(state string, pass bool, warning bool) *unstructured.Unstructured {
+	analysis := &unstructured.Unstructured{}
+	analysis.SetGroupVersionKind(common.AnalysisGVK)
+	analysis.SetName("post-eval-my-analysis-12345")
+	analysis.SetNamespace("namespace")
+	analysis.Object["status"] = map[string]interface{}{
+		"state":   state,
+		"pass":    pass,
+		"warning": warning,
+		"raw":     "some raw result",
+	}
+	return analysis
+}
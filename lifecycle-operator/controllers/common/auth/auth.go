@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+type userKey struct{}
+
+// Authenticator protects the HTTP endpoints served by the lifecycle-operator.
+// Callers have to send a bearer token that is accepted by the Kubernetes API server, e.g. the token of a
+// ServiceAccount, and need the RBAC permissions of the action they trigger in the namespace they target.
+// There is no way to disable the checks, so that an endpoint never accepts anonymous requests.
+type Authenticator struct {
+	client client.Client
+	log    logr.Logger
+}
+
+func NewAuthenticator(client client.Client, log logr.Logger) *Authenticator {
+	return &Authenticator{
+		client: client,
+		log:    log,
+	}
+}
+
+// Middleware rejects requests without a valid bearer token and passes the authenticated user
+// to the next handler, where it can be retrieved with UserFrom
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := a.Authenticate(r.Context(), r)
+		if err != nil {
+			if !errors.Is(err, controllererrors.ErrNotAuthenticated) {
+				a.log.Error(err, "could not review bearer token")
+			}
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userKey{}, user)))
+	})
+}
+
+// Authenticate returns the user identified by the bearer token of the given request
+func (a *Authenticator) Authenticate(ctx context.Context, r *http.Request) (authenticationv1.UserInfo, error) {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		return authenticationv1.UserInfo{}, controllererrors.ErrNotAuthenticated
+	}
+
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: token,
+		},
+	}
+	if err := a.client.Create(ctx, review); err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+	if !review.Status.Authenticated || review.Status.User.Username == "" {
+		return authenticationv1.UserInfo{}, controllererrors.ErrNotAuthenticated
+	}
+	return review.Status.User, nil
+}
+
+// Authorize checks whether the given user may perform the verb on the Keptn resource in the given namespace.
+// An empty namespace checks the permission in all namespaces.
+func (a *Authenticator) Authorize(ctx context.Context, user authenticationv1.UserInfo, verb string, resource string, subresource string, namespace string) (bool, error) {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   namespace,
+				Verb:        verb,
+				Group:       apilifecycle.GroupVersion.Group,
+				Resource:    resource,
+				Subresource: subresource,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  extra,
+		},
+	}
+	if err := a.client.Create(ctx, review); err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+// UserFrom returns the user authenticated by the Middleware
+func UserFrom(ctx context.Context) authenticationv1.UserInfo {
+	user, _ := ctx.Value(userKey{}).(authenticationv1.UserInfo)
+	return user
+}
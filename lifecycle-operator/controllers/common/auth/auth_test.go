@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAuthenticator_Middleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		wantCode int
+		wantUser string
+	}{
+		{
+			name:     "valid token",
+			header:   "Bearer alice-token",
+			wantCode: http.StatusOK,
+			wantUser: "alice",
+		},
+		{
+			name:     "invalid token",
+			header:   "Bearer wrong",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "empty token",
+			header:   "Bearer ",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "missing bearer scheme",
+			header:   "alice-token",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "missing header",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authenticator := NewAuthenticator(newTestClient(), ctrl.Log.WithName("test"))
+
+			user := ""
+			handler := authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				user = UserFrom(r.Context()).Username
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Equal(t, tt.wantUser, user)
+		})
+	}
+}
+
+func TestAuthenticator_Authorize(t *testing.T) {
+	authenticator := NewAuthenticator(newTestClient(), ctrl.Log.WithName("test"))
+	alice := authenticationv1.UserInfo{Username: "alice"}
+
+	allowed, err := authenticator.Authorize(context.TODO(), alice, "list", "keptntasks", "", "default")
+	require.Nil(t, err)
+	require.True(t, allowed)
+
+	allowed, err = authenticator.Authorize(context.TODO(), alice, "list", "keptntasks", "", "other")
+	require.Nil(t, err)
+	require.False(t, allowed)
+
+	allowed, err = authenticator.Authorize(context.TODO(), alice, "update", "keptntasks", "status", "default")
+	require.Nil(t, err)
+	require.False(t, allowed)
+}
+
+// newTestClient authenticates alice, who may list the Keptn resources in the default namespace
+func newTestClient() client.Client {
+	return testcommon.NewTestClientWithReviews(map[string]string{"alice-token": "alice"}, func(user string, attributes *authorizationv1.ResourceAttributes) bool {
+		return user == "alice" && attributes.Verb == "list" && attributes.Group == "lifecycle.keptn.sh" && attributes.Namespace == "default"
+	})
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	"github.com/keptn/lifecycle-toolkit/keptn-cert-manager/pkg/certificates"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+)
+
+// ListenAndServeTLS serves the given server with the certificate stored as tls.crt and tls.key in certDir,
+// so that bearer tokens are never sent in plain text. The certificate is reloaded whenever the files change,
+// e.g. when the keptn-cert-manager renews the webhook certificate.
+func ListenAndServeTLS(ctx context.Context, log logr.Logger, server *http.Server, certDir string) error {
+	watcher, err := certwatcher.New(
+		filepath.Join(certDir, certificates.ServerCert),
+		filepath.Join(certDir, certificates.ServerKey),
+	)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := watcher.Start(ctx); err != nil {
+			log.Error(err, "could not watch certificate", "directory", certDir)
+		}
+	}()
+
+	server.TLSConfig = &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: watcher.GetCertificate,
+	}
+	return server.ListenAndServeTLS("", "")
+}
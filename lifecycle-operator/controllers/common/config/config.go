@@ -5,10 +5,80 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const defaultKeptnAppCreationRequestTimeout = 30 * time.Second
 
+const (
+	DefaultCloudEventsQueueSize        = 1000
+	DefaultCloudEventsMaxRetries       = 5
+	DefaultCloudEventsRetryBackoff     = 1 * time.Second
+	DefaultCloudEventsBatchSize        = 1
+	DefaultCloudEventsDeadLetterSize   = 1000
+	DefaultCloudEventsDeadLetterMaxAge = 1 * time.Hour
+)
+
+const (
+	// CloudEventsFormatKeptn is the format of the Cloud Events describing Keptn phases
+	CloudEventsFormatKeptn = "keptn"
+	// CloudEventsFormatCDEvents is the format of Cloud Events compliant with the CDEvents specification
+	CloudEventsFormatCDEvents = "cdevents"
+)
+
+// DefaultFunctionCodeScheme is the only URL scheme function code is fetched with if no schemes are configured
+const DefaultFunctionCodeScheme = "https"
+
+// CloudEventsDelivery configures how CloudEvents are delivered to the CloudEvents endpoint
+type CloudEventsDelivery struct {
+	// QueueSize is the maximum number of CloudEvents waiting to be delivered
+	QueueSize int
+	// MaxRetries is the number of retries after a failed delivery
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, which is doubled with each further retry
+	RetryBackoff time.Duration
+	// BatchSize is the maximum number of CloudEvents sent in a single request
+	BatchSize int
+	// DeadLetterSize is the maximum number of CloudEvents kept after all retries failed
+	DeadLetterSize int
+	// DeadLetterMaxAge is the maximum age of the CloudEvents kept after all retries failed
+	DeadLetterMaxAge time.Duration
+	// HeadersSecret references the Secret containing the HTTP headers added to each request
+	// sent to the CloudEvents endpoint
+	HeadersSecret types.NamespacedName
+}
+
+// CloudEventsEndpoint is an endpoint receiving the CloudEvents that match its filter
+type CloudEventsEndpoint struct {
+	// URL is the URL the CloudEvents are posted to
+	URL string
+	// HeadersSecret references the Secret containing the HTTP headers added to each request
+	HeadersSecret types.NamespacedName
+	// Filter selects the CloudEvents sent to the endpoint
+	Filter CloudEventsFilter
+	// Format is the format of the CloudEvents sent to the endpoint, defaults to CloudEventsFormatKeptn
+	Format string
+}
+
+// CloudEventsFilter selects CloudEvents, an empty list matches all values
+type CloudEventsFilter struct {
+	Namespaces []string
+	Phases     []string
+	Statuses   []string
+	Types      []string
+}
+
+// FunctionCodeSources restricts the URLs the function code of KeptnTaskDefinitions is fetched from
+type FunctionCodeSources struct {
+	// Schemes is the list of allowed URL schemes
+	Schemes []string
+	// Hosts is the list of allowed hosts, an entry starting with "*." allows all subdomains of the domain.
+	// An empty list allows all hosts
+	Hosts []string
+	// AllowPrivateAddresses allows fetching function code from loopback, private and link-local addresses
+	AllowPrivateAddresses bool
+}
+
 //go:generate moq -pkg fake -skip-ensure -out ./fake/config_mock.go . IConfig:MockConfig
 type IConfig interface {
 	SetCreationRequestTimeout(value time.Duration)
@@ -23,6 +93,18 @@ type IConfig interface {
 	GetObservabilityTimeout() metav1.Duration
 	SetRestApiEnabled(value bool)
 	GetRestApiEnabled() bool
+	SetTaskDefinitionLibraries(libraries map[string][]string)
+	GetTaskDefinitionLibraries() map[string][]string
+	SetMaxParallelTasks(value int)
+	GetMaxParallelTasks() int
+	SetMaxParallelTasksPerNamespace(value int)
+	GetMaxParallelTasksPerNamespace() int
+	SetCloudEventsDelivery(delivery CloudEventsDelivery)
+	GetCloudEventsDelivery() CloudEventsDelivery
+	SetCloudEventsEndpoints(endpoints []CloudEventsEndpoint)
+	GetCloudEventsEndpoints() []CloudEventsEndpoint
+	SetFunctionCodeSources(sources FunctionCodeSources)
+	GetFunctionCodeSources() FunctionCodeSources
 }
 
 type ControllerConfig struct {
@@ -32,6 +114,12 @@ type ControllerConfig struct {
 	blockDeployment                bool
 	observabilityTimeout           metav1.Duration
 	restApiEnabled                 bool
+	taskDefinitionLibraries        map[string][]string
+	maxParallelTasks               int
+	maxParallelTasksPerNamespace   int
+	cloudEventsDelivery            CloudEventsDelivery
+	cloudEventsEndpoints           []CloudEventsEndpoint
+	functionCodeSources            FunctionCodeSources
 }
 
 var instance *ControllerConfig
@@ -45,6 +133,17 @@ func Instance() *ControllerConfig {
 			observabilityTimeout: metav1.Duration{
 				Duration: time.Duration(5 * time.Minute),
 			},
+			cloudEventsDelivery: CloudEventsDelivery{
+				QueueSize:        DefaultCloudEventsQueueSize,
+				MaxRetries:       DefaultCloudEventsMaxRetries,
+				RetryBackoff:     DefaultCloudEventsRetryBackoff,
+				BatchSize:        DefaultCloudEventsBatchSize,
+				DeadLetterSize:   DefaultCloudEventsDeadLetterSize,
+				DeadLetterMaxAge: DefaultCloudEventsDeadLetterMaxAge,
+			},
+			functionCodeSources: FunctionCodeSources{
+				Schemes: []string{DefaultFunctionCodeScheme},
+			},
 		}
 	})
 	return instance
@@ -97,3 +196,63 @@ func (o *ControllerConfig) SetRestApiEnabled(value bool) {
 func (o *ControllerConfig) GetRestApiEnabled() bool {
 	return o.restApiEnabled
 }
+
+func (o *ControllerConfig) SetTaskDefinitionLibraries(libraries map[string][]string) {
+	o.taskDefinitionLibraries = libraries
+}
+
+// GetTaskDefinitionLibraries returns the namespaces containing shared KeptnTaskDefinitions,
+// mapped to the namespaces that are allowed to use them
+func (o *ControllerConfig) GetTaskDefinitionLibraries() map[string][]string {
+	return o.taskDefinitionLibraries
+}
+
+func (o *ControllerConfig) SetMaxParallelTasks(value int) {
+	o.maxParallelTasks = value
+}
+
+func (o *ControllerConfig) GetMaxParallelTasks() int {
+	return o.maxParallelTasks
+}
+
+func (o *ControllerConfig) SetMaxParallelTasksPerNamespace(value int) {
+	o.maxParallelTasksPerNamespace = value
+}
+
+func (o *ControllerConfig) GetMaxParallelTasksPerNamespace() int {
+	return o.maxParallelTasksPerNamespace
+}
+
+func (o *ControllerConfig) SetCloudEventsDelivery(delivery CloudEventsDelivery) {
+	o.cloudEventsDelivery = delivery
+}
+
+func (o *ControllerConfig) GetCloudEventsDelivery() CloudEventsDelivery {
+	return o.cloudEventsDelivery
+}
+
+func (o *ControllerConfig) SetCloudEventsEndpoints(endpoints []CloudEventsEndpoint) {
+	o.cloudEventsEndpoints = endpoints
+}
+
+// GetCloudEventsEndpoints returns all endpoints receiving CloudEvents,
+// including the CloudEvents endpoint receiving all events
+func (o *ControllerConfig) GetCloudEventsEndpoints() []CloudEventsEndpoint {
+	if o.cloudEventsEndpoint == "" {
+		return o.cloudEventsEndpoints
+	}
+	endpoints := make([]CloudEventsEndpoint, 0, len(o.cloudEventsEndpoints)+1)
+	endpoints = append(endpoints, CloudEventsEndpoint{
+		URL:           o.cloudEventsEndpoint,
+		HeadersSecret: o.cloudEventsDelivery.HeadersSecret,
+	})
+	return append(endpoints, o.cloudEventsEndpoints...)
+}
+
+func (o *ControllerConfig) SetFunctionCodeSources(sources FunctionCodeSources) {
+	o.functionCodeSources = sources
+}
+
+func (o *ControllerConfig) GetFunctionCodeSources() FunctionCodeSources {
+	return o.functionCodeSources
+}
@@ -81,3 +81,68 @@ func TestConfig_SetAndGetObservabilityTimeout(t *testing.T) {
 		Duration: time.Duration(10 * time.Minute),
 	}, i.GetObservabilityTimeout())
 }
+
+func TestConfig_SetAndGetTaskDefinitionLibraries(t *testing.T) {
+	i := Instance()
+
+	require.Empty(t, i.GetTaskDefinitionLibraries())
+
+	libraries := map[string][]string{
+		"platform-tasks": {"app-a"},
+	}
+	i.SetTaskDefinitionLibraries(libraries)
+
+	require.Equal(t, libraries, i.GetTaskDefinitionLibraries())
+}
+
+func TestConfig_SetAndGetMaxParallelTasks(t *testing.T) {
+	i := Instance()
+
+	require.Zero(t, i.GetMaxParallelTasks())
+	require.Zero(t, i.GetMaxParallelTasksPerNamespace())
+
+	i.SetMaxParallelTasks(20)
+	i.SetMaxParallelTasksPerNamespace(5)
+
+	require.Equal(t, 20, i.GetMaxParallelTasks())
+	require.Equal(t, 5, i.GetMaxParallelTasksPerNamespace())
+}
+
+func TestConfig_SetAndGetCloudEventsEndpoints(t *testing.T) {
+	i := Instance()
+	i.SetCloudEventsEndpoint("")
+	defer i.SetCloudEventsEndpoint("")
+	defer i.SetCloudEventsEndpoints(nil)
+
+	require.Empty(t, i.GetCloudEventsEndpoints())
+
+	paging := CloudEventsEndpoint{
+		URL: "http://paging",
+		Filter: CloudEventsFilter{
+			Statuses: []string{"Failed"},
+		},
+	}
+	i.SetCloudEventsEndpoints([]CloudEventsEndpoint{paging})
+	require.Equal(t, []CloudEventsEndpoint{paging}, i.GetCloudEventsEndpoints())
+
+	// the CloudEvents endpoint receiving all events comes first
+	i.SetCloudEventsEndpoint("http://audit")
+	require.Equal(t, []CloudEventsEndpoint{{URL: "http://audit"}, paging}, i.GetCloudEventsEndpoints())
+}
+
+func TestConfig_SetAndGetFunctionCodeSources(t *testing.T) {
+	i := Instance()
+	defer i.SetFunctionCodeSources(FunctionCodeSources{Schemes: []string{DefaultFunctionCodeScheme}})
+
+	// only HTTPS URLs pointing to public addresses are allowed by default
+	require.Equal(t, FunctionCodeSources{Schemes: []string{"https"}}, i.GetFunctionCodeSources())
+
+	sources := FunctionCodeSources{
+		Schemes:               []string{"http", "https"},
+		Hosts:                 []string{"*.example.com"},
+		AllowPrivateAddresses: true,
+	}
+	i.SetFunctionCodeSources(sources)
+
+	require.Equal(t, sources, i.GetFunctionCodeSources())
+}
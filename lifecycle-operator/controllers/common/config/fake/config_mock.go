@@ -4,6 +4,7 @@
 package fake
 
 import (
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sync"
 	"time"
@@ -88,6 +89,42 @@ type MockConfig struct {
 	// SetObservabilityTimeoutFunc mocks the SetObservabilityTimeout method.
 	SetObservabilityTimeoutFunc func(timeout metav1.Duration)
 
+	// GetTaskDefinitionLibrariesFunc mocks the GetTaskDefinitionLibraries method.
+	GetTaskDefinitionLibrariesFunc func() map[string][]string
+
+	// SetTaskDefinitionLibrariesFunc mocks the SetTaskDefinitionLibraries method.
+	SetTaskDefinitionLibrariesFunc func(libraries map[string][]string)
+
+	// GetMaxParallelTasksFunc mocks the GetMaxParallelTasks method.
+	GetMaxParallelTasksFunc func() int
+
+	// GetMaxParallelTasksPerNamespaceFunc mocks the GetMaxParallelTasksPerNamespace method.
+	GetMaxParallelTasksPerNamespaceFunc func() int
+
+	// SetMaxParallelTasksFunc mocks the SetMaxParallelTasks method.
+	SetMaxParallelTasksFunc func(value int)
+
+	// SetMaxParallelTasksPerNamespaceFunc mocks the SetMaxParallelTasksPerNamespace method.
+	SetMaxParallelTasksPerNamespaceFunc func(value int)
+
+	// GetCloudEventsDeliveryFunc mocks the GetCloudEventsDelivery method.
+	GetCloudEventsDeliveryFunc func() config.CloudEventsDelivery
+
+	// SetCloudEventsDeliveryFunc mocks the SetCloudEventsDelivery method.
+	SetCloudEventsDeliveryFunc func(delivery config.CloudEventsDelivery)
+
+	// GetCloudEventsEndpointsFunc mocks the GetCloudEventsEndpoints method.
+	GetCloudEventsEndpointsFunc func() []config.CloudEventsEndpoint
+
+	// SetCloudEventsEndpointsFunc mocks the SetCloudEventsEndpoints method.
+	SetCloudEventsEndpointsFunc func(endpoints []config.CloudEventsEndpoint)
+
+	// GetFunctionCodeSourcesFunc mocks the GetFunctionCodeSources method.
+	GetFunctionCodeSourcesFunc func() config.FunctionCodeSources
+
+	// SetFunctionCodeSourcesFunc mocks the SetFunctionCodeSources method.
+	SetFunctionCodeSourcesFunc func(sources config.FunctionCodeSources)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// GetBlockDeployment holds details about calls to the GetBlockDeployment method.
@@ -130,17 +167,77 @@ type MockConfig struct {
 			// Timeout is the timeout argument value.
 			Timeout metav1.Duration
 		}
+		// GetTaskDefinitionLibraries holds details about calls to the GetTaskDefinitionLibraries method.
+		GetTaskDefinitionLibraries []struct {
+		}
+		// SetTaskDefinitionLibraries holds details about calls to the SetTaskDefinitionLibraries method.
+		SetTaskDefinitionLibraries []struct {
+			// Libraries is the libraries argument value.
+			Libraries map[string][]string
+		}
+		// GetMaxParallelTasks holds details about calls to the GetMaxParallelTasks method.
+		GetMaxParallelTasks []struct {
+		}
+		// GetMaxParallelTasksPerNamespace holds details about calls to the GetMaxParallelTasksPerNamespace method.
+		GetMaxParallelTasksPerNamespace []struct {
+		}
+		// SetMaxParallelTasks holds details about calls to the SetMaxParallelTasks method.
+		SetMaxParallelTasks []struct {
+			// Value is the value argument value.
+			Value int
+		}
+		// SetMaxParallelTasksPerNamespace holds details about calls to the SetMaxParallelTasksPerNamespace method.
+		SetMaxParallelTasksPerNamespace []struct {
+			// Value is the value argument value.
+			Value int
+		}
+		// GetCloudEventsDelivery holds details about calls to the GetCloudEventsDelivery method.
+		GetCloudEventsDelivery []struct {
+		}
+		// SetCloudEventsDelivery holds details about calls to the SetCloudEventsDelivery method.
+		SetCloudEventsDelivery []struct {
+			// Delivery is the delivery argument value.
+			Delivery config.CloudEventsDelivery
+		}
+		// GetCloudEventsEndpoints holds details about calls to the GetCloudEventsEndpoints method.
+		GetCloudEventsEndpoints []struct {
+		}
+		// SetCloudEventsEndpoints holds details about calls to the SetCloudEventsEndpoints method.
+		SetCloudEventsEndpoints []struct {
+			// Endpoints is the endpoints argument value.
+			Endpoints []config.CloudEventsEndpoint
+		}
+		// GetFunctionCodeSources holds details about calls to the GetFunctionCodeSources method.
+		GetFunctionCodeSources []struct {
+		}
+		// SetFunctionCodeSources holds details about calls to the SetFunctionCodeSources method.
+		SetFunctionCodeSources []struct {
+			// Sources is the sources argument value.
+			Sources config.FunctionCodeSources
+		}
 	}
-	lockGetBlockDeployment        sync.RWMutex
-	lockGetCloudEventsEndpoint    sync.RWMutex
-	lockGetCreationRequestTimeout sync.RWMutex
-	lockGetDefaultNamespace       sync.RWMutex
-	lockGetObservabilityTimeout   sync.RWMutex
-	lockSetBlockDeployment        sync.RWMutex
-	lockSetCloudEventsEndpoint    sync.RWMutex
-	lockSetCreationRequestTimeout sync.RWMutex
-	lockSetDefaultNamespace       sync.RWMutex
-	lockSetObservabilityTimeout   sync.RWMutex
+	lockGetBlockDeployment              sync.RWMutex
+	lockGetCloudEventsEndpoint          sync.RWMutex
+	lockGetCreationRequestTimeout       sync.RWMutex
+	lockGetDefaultNamespace             sync.RWMutex
+	lockGetObservabilityTimeout         sync.RWMutex
+	lockSetBlockDeployment              sync.RWMutex
+	lockSetCloudEventsEndpoint          sync.RWMutex
+	lockSetCreationRequestTimeout       sync.RWMutex
+	lockSetDefaultNamespace             sync.RWMutex
+	lockSetObservabilityTimeout         sync.RWMutex
+	lockGetTaskDefinitionLibraries      sync.RWMutex
+	lockSetTaskDefinitionLibraries      sync.RWMutex
+	lockGetMaxParallelTasks             sync.RWMutex
+	lockGetMaxParallelTasksPerNamespace sync.RWMutex
+	lockSetMaxParallelTasks             sync.RWMutex
+	lockSetMaxParallelTasksPerNamespace sync.RWMutex
+	lockGetCloudEventsDelivery          sync.RWMutex
+	lockSetCloudEventsDelivery          sync.RWMutex
+	lockGetCloudEventsEndpoints         sync.RWMutex
+	lockSetCloudEventsEndpoints         sync.RWMutex
+	lockGetFunctionCodeSources          sync.RWMutex
+	lockSetFunctionCodeSources          sync.RWMutex
 }
 
 // GetRestApi calls GetRestApiFunc.
@@ -447,3 +544,357 @@ func (mock *MockConfig) SetObservabilityTimeoutCalls() []struct {
 	mock.lockSetObservabilityTimeout.RUnlock()
 	return calls
 }
+
+// GetTaskDefinitionLibraries calls GetTaskDefinitionLibrariesFunc.
+func (mock *MockConfig) GetTaskDefinitionLibraries() map[string][]string {
+	if mock.GetTaskDefinitionLibrariesFunc == nil {
+		panic("MockConfig.GetTaskDefinitionLibrariesFunc: method is nil but IConfig.GetTaskDefinitionLibraries was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetTaskDefinitionLibraries.Lock()
+	mock.calls.GetTaskDefinitionLibraries = append(mock.calls.GetTaskDefinitionLibraries, callInfo)
+	mock.lockGetTaskDefinitionLibraries.Unlock()
+	return mock.GetTaskDefinitionLibrariesFunc()
+}
+
+// GetTaskDefinitionLibrariesCalls gets all the calls that were made to GetTaskDefinitionLibraries.
+// Check the length with:
+//
+//	len(mockedIConfig.GetTaskDefinitionLibrariesCalls())
+func (mock *MockConfig) GetTaskDefinitionLibrariesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetTaskDefinitionLibraries.RLock()
+	calls = mock.calls.GetTaskDefinitionLibraries
+	mock.lockGetTaskDefinitionLibraries.RUnlock()
+	return calls
+}
+
+// SetTaskDefinitionLibraries calls SetTaskDefinitionLibrariesFunc.
+func (mock *MockConfig) SetTaskDefinitionLibraries(libraries map[string][]string) {
+	if mock.SetTaskDefinitionLibrariesFunc == nil {
+		panic("MockConfig.SetTaskDefinitionLibrariesFunc: method is nil but IConfig.SetTaskDefinitionLibraries was just called")
+	}
+	callInfo := struct {
+		Libraries map[string][]string
+	}{
+		Libraries: libraries,
+	}
+	mock.lockSetTaskDefinitionLibraries.Lock()
+	mock.calls.SetTaskDefinitionLibraries = append(mock.calls.SetTaskDefinitionLibraries, callInfo)
+	mock.lockSetTaskDefinitionLibraries.Unlock()
+	mock.SetTaskDefinitionLibrariesFunc(libraries)
+}
+
+// SetTaskDefinitionLibrariesCalls gets all the calls that were made to SetTaskDefinitionLibraries.
+// Check the length with:
+//
+//	len(mockedIConfig.SetTaskDefinitionLibrariesCalls())
+func (mock *MockConfig) SetTaskDefinitionLibrariesCalls() []struct {
+	Libraries map[string][]string
+} {
+	var calls []struct {
+		Libraries map[string][]string
+	}
+	mock.lockSetTaskDefinitionLibraries.RLock()
+	calls = mock.calls.SetTaskDefinitionLibraries
+	mock.lockSetTaskDefinitionLibraries.RUnlock()
+	return calls
+}
+
+// GetMaxParallelTasks calls GetMaxParallelTasksFunc.
+func (mock *MockConfig) GetMaxParallelTasks() int {
+	if mock.GetMaxParallelTasksFunc == nil {
+		panic("MockConfig.GetMaxParallelTasksFunc: method is nil but IConfig.GetMaxParallelTasks was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetMaxParallelTasks.Lock()
+	mock.calls.GetMaxParallelTasks = append(mock.calls.GetMaxParallelTasks, callInfo)
+	mock.lockGetMaxParallelTasks.Unlock()
+	return mock.GetMaxParallelTasksFunc()
+}
+
+// GetMaxParallelTasksCalls gets all the calls that were made to GetMaxParallelTasks.
+// Check the length with:
+//
+//	len(mockedIConfig.GetMaxParallelTasksCalls())
+func (mock *MockConfig) GetMaxParallelTasksCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetMaxParallelTasks.RLock()
+	calls = mock.calls.GetMaxParallelTasks
+	mock.lockGetMaxParallelTasks.RUnlock()
+	return calls
+}
+
+// GetMaxParallelTasksPerNamespace calls GetMaxParallelTasksPerNamespaceFunc.
+func (mock *MockConfig) GetMaxParallelTasksPerNamespace() int {
+	if mock.GetMaxParallelTasksPerNamespaceFunc == nil {
+		panic("MockConfig.GetMaxParallelTasksPerNamespaceFunc: method is nil but IConfig.GetMaxParallelTasksPerNamespace was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetMaxParallelTasksPerNamespace.Lock()
+	mock.calls.GetMaxParallelTasksPerNamespace = append(mock.calls.GetMaxParallelTasksPerNamespace, callInfo)
+	mock.lockGetMaxParallelTasksPerNamespace.Unlock()
+	return mock.GetMaxParallelTasksPerNamespaceFunc()
+}
+
+// GetMaxParallelTasksPerNamespaceCalls gets all the calls that were made to GetMaxParallelTasksPerNamespace.
+// Check the length with:
+//
+//	len(mockedIConfig.GetMaxParallelTasksPerNamespaceCalls())
+func (mock *MockConfig) GetMaxParallelTasksPerNamespaceCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetMaxParallelTasksPerNamespace.RLock()
+	calls = mock.calls.GetMaxParallelTasksPerNamespace
+	mock.lockGetMaxParallelTasksPerNamespace.RUnlock()
+	return calls
+}
+
+// SetMaxParallelTasks calls SetMaxParallelTasksFunc.
+func (mock *MockConfig) SetMaxParallelTasks(value int) {
+	if mock.SetMaxParallelTasksFunc == nil {
+		panic("MockConfig.SetMaxParallelTasksFunc: method is nil but IConfig.SetMaxParallelTasks was just called")
+	}
+	callInfo := struct {
+		Value int
+	}{
+		Value: value,
+	}
+	mock.lockSetMaxParallelTasks.Lock()
+	mock.calls.SetMaxParallelTasks = append(mock.calls.SetMaxParallelTasks, callInfo)
+	mock.lockSetMaxParallelTasks.Unlock()
+	mock.SetMaxParallelTasksFunc(value)
+}
+
+// SetMaxParallelTasksCalls gets all the calls that were made to SetMaxParallelTasks.
+// Check the length with:
+//
+//	len(mockedIConfig.SetMaxParallelTasksCalls())
+func (mock *MockConfig) SetMaxParallelTasksCalls() []struct {
+	Value int
+} {
+	var calls []struct {
+		Value int
+	}
+	mock.lockSetMaxParallelTasks.RLock()
+	calls = mock.calls.SetMaxParallelTasks
+	mock.lockSetMaxParallelTasks.RUnlock()
+	return calls
+}
+
+// SetMaxParallelTasksPerNamespace calls SetMaxParallelTasksPerNamespaceFunc.
+func (mock *MockConfig) SetMaxParallelTasksPerNamespace(value int) {
+	if mock.SetMaxParallelTasksPerNamespaceFunc == nil {
+		panic("MockConfig.SetMaxParallelTasksPerNamespaceFunc: method is nil but IConfig.SetMaxParallelTasksPerNamespace was just called")
+	}
+	callInfo := struct {
+		Value int
+	}{
+		Value: value,
+	}
+	mock.lockSetMaxParallelTasksPerNamespace.Lock()
+	mock.calls.SetMaxParallelTasksPerNamespace = append(mock.calls.SetMaxParallelTasksPerNamespace, callInfo)
+	mock.lockSetMaxParallelTasksPerNamespace.Unlock()
+	mock.SetMaxParallelTasksPerNamespaceFunc(value)
+}
+
+// SetMaxParallelTasksPerNamespaceCalls gets all the calls that were made to SetMaxParallelTasksPerNamespace.
+// Check the length with:
+//
+//	len(mockedIConfig.SetMaxParallelTasksPerNamespaceCalls())
+func (mock *MockConfig) SetMaxParallelTasksPerNamespaceCalls() []struct {
+	Value int
+} {
+	var calls []struct {
+		Value int
+	}
+	mock.lockSetMaxParallelTasksPerNamespace.RLock()
+	calls = mock.calls.SetMaxParallelTasksPerNamespace
+	mock.lockSetMaxParallelTasksPerNamespace.RUnlock()
+	return calls
+}
+
+// GetCloudEventsDelivery calls GetCloudEventsDeliveryFunc.
+func (mock *MockConfig) GetCloudEventsDelivery() config.CloudEventsDelivery {
+	if mock.GetCloudEventsDeliveryFunc == nil {
+		panic("MockConfig.GetCloudEventsDeliveryFunc: method is nil but IConfig.GetCloudEventsDelivery was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCloudEventsDelivery.Lock()
+	mock.calls.GetCloudEventsDelivery = append(mock.calls.GetCloudEventsDelivery, callInfo)
+	mock.lockGetCloudEventsDelivery.Unlock()
+	return mock.GetCloudEventsDeliveryFunc()
+}
+
+// GetCloudEventsDeliveryCalls gets all the calls that were made to GetCloudEventsDelivery.
+// Check the length with:
+//
+//	len(mockedIConfig.GetCloudEventsDeliveryCalls())
+func (mock *MockConfig) GetCloudEventsDeliveryCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetCloudEventsDelivery.RLock()
+	calls = mock.calls.GetCloudEventsDelivery
+	mock.lockGetCloudEventsDelivery.RUnlock()
+	return calls
+}
+
+// SetCloudEventsDelivery calls SetCloudEventsDeliveryFunc.
+func (mock *MockConfig) SetCloudEventsDelivery(delivery config.CloudEventsDelivery) {
+	if mock.SetCloudEventsDeliveryFunc == nil {
+		panic("MockConfig.SetCloudEventsDeliveryFunc: method is nil but IConfig.SetCloudEventsDelivery was just called")
+	}
+	callInfo := struct {
+		Delivery config.CloudEventsDelivery
+	}{
+		Delivery: delivery,
+	}
+	mock.lockSetCloudEventsDelivery.Lock()
+	mock.calls.SetCloudEventsDelivery = append(mock.calls.SetCloudEventsDelivery, callInfo)
+	mock.lockSetCloudEventsDelivery.Unlock()
+	mock.SetCloudEventsDeliveryFunc(delivery)
+}
+
+// SetCloudEventsDeliveryCalls gets all the calls that were made to SetCloudEventsDelivery.
+// Check the length with:
+//
+//	len(mockedIConfig.SetCloudEventsDeliveryCalls())
+func (mock *MockConfig) SetCloudEventsDeliveryCalls() []struct {
+	Delivery config.CloudEventsDelivery
+} {
+	var calls []struct {
+		Delivery config.CloudEventsDelivery
+	}
+	mock.lockSetCloudEventsDelivery.RLock()
+	calls = mock.calls.SetCloudEventsDelivery
+	mock.lockSetCloudEventsDelivery.RUnlock()
+	return calls
+}
+
+// GetCloudEventsEndpoints calls GetCloudEventsEndpointsFunc.
+func (mock *MockConfig) GetCloudEventsEndpoints() []config.CloudEventsEndpoint {
+	if mock.GetCloudEventsEndpointsFunc == nil {
+		panic("MockConfig.GetCloudEventsEndpointsFunc: method is nil but IConfig.GetCloudEventsEndpoints was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCloudEventsEndpoints.Lock()
+	mock.calls.GetCloudEventsEndpoints = append(mock.calls.GetCloudEventsEndpoints, callInfo)
+	mock.lockGetCloudEventsEndpoints.Unlock()
+	return mock.GetCloudEventsEndpointsFunc()
+}
+
+// GetCloudEventsEndpointsCalls gets all the calls that were made to GetCloudEventsEndpoints.
+// Check the length with:
+//
+//	len(mockedIConfig.GetCloudEventsEndpointsCalls())
+func (mock *MockConfig) GetCloudEventsEndpointsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetCloudEventsEndpoints.RLock()
+	calls = mock.calls.GetCloudEventsEndpoints
+	mock.lockGetCloudEventsEndpoints.RUnlock()
+	return calls
+}
+
+// SetCloudEventsEndpoints calls SetCloudEventsEndpointsFunc.
+func (mock *MockConfig) SetCloudEventsEndpoints(endpoints []config.CloudEventsEndpoint) {
+	if mock.SetCloudEventsEndpointsFunc == nil {
+		panic("MockConfig.SetCloudEventsEndpointsFunc: method is nil but IConfig.SetCloudEventsEndpoints was just called")
+	}
+	callInfo := struct {
+		Endpoints []config.CloudEventsEndpoint
+	}{
+		Endpoints: endpoints,
+	}
+	mock.lockSetCloudEventsEndpoints.Lock()
+	mock.calls.SetCloudEventsEndpoints = append(mock.calls.SetCloudEventsEndpoints, callInfo)
+	mock.lockSetCloudEventsEndpoints.Unlock()
+	mock.SetCloudEventsEndpointsFunc(endpoints)
+}
+
+// SetCloudEventsEndpointsCalls gets all the calls that were made to SetCloudEventsEndpoints.
+// Check the length with:
+//
+//	len(mockedIConfig.SetCloudEventsEndpointsCalls())
+func (mock *MockConfig) SetCloudEventsEndpointsCalls() []struct {
+	Endpoints []config.CloudEventsEndpoint
+} {
+	var calls []struct {
+		Endpoints []config.CloudEventsEndpoint
+	}
+	mock.lockSetCloudEventsEndpoints.RLock()
+	calls = mock.calls.SetCloudEventsEndpoints
+	mock.lockSetCloudEventsEndpoints.RUnlock()
+	return calls
+}
+
+// GetFunctionCodeSources calls GetFunctionCodeSourcesFunc.
+func (mock *MockConfig) GetFunctionCodeSources() config.FunctionCodeSources {
+	if mock.GetFunctionCodeSourcesFunc == nil {
+		panic("MockConfig.GetFunctionCodeSourcesFunc: method is nil but IConfig.GetFunctionCodeSources was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetFunctionCodeSources.Lock()
+	mock.calls.GetFunctionCodeSources = append(mock.calls.GetFunctionCodeSources, callInfo)
+	mock.lockGetFunctionCodeSources.Unlock()
+	return mock.GetFunctionCodeSourcesFunc()
+}
+
+// GetFunctionCodeSourcesCalls gets all the calls that were made to GetFunctionCodeSources.
+// Check the length with:
+//
+//	len(mockedIConfig.GetFunctionCodeSourcesCalls())
+func (mock *MockConfig) GetFunctionCodeSourcesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetFunctionCodeSources.RLock()
+	calls = mock.calls.GetFunctionCodeSources
+	mock.lockGetFunctionCodeSources.RUnlock()
+	return calls
+}
+
+// SetFunctionCodeSources calls SetFunctionCodeSourcesFunc.
+func (mock *MockConfig) SetFunctionCodeSources(sources config.FunctionCodeSources) {
+	if mock.SetFunctionCodeSourcesFunc == nil {
+		panic("MockConfig.SetFunctionCodeSourcesFunc: method is nil but IConfig.SetFunctionCodeSources was just called")
+	}
+	callInfo := struct {
+		Sources config.FunctionCodeSources
+	}{
+		Sources: sources,
+	}
+	mock.lockSetFunctionCodeSources.Lock()
+	mock.calls.SetFunctionCodeSources = append(mock.calls.SetFunctionCodeSources, callInfo)
+	mock.lockSetFunctionCodeSources.Unlock()
+	mock.SetFunctionCodeSourcesFunc(sources)
+}
+
+// SetFunctionCodeSourcesCalls gets all the calls that were made to SetFunctionCodeSources.
+// Check the length with:
+//
+//	len(mockedIConfig.SetFunctionCodeSourcesCalls())
+func (mock *MockConfig) SetFunctionCodeSourcesCalls() []struct {
+	Sources config.FunctionCodeSources
+} {
+	var calls []struct {
+		Sources config.FunctionCodeSources
+	}
+	mock.lockSetFunctionCodeSources.RLock()
+	calls = mock.calls.SetFunctionCodeSources
+	mock.lockSetFunctionCodeSources.RUnlock()
+	return calls
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/go-logr/logr"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/common/expression"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/telemetry"
@@ -62,12 +63,14 @@ func (r Handler) ReconcileEvaluations(ctx context.Context, phaseCtx context.Cont
 	}
 
 	evaluations, statuses := r.setupEvaluations(evaluationCreateAttributes, piWrapper)
+	variables := common.GetExpressionVariables(phaseCtx, piWrapper, evaluationCreateAttributes.CheckType)
 
 	var summary apicommon.StatusSummary
 	summary.Total = len(evaluations)
 	// Check current state of the PrePostEvaluationTasks
 	var newStatus []apilifecycle.ItemStatus
-	for _, evaluationName := range evaluations {
+	for _, evaluationRef := range evaluations {
+		evaluationName := evaluationRef.Name
 		oldstatus := common.GetOldStatus(evaluationName, statuses)
 
 		evaluationStatus := common.GetItemStatus(evaluationName, statuses)
@@ -95,6 +98,27 @@ func (r Handler) ReconcileEvaluations(ctx context.Context, phaseCtx context.Cont
 			evaluationExists = true
 		}
 
+		// Evaluate the condition of the evaluation before creating it
+		if !evaluationExists && evaluationRef.When != "" {
+			conditionMet, err := expression.Evaluate(evaluationRef.When, variables)
+			if err != nil {
+				r.Log.Error(err, "Could not evaluate condition of evaluation",
+					"evaluationDefinition", evaluationName,
+					"namespace", piWrapper.GetNamespace(),
+				)
+				evaluationStatus.Status = apicommon.StateFailed
+				r.EventSender.Emit(apicommon.PhaseReconcileEvaluation, "Warning", reconcileObject, apicommon.PhaseStateFailed, fmt.Sprintf("could not evaluate condition of evaluation %s: %s", evaluationName, err.Error()), piWrapper.GetVersion())
+				newStatus = append(newStatus, evaluationStatus)
+				continue
+			}
+			if !conditionMet {
+				evaluationStatus.Status = apicommon.StateSkipped
+				r.EventSender.Emit(apicommon.PhaseReconcileEvaluation, "Normal", reconcileObject, apicommon.PhaseStateStatusChanged, fmt.Sprintf("evaluation %s skipped as its condition is not met", evaluationName), piWrapper.GetVersion())
+				newStatus = append(newStatus, evaluationStatus)
+				continue
+			}
+		}
+
 		// Create new Evaluation if it does not exist
 		if !evaluationExists {
 			err := r.handleEvaluationNotExists(
@@ -179,19 +203,16 @@ func (r Handler) emitEvaluationFailureEvents(evaluation *apilifecycle.KeptnEvalu
 	r.EventSender.Emit(apicommon.PhaseReconcileEvaluation, "Warning", evaluation, apicommon.PhaseStateFailed, k8sEventMessage, piWrapper.GetVersion())
 }
 
-func (r Handler) setupEvaluations(evaluationCreateAttributes CreateEvaluationAttributes, piWrapper *interfaces.PhaseItemWrapper) ([]string, []apilifecycle.ItemStatus) {
-	var evaluations []string
+func (r Handler) setupEvaluations(evaluationCreateAttributes CreateEvaluationAttributes, piWrapper *interfaces.PhaseItemWrapper) ([]apilifecycle.EvaluationReference, []apilifecycle.ItemStatus) {
 	var statuses []apilifecycle.ItemStatus
 
 	switch evaluationCreateAttributes.CheckType {
 	case apicommon.PreDeploymentEvaluationCheckType:
-		evaluations = piWrapper.GetPreDeploymentEvaluations()
 		statuses = piWrapper.GetPreDeploymentEvaluationTaskStatus()
 	case apicommon.PostDeploymentEvaluationCheckType:
-		evaluations = piWrapper.GetPostDeploymentEvaluations()
 		statuses = piWrapper.GetPostDeploymentEvaluationTaskStatus()
 	}
-	return evaluations, statuses
+	return piWrapper.GetEvaluationReferences(evaluationCreateAttributes.CheckType), statuses
 }
 
 func (r Handler) handleEvaluationNotExists(ctx context.Context, phaseCtx context.Context, evaluationCreateAttributes CreateEvaluationAttributes, evaluationName string, piWrapper *interfaces.PhaseItemWrapper, reconcileObject client.Object, evaluation *apilifecycle.KeptnEvaluation, evaluationStatus *apilifecycle.ItemStatus) error {
@@ -225,6 +246,10 @@ func (r Handler) handleEvaluationExists(phaseCtx context.Context, piWrapper *int
 		if evaluationStatus.Status.IsSucceeded() {
 			spanEvaluationTrace.AddEvent(evaluation.Name + " has finished")
 			spanEvaluationTrace.SetStatus(codes.Ok, "Finished")
+		} else if evaluationStatus.Status.IsWarning() {
+			spanEvaluationTrace.AddEvent(fmt.Sprintf("%s has finished with a warning, score: %s", evaluation.Name, evaluation.Status.Score))
+			r.emitEvaluationFailureEvents(evaluation, spanEvaluationTrace, piWrapper)
+			spanEvaluationTrace.SetStatus(codes.Ok, "Warning")
 		} else {
 			spanEvaluationTrace.AddEvent(evaluation.Name + " has failed")
 			r.emitEvaluationFailureEvents(evaluation, spanEvaluationTrace, piWrapper)
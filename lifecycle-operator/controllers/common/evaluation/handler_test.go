@@ -387,6 +387,120 @@ func TestEvaluationHandler(t *testing.T) {
 			getSpanCalls:    1,
 			unbindSpanCalls: 1,
 		},
+		{
+			name: "evaluation finished with warning",
+			object: &apilifecycle.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: apilifecycle.KeptnAppVersionSpec{
+					KeptnAppContextSpec: apilifecycle.KeptnAppContextSpec{
+						DeploymentTaskSpec: apilifecycle.DeploymentTaskSpec{
+							PreDeploymentEvaluations: []string{"eval-def"},
+						},
+					},
+				},
+				Status: apilifecycle.KeptnAppVersionStatus{
+					PreDeploymentEvaluationStatus: apicommon.StateProgressing,
+					PreDeploymentEvaluationTaskStatus: []apilifecycle.ItemStatus{
+						{
+							DefinitionName: "eval-def",
+							Status:         apicommon.StateProgressing,
+							Name:           "pre-eval-eval-def-",
+						},
+					},
+				},
+			},
+			evalObj: apilifecycle.KeptnEvaluation{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "pre-eval-eval-def-",
+				},
+				Status: apilifecycle.KeptnEvaluationStatus{
+					OverallStatus: apicommon.StateWarning,
+					Score:         "75.00",
+					EvaluationStatus: map[string]apilifecycle.EvaluationStatusItem{
+						"my-target": {
+							Value:   "1",
+							Status:  apicommon.StateFailed,
+							Message: "failed",
+						},
+					},
+				},
+			},
+			createAttr: CreateEvaluationAttributes{
+				SpanName: "",
+				Definition: apilifecycle.KeptnEvaluationDefinition{
+					ObjectMeta: v1.ObjectMeta{
+						Name: "eval-def",
+					},
+				},
+				CheckType: apicommon.PreDeploymentEvaluationCheckType,
+			},
+			wantStatus: []apilifecycle.ItemStatus{
+				{
+					DefinitionName: "eval-def",
+					Status:         apicommon.StateWarning,
+					Name:           "pre-eval-eval-def-",
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 1, Warning: 1},
+			wantErr:         nil,
+			getSpanCalls:    1,
+			unbindSpanCalls: 1,
+			events: []string{
+				"evaluation of 'my-target' failed with value: '1' and reason: 'failed'",
+			},
+		},
+		{
+			name: "evaluations are created or skipped depending on their condition",
+			object: &apilifecycle.KeptnWorkloadVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: apilifecycle.KeptnWorkloadVersionSpec{
+					KeptnWorkloadSpec: apilifecycle.KeptnWorkloadSpec{
+						Version: "1.1.0",
+						PostDeploymentEvaluationRefs: []apilifecycle.EvaluationReference{
+							{Name: "eval-def", When: "objectType == 'Workload' && minorVersion(version) > 0"},
+							{Name: "other-eval-def", When: "majorVersion(version) > majorVersion(previousVersion)"},
+						},
+					},
+					PreviousVersion: "1.0.0",
+				},
+			},
+			evalDef: &apilifecycle.KeptnEvaluationDefinition{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "eval-def",
+				},
+			},
+			evalObj: apilifecycle.KeptnEvaluation{},
+			createAttr: CreateEvaluationAttributes{
+				CheckType: apicommon.PostDeploymentEvaluationCheckType,
+			},
+			wantStatus: []apilifecycle.ItemStatus{
+				{
+					DefinitionName: "eval-def",
+					Status:         apicommon.StatePending,
+					Name:           "post-eval-eval-def-",
+				},
+				{
+					DefinitionName: "other-eval-def",
+					Status:         apicommon.StateSkipped,
+					Name:           "",
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 2, Pending: 1, Skipped: 1},
+			wantErr:         nil,
+			getSpanCalls:    1,
+			unbindSpanCalls: 0,
+			events: []string{
+				"evaluation status changed from  to Pending",
+				"evaluation status changed from  to Pending",
+				"evaluation other-eval-def skipped as its condition is not met",
+			},
+		},
 	}
 
 	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
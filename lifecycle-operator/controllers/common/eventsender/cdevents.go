@@ -0,0 +1,314 @@
+package eventsender
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	"github.com/go-logr/logr"
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CDEventsSpecVersion is the version of the CDEvents specification the emitted events comply with
+const CDEventsSpecVersion = "0.4.1"
+
+const (
+	CDEventServiceDeployed      = "dev.cdevents.service.deployed.0.2.0"
+	CDEventPipelineRunStarted   = "dev.cdevents.pipelinerun.started.0.2.0"
+	CDEventPipelineRunFinished  = "dev.cdevents.pipelinerun.finished.0.2.0"
+	CDEventTaskRunStarted       = "dev.cdevents.taskrun.started.0.2.0"
+	CDEventTaskRunFinished      = "dev.cdevents.taskrun.finished.0.2.0"
+	CDEventTestCaseRunStarted   = "dev.cdevents.testcaserun.started.0.1.0"
+	CDEventTestCaseRunFinished  = "dev.cdevents.testcaserun.finished.0.1.0"
+	cdEventSource               = "keptn.sh"
+	cdEventTraceParentExtension = "traceparent"
+	cdEventTraceStateExtension  = "tracestate"
+)
+
+// CDEvent is the payload of a Cloud Event compliant with the CDEvents specification
+type CDEvent struct {
+	Context               CDEventContext         `json:"context"`
+	Subject               CDEventSubject         `json:"subject"`
+	CustomData            map[string]interface{} `json:"customData,omitempty"`
+	CustomDataContentType string                 `json:"customDataContentType,omitempty"`
+}
+
+// CDEventContext contains the metadata of a CDEvent
+type CDEventContext struct {
+	Version   string    `json:"version"`
+	ID        string    `json:"id"`
+	Source    string    `json:"source"`
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// CDEventSubject is the entity a CDEvent is about, e.g. the deployed service
+type CDEventSubject struct {
+	ID      string                 `json:"id"`
+	Source  string                 `json:"source"`
+	Type    string                 `json:"type"`
+	Content map[string]interface{} `json:"content"`
+}
+
+// ===== CDEvent Sender =====
+
+type cdEvent struct {
+	dispatcher *CloudEventDispatcher
+	logger     logr.Logger
+}
+
+func newCDEventSender(logger logr.Logger, dispatcher *CloudEventDispatcher) *cdEvent {
+	return &cdEvent{
+		dispatcher: dispatcher,
+		logger:     logger,
+	}
+}
+
+// Emit creates a CDEvent for the phases that map to a CDEvent and queues it for delivery
+// to all endpoints of the cdevents format whose filter matches the event
+func (e *cdEvent) Emit(phase apicommon.KeptnPhaseType, eventType string, reconcileObject client.Object, status string, message string, version string) {
+	if e.dispatcher == nil {
+		return
+	}
+	endpoints := getEndpoints(e.logger, config.CloudEventsFormatCDEvents, phase, eventType, reconcileObject, status)
+	if len(endpoints) == 0 {
+		return
+	}
+
+	cdEventType, subject, ok := newCDEventSubject(phase, eventType, reconcileObject, status, message, version)
+	if !ok {
+		// not every Keptn phase has a counterpart in the CDEvents specification
+		return
+	}
+
+	id := string(uuid.NewUUID())
+	now := time.Now()
+	event := ce.NewEvent()
+	event.SetID(id)
+	event.SetTime(now)
+	event.SetSource(cdEventSource)
+	event.SetType(cdEventType)
+	event.SetSubject(subject.ID)
+	for key, value := range getTraceContext(phase, reconcileObject) {
+		if value != "" && (key == cdEventTraceParentExtension || key == cdEventTraceStateExtension) {
+			event.SetExtension(key, value)
+		}
+	}
+
+	err := event.SetData(ce.ApplicationJSON, CDEvent{
+		Context: CDEventContext{
+			Version:   CDEventsSpecVersion,
+			ID:        id,
+			Source:    cdEventSource,
+			Type:      cdEventType,
+			Timestamp: now,
+		},
+		Subject: subject,
+		CustomData: map[string]interface{}{
+			"phase":     phase.ShortName,
+			"status":    status,
+			"type":      eventType,
+			"message":   setEventMessage(phase, reconcileObject, message, version),
+			"name":      reconcileObject.GetName(),
+			"namespace": reconcileObject.GetNamespace(),
+		},
+		CustomDataContentType: ce.ApplicationJSON,
+	})
+	if err != nil {
+		e.logger.V(5).Info(fmt.Sprintf("Failed to set data for CDEvent: %v", err))
+		return
+	}
+
+	for _, endpoint := range endpoints {
+		e.dispatcher.Enqueue(endpoint, event.Clone())
+	}
+}
+
+// newCDEventSubject maps the given Keptn phase to the type and subject of a CDEvent.
+// Deployments of workloads are mapped to service events, task phases to pipeline run events,
+// the KeptnTasks executed in them to task run events and evaluation phases to test case run events.
+func newCDEventSubject(phase apicommon.KeptnPhaseType, eventType string, reconcileObject client.Object, status string, message string, version string) (string, CDEventSubject, bool) {
+	app, workload := getAppAndWorkload(reconcileObject)
+	namespace := reconcileObject.GetNamespace()
+	runID := joinSubjectID(namespace, app, workload, version)
+	environment := map[string]interface{}{
+		"id":     namespace,
+		"source": cdEventSource,
+	}
+
+	switch {
+	case phase == apicommon.PhaseWorkloadDeployment:
+		// a deployment that reached its timeout is reported as finished with a warning
+		if workload == "" || status != apicommon.PhaseStateFinished || eventType != "Normal" {
+			return "", CDEventSubject{}, false
+		}
+		return CDEventServiceDeployed, CDEventSubject{
+			ID:     joinSubjectID(namespace, app, workload),
+			Source: cdEventSource,
+			Type:   "service",
+			Content: map[string]interface{}{
+				"environment": environment,
+				"artifactId":  fmt.Sprintf("pkg:generic/%s@%s", workload, version),
+			},
+		}, true
+	case phase.IsTask() || phase.IsPromotionTask():
+		content := map[string]interface{}{
+			"pipelineName": phase.ShortName,
+		}
+		cdEventType := CDEventPipelineRunFinished
+		switch status {
+		case apicommon.PhaseStateStarted:
+			cdEventType = CDEventPipelineRunStarted
+		case apicommon.PhaseStateFinished:
+			content["outcome"] = "success"
+		case apicommon.PhaseStateFailed:
+			content["outcome"] = "failure"
+			content["errors"] = message
+		default:
+			return "", CDEventSubject{}, false
+		}
+		return cdEventType, CDEventSubject{
+			ID:      joinSubjectID(runID, phase.ShortName),
+			Source:  cdEventSource,
+			Type:    "pipelineRun",
+			Content: content,
+		}, true
+	case phase == apicommon.PhaseReconcileTask:
+		task, ok := reconcileObject.(*apilifecycle.KeptnTask)
+		if !ok {
+			return "", CDEventSubject{}, false
+		}
+		content := map[string]interface{}{
+			"taskName": task.Spec.TaskDefinition,
+			"pipelineRun": map[string]interface{}{
+				"id":     getTaskPipelineRunID(task),
+				"source": cdEventSource,
+			},
+		}
+		cdEventType := CDEventTaskRunFinished
+		switch status {
+		case apicommon.PhaseStateStarted:
+			cdEventType = CDEventTaskRunStarted
+		case apicommon.PhaseStateFinished:
+			content["outcome"] = "success"
+		case apicommon.PhaseStateFailed:
+			content["outcome"] = "failure"
+			content["errors"] = message
+		default:
+			return "", CDEventSubject{}, false
+		}
+		return cdEventType, CDEventSubject{
+			ID:      TaskRunSubjectID(task.Namespace, task.Name),
+			Source:  cdEventSource,
+			Type:    "taskRun",
+			Content: content,
+		}, true
+	case phase.IsEvaluation():
+		content := map[string]interface{}{
+			"environment": environment,
+			"testCase": map[string]interface{}{
+				"id":   phase.ShortName,
+				"name": phase.LongName,
+			},
+		}
+		cdEventType := CDEventTestCaseRunFinished
+		switch status {
+		case apicommon.PhaseStateStarted:
+			cdEventType = CDEventTestCaseRunStarted
+		case apicommon.PhaseStateFinished:
+			content["outcome"] = "pass"
+		case apicommon.PhaseStateFailed:
+			content["outcome"] = "fail"
+			content["reason"] = message
+		default:
+			return "", CDEventSubject{}, false
+		}
+		return cdEventType, CDEventSubject{
+			ID:      joinSubjectID(runID, phase.ShortName),
+			Source:  cdEventSource,
+			Type:    "testCaseRun",
+			Content: content,
+		}, true
+	}
+	return "", CDEventSubject{}, false
+}
+
+// TaskRunSubjectID returns the ID of the subject of the task run CDEvents of a KeptnTask, which is <namespace>/<task>
+func TaskRunSubjectID(namespace string, task string) string {
+	return joinSubjectID(namespace, task)
+}
+
+// ParseTaskRunSubjectID returns the namespace and name of the KeptnTask identified by the given task run subject ID
+func ParseTaskRunSubjectID(id string) (string, string, bool) {
+	namespace, task, found := strings.Cut(id, "/")
+	if !found || namespace == "" || task == "" || strings.Contains(task, "/") {
+		return "", "", false
+	}
+	return namespace, task, true
+}
+
+// getTaskPipelineRunID returns the subject ID of the pipeline run of the task phase the given KeptnTask is executed in
+func getTaskPipelineRunID(task *apilifecycle.KeptnTask) string {
+	taskContext := task.Spec.Context
+	version := taskContext.AppVersion
+	if taskContext.WorkloadName != "" {
+		version = taskContext.WorkloadVersion
+	}
+
+	phase := apicommon.PhaseAppPreDeployment
+	switch {
+	case task.Spec.Type == apicommon.PromotionCheckType:
+		phase = apicommon.PhasePromotion
+	case task.Spec.Type == apicommon.PostDeploymentCheckType && taskContext.WorkloadName != "":
+		phase = apicommon.PhaseWorkloadPostDeployment
+	case task.Spec.Type == apicommon.PostDeploymentCheckType:
+		phase = apicommon.PhaseAppPostDeployment
+	case taskContext.WorkloadName != "":
+		phase = apicommon.PhaseWorkloadPreDeployment
+	}
+	return joinSubjectID(task.Namespace, taskContext.AppName, taskContext.WorkloadName, version, phase.ShortName)
+}
+
+// getAppAndWorkload returns the names of the KeptnApp and KeptnWorkload the given object belongs to
+func getAppAndWorkload(reconcileObject client.Object) (string, string) {
+	piWrapper, err := interfaces.NewEventObjectWrapperFromClientObject(reconcileObject)
+	if err != nil {
+		return reconcileObject.GetName(), ""
+	}
+	annotations := piWrapper.GetEventAnnotations()
+	return annotations["appName"], annotations["workloadName"]
+}
+
+// getTraceContext returns the trace context of the given phase, falling back to the trace context of the object
+func getTraceContext(phase apicommon.KeptnPhaseType, reconcileObject client.Object) map[string]string {
+	switch obj := reconcileObject.(type) {
+	case *apilifecycle.KeptnAppVersion:
+		if carrier := obj.Status.PhaseTraceIDs.GetPhaseTraceID(phase.ShortName); carrier.Get(cdEventTraceParentExtension) != "" {
+			return carrier
+		}
+		return obj.Spec.TraceId
+	case *apilifecycle.KeptnWorkloadVersion:
+		if carrier := obj.Status.PhaseTraceIDs.GetPhaseTraceID(phase.ShortName); carrier.Get(cdEventTraceParentExtension) != "" {
+			return carrier
+		}
+		return obj.Spec.TraceId
+	}
+	return reconcileObject.GetAnnotations()
+}
+
+// joinSubjectID builds the ID of a CDEvent subject from the given non-empty parts
+func joinSubjectID(parts ...string) string {
+	nonEmpty := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, "/")
+}
@@ -0,0 +1,287 @@
+package eventsender
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCDEventSender_Emit(t *testing.T) {
+	keptnEndpoint := config.CloudEventsEndpoint{URL: "http://keptn"}
+	cdEventsEndpoint := config.CloudEventsEndpoint{URL: "http://cdevents", Format: config.CloudEventsFormatCDEvents}
+	config.Instance().SetCloudEventsEndpoints([]config.CloudEventsEndpoint{keptnEndpoint, cdEventsEndpoint})
+	defer config.Instance().SetCloudEventsEndpoints(nil)
+
+	dispatcher := NewCloudEventDispatcher(ctrl.Log.WithName("testytest"), nil, nil, apicommon.CloudEventMeters{})
+	cdSender := newCDEventSender(ctrl.Log.WithName("testytest"), dispatcher)
+	ceSender := newCloudEventSender(ctrl.Log.WithName("testytest"), dispatcher)
+
+	workloadVersion := makeTestWorkloadVersion()
+	cdSender.Emit(apicommon.PhaseWorkloadDeployment, "Normal", workloadVersion, apicommon.PhaseStateFinished, "has finished", "1.0.0")
+	ceSender.Emit(apicommon.PhaseWorkloadDeployment, "Normal", workloadVersion, apicommon.PhaseStateFinished, "has finished", "1.0.0")
+
+	// each endpoint only receives the events of its format
+	require.Len(t, dispatcher.queues, 2)
+	require.Len(t, dispatcher.queues[newEndpointKey(keptnEndpoint)].queue, 1)
+	require.Equal(t, "Workload Deployment.Finished", dispatcher.queues[newEndpointKey(keptnEndpoint)].queue[0].Type())
+	require.Len(t, dispatcher.queues[newEndpointKey(cdEventsEndpoint)].queue, 1)
+
+	event := dispatcher.queues[newEndpointKey(cdEventsEndpoint)].queue[0]
+	require.Equal(t, CDEventServiceDeployed, event.Type())
+	require.Equal(t, "my-namespace/my-app/my-app-my-workload", event.Subject())
+	require.Equal(t, "00-trace-deploy-01", event.Extensions()["traceparent"])
+
+	payload := CDEvent{}
+	require.Nil(t, json.Unmarshal(event.Data(), &payload))
+	require.Equal(t, CDEventsSpecVersion, payload.Context.Version)
+	require.Equal(t, event.ID(), payload.Context.ID)
+	require.Equal(t, CDEventServiceDeployed, payload.Context.Type)
+	require.Equal(t, "my-namespace/my-app/my-app-my-workload", payload.Subject.ID)
+	require.Equal(t, "service", payload.Subject.Type)
+	require.Equal(t, "pkg:generic/my-app-my-workload@1.0.0", payload.Subject.Content["artifactId"])
+	require.Equal(t, map[string]interface{}{"id": "my-namespace", "source": "keptn.sh"}, payload.Subject.Content["environment"])
+}
+
+func TestCDEventSender_NoMapping(t *testing.T) {
+	config.Instance().SetCloudEventsEndpoints([]config.CloudEventsEndpoint{
+		{URL: "http://cdevents", Format: config.CloudEventsFormatCDEvents},
+	})
+	defer config.Instance().SetCloudEventsEndpoints(nil)
+
+	dispatcher := NewCloudEventDispatcher(ctrl.Log.WithName("testytest"), nil, nil, apicommon.CloudEventMeters{})
+	cdSender := newCDEventSender(ctrl.Log.WithName("testytest"), dispatcher)
+
+	cdSender.Emit(apicommon.PhaseCreateWorkloadVersion, "Warning", makeTestWorkloadVersion(), apicommon.PhaseStateFailed, "could not create", "1.0.0")
+
+	require.Empty(t, dispatcher.queues)
+}
+
+func TestCDEventSender_Delivery(t *testing.T) {
+	received := make(chan http.Header, 1)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	dispatcher, endpoint, _ := newTestDispatcher(svr.URL, config.CloudEventsDelivery{}, nil)
+	endpoint.Format = config.CloudEventsFormatCDEvents
+	config.Instance().SetCloudEventsEndpoints([]config.CloudEventsEndpoint{endpoint})
+	defer config.Instance().SetCloudEventsEndpoints(nil)
+
+	cdSender := newCDEventSender(ctrl.Log.WithName("testytest"), dispatcher)
+	cdSender.Emit(apicommon.PhaseWorkloadPreDeployment, "Normal", makeTestWorkloadVersion(), apicommon.PhaseStateStarted, "has started", "1.0.0")
+	dispatcher.deliverQueued(context.TODO(), dispatcher.getQueue(endpoint))
+
+	select {
+	case header := <-received:
+		require.Equal(t, CDEventPipelineRunStarted, header.Get("Ce-Type"))
+		require.Equal(t, "00-trace-workload-01", header.Get("Ce-Traceparent"))
+		require.Equal(t, "my-namespace/my-app/my-app-my-workload/1.0.0/WorkloadPreDeployTasks", header.Get("Ce-Subject"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("didn't receive the CDEvent")
+	}
+}
+
+func TestNewCDEventSubject(t *testing.T) {
+	tests := []struct {
+		name        string
+		phase       apicommon.KeptnPhaseType
+		eventType   string
+		obj         client.Object
+		status      string
+		wantType    string
+		wantID      string
+		wantOutcome interface{}
+		wantContent map[string]interface{}
+		wantOk      bool
+	}{
+		{
+			name:      "workload deployed",
+			phase:     apicommon.PhaseWorkloadDeployment,
+			eventType: "Normal",
+			status:    apicommon.PhaseStateFinished,
+			wantType:  CDEventServiceDeployed,
+			wantID:    "my-namespace/my-app/my-app-my-workload",
+			wantOk:    true,
+		},
+		{
+			name:      "workload deployment timed out",
+			phase:     apicommon.PhaseWorkloadDeployment,
+			eventType: "Warning",
+			status:    apicommon.PhaseStateFinished,
+			wantOk:    false,
+		},
+		{
+			name:      "pre-deployment tasks started",
+			phase:     apicommon.PhaseWorkloadPreDeployment,
+			eventType: "Normal",
+			status:    apicommon.PhaseStateStarted,
+			wantType:  CDEventPipelineRunStarted,
+			wantID:    "my-namespace/my-app/my-app-my-workload/1.0.0/WorkloadPreDeployTasks",
+			wantOk:    true,
+		},
+		{
+			name:        "promotion tasks failed",
+			phase:       apicommon.PhasePromotion,
+			eventType:   "Warning",
+			status:      apicommon.PhaseStateFailed,
+			wantType:    CDEventPipelineRunFinished,
+			wantID:      "my-namespace/my-app/my-app-my-workload/1.0.0/PromotionTasks",
+			wantOutcome: "failure",
+			wantOk:      true,
+		},
+		{
+			name:      "task started",
+			phase:     apicommon.PhaseReconcileTask,
+			eventType: "Normal",
+			obj:       makeTestTask(apicommon.PreDeploymentCheckType),
+			status:    apicommon.PhaseStateStarted,
+			wantType:  CDEventTaskRunStarted,
+			wantID:    "my-namespace/my-task",
+			wantContent: map[string]interface{}{
+				"taskName": "my-definition",
+				"pipelineRun": map[string]interface{}{
+					"id":     "my-namespace/my-app/my-app-my-workload/1.0.0/WorkloadPreDeployTasks",
+					"source": "keptn.sh",
+				},
+			},
+			wantOk: true,
+		},
+		{
+			name:        "task succeeded",
+			phase:       apicommon.PhaseReconcileTask,
+			eventType:   "Normal",
+			obj:         makeTestTask(apicommon.PostDeploymentCheckType),
+			status:      apicommon.PhaseStateFinished,
+			wantType:    CDEventTaskRunFinished,
+			wantID:      "my-namespace/my-task",
+			wantOutcome: "success",
+			wantOk:      true,
+		},
+		{
+			name:      "task phase of an app version",
+			phase:     apicommon.PhaseReconcileTask,
+			eventType: "Normal",
+			status:    apicommon.PhaseStateStatusChanged,
+			wantOk:    false,
+		},
+		{
+			name:        "post-deployment evaluations finished",
+			phase:       apicommon.PhaseWorkloadPostEvaluation,
+			eventType:   "Normal",
+			status:      apicommon.PhaseStateFinished,
+			wantType:    CDEventTestCaseRunFinished,
+			wantID:      "my-namespace/my-app/my-app-my-workload/1.0.0/WorkloadPostDeployEvaluations",
+			wantOutcome: "pass",
+			wantOk:      true,
+		},
+		{
+			name:      "status change of an evaluation",
+			phase:     apicommon.PhaseWorkloadPreEvaluation,
+			eventType: "Normal",
+			status:    apicommon.PhaseStateStatusChanged,
+			wantOk:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := tt.obj
+			if obj == nil {
+				obj = makeTestWorkloadVersion()
+			}
+			cdEventType, subject, ok := newCDEventSubject(tt.phase, tt.eventType, obj, tt.status, "message", "1.0.0")
+			require.Equal(t, tt.wantOk, ok)
+			if !tt.wantOk {
+				return
+			}
+			require.Equal(t, tt.wantType, cdEventType)
+			require.Equal(t, tt.wantID, subject.ID)
+			require.Equal(t, tt.wantOutcome, subject.Content["outcome"])
+			if tt.wantContent != nil {
+				require.Equal(t, tt.wantContent, subject.Content)
+			}
+		})
+	}
+}
+
+func TestParseTaskRunSubjectID(t *testing.T) {
+	namespace, task, ok := ParseTaskRunSubjectID(TaskRunSubjectID("my-namespace", "my-task"))
+	require.True(t, ok)
+	require.Equal(t, "my-namespace", namespace)
+	require.Equal(t, "my-task", task)
+
+	for _, id := range []string{"my-task", "my-namespace/", "/my-task", "my-namespace/my-app/my-task"} {
+		_, _, ok = ParseTaskRunSubjectID(id)
+		require.False(t, ok, id)
+	}
+}
+
+func Test_getTraceContext(t *testing.T) {
+	workloadVersion := makeTestWorkloadVersion()
+
+	// the trace context of the phase is preferred
+	traceContext := getTraceContext(apicommon.PhaseWorkloadDeployment, workloadVersion)
+	require.Equal(t, "00-trace-deploy-01", traceContext["traceparent"])
+
+	// phases without a span of their own use the trace context of the workload version
+	traceContext = getTraceContext(apicommon.PhaseWorkloadPreDeployment, workloadVersion)
+	require.Equal(t, "00-trace-workload-01", traceContext["traceparent"])
+}
+
+func makeTestTask(checkType apicommon.CheckType) *apilifecycle.KeptnTask {
+	return &apilifecycle.KeptnTask{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-task",
+			Namespace: "my-namespace",
+		},
+		Spec: apilifecycle.KeptnTaskSpec{
+			Context: apilifecycle.TaskContext{
+				AppName:         "my-app",
+				WorkloadName:    "my-app-my-workload",
+				WorkloadVersion: "1.0.0",
+			},
+			TaskDefinition: "my-definition",
+			Type:           checkType,
+		},
+	}
+}
+
+func makeTestWorkloadVersion() *apilifecycle.KeptnWorkloadVersion {
+	return &apilifecycle.KeptnWorkloadVersion{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-app-my-workload-1.0.0",
+			Namespace: "my-namespace",
+		},
+		Spec: apilifecycle.KeptnWorkloadVersionSpec{
+			KeptnWorkloadSpec: apilifecycle.KeptnWorkloadSpec{
+				AppName: "my-app",
+				Version: "1.0.0",
+			},
+			WorkloadName: "my-app-my-workload",
+			TraceId: map[string]string{
+				"traceparent": "00-trace-workload-01",
+			},
+		},
+		Status: apilifecycle.KeptnWorkloadVersionStatus{
+			PhaseTraceIDs: apicommon.PhaseTraceID{
+				apicommon.PhaseWorkloadDeployment.ShortName: propagation.MapCarrier{
+					"traceparent": "00-trace-deploy-01",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,410 @@
+package eventsender
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/go-logr/logr"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	requestTimeout          = 30 * time.Second
+	maxRetryBackoff         = 5 * time.Minute
+	deadLetterRetryInterval = 1 * time.Minute
+	staleQueueInterval      = 10 * time.Second
+)
+
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+
+// CloudEventDispatcher delivers Cloud Events to the configured Cloud Events endpoints.
+// The events of each endpoint are buffered in a bounded queue and retried with an exponential backoff,
+// so that an unreachable endpoint does not delay the delivery to the other endpoints.
+// Events that could not be delivered after all retries are kept in a dead-letter buffer
+// and sent again once the endpoint is reachable again, unless they exceeded the maximum age.
+// The queues of endpoints that are removed from the configuration are stopped and their events dropped.
+type CloudEventDispatcher struct {
+	client     ce.Client
+	httpClient *http.Client
+	reader     client.Reader
+	logger     logr.Logger
+	config     config.IConfig
+	meters     apicommon.CloudEventMeters
+
+	mtx    sync.Mutex
+	ctx    context.Context
+	queues map[endpointKey]*endpointQueue
+}
+
+// endpointKey identifies the queue of an endpoint. Endpoints sharing a URL but using a different
+// format or headers Secret are delivered independently.
+type endpointKey struct {
+	url           string
+	format        string
+	headersSecret types.NamespacedName
+}
+
+func newEndpointKey(endpoint config.CloudEventsEndpoint) endpointKey {
+	return endpointKey{
+		url:           endpoint.URL,
+		format:        getFormat(endpoint),
+		headersSecret: endpoint.HeadersSecret,
+	}
+}
+
+// endpointQueue holds the Cloud Events waiting to be delivered to a single endpoint
+type endpointQueue struct {
+	mtx        sync.Mutex
+	endpoint   config.CloudEventsEndpoint
+	queue      []ce.Event
+	deadLetter []ce.Event
+	notify     chan struct{}
+	cancel     context.CancelFunc
+}
+
+func NewCloudEventDispatcher(logger logr.Logger, ceClient ce.Client, reader client.Reader, meters apicommon.CloudEventMeters) *CloudEventDispatcher {
+	return &CloudEventDispatcher{
+		client:     ceClient,
+		httpClient: &http.Client{Timeout: requestTimeout},
+		reader:     reader,
+		logger:     logger,
+		config:     config.Instance(),
+		meters:     meters,
+		queues:     map[endpointKey]*endpointQueue{},
+	}
+}
+
+// Enqueue adds the given Cloud Event to the queue of the given endpoint.
+// If the queue is full, the event is dropped.
+func (d *CloudEventDispatcher) Enqueue(endpoint config.CloudEventsEndpoint, event ce.Event) {
+	queueSize := d.config.GetCloudEventsDelivery().QueueSize
+
+	// the event is pushed while holding the lock, so that it is not added to a queue that is being removed
+	d.mtx.Lock()
+	q := d.queueFor(endpoint)
+	pushed := q.push(event, queueSize)
+	d.mtx.Unlock()
+
+	if !pushed {
+		d.logger.Info("Cloud Event queue is full, dropping event", "endpoint", endpoint.URL, "type", event.Type(), "queueSize", queueSize)
+		d.addCount(context.Background(), d.meters.DroppedCount, endpoint.URL, 1)
+		return
+	}
+
+	// wake up the delivery loop without blocking if it is already busy
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Start delivers the queued Cloud Events until the given context is cancelled
+func (d *CloudEventDispatcher) Start(ctx context.Context) error {
+	d.mtx.Lock()
+	d.ctx = ctx
+	for _, q := range d.queues {
+		d.startQueue(q)
+	}
+	d.mtx.Unlock()
+
+	ticker := time.NewTicker(staleQueueInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			d.removeStaleQueues()
+		}
+	}
+}
+
+// NeedLeaderElection implements LeaderElectionRunnable, events are emitted by all replicas
+func (d *CloudEventDispatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// getQueue returns the queue of the given endpoint, the delivery of a new queue starts right away
+func (d *CloudEventDispatcher) getQueue(endpoint config.CloudEventsEndpoint) *endpointQueue {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.queueFor(endpoint)
+}
+
+// queueFor returns the queue of the given endpoint, the caller has to hold the lock of the dispatcher
+func (d *CloudEventDispatcher) queueFor(endpoint config.CloudEventsEndpoint) *endpointQueue {
+	key := newEndpointKey(endpoint)
+	q, ok := d.queues[key]
+	if !ok {
+		q = &endpointQueue{
+			notify: make(chan struct{}, 1),
+		}
+		d.queues[key] = q
+		if d.ctx != nil {
+			d.startQueue(q)
+		}
+	}
+	// use the latest configuration of the endpoint, e.g. an updated filter
+	q.setEndpoint(endpoint)
+	return q
+}
+
+// startQueue starts the delivery of the given queue, the caller has to hold the lock of the dispatcher
+func (d *CloudEventDispatcher) startQueue(q *endpointQueue) {
+	ctx, cancel := context.WithCancel(d.ctx)
+	q.cancel = cancel
+	go d.run(ctx, q)
+}
+
+// removeStaleQueues stops the delivery to the endpoints that are no longer configured,
+// e.g. after the URL or headers Secret of an endpoint changed, and drops their queued and dead-letter events
+func (d *CloudEventDispatcher) removeStaleQueues() {
+	configured := map[endpointKey]bool{}
+	for _, endpoint := range d.config.GetCloudEventsEndpoints() {
+		configured[newEndpointKey(endpoint)] = true
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	for key, q := range d.queues {
+		if configured[key] {
+			continue
+		}
+		delete(d.queues, key)
+		if q.cancel != nil {
+			q.cancel()
+		}
+		if dropped := q.clear(); dropped > 0 {
+			d.logger.Info("Cloud Events endpoint is no longer configured, dropping its events", "endpoint", key.url, "count", dropped)
+			d.addCount(context.Background(), d.meters.DroppedCount, key.url, dropped)
+		}
+	}
+}
+
+func (d *CloudEventDispatcher) run(ctx context.Context, q *endpointQueue) {
+	ticker := time.NewTicker(deadLetterRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.requeueDeadLetters(ctx, q, d.config.GetCloudEventsDelivery())
+		case <-q.notify:
+		}
+		d.deliverQueued(ctx, q)
+	}
+}
+
+func (d *CloudEventDispatcher) deliverQueued(ctx context.Context, q *endpointQueue) {
+	for ctx.Err() == nil {
+		delivery := d.config.GetCloudEventsDelivery()
+		endpoint := q.getEndpoint()
+		events := q.dequeue(delivery.BatchSize)
+		if len(events) == 0 {
+			return
+		}
+
+		err := d.deliverWithRetries(ctx, endpoint, events, delivery)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			d.logger.Error(err, "could not deliver Cloud Events, moving them to the dead-letter buffer", "endpoint", endpoint.URL, "count", len(events))
+			d.addCount(ctx, d.meters.FailedCount, endpoint.URL, len(events))
+			if dropped := q.addDeadLetters(events, delivery.DeadLetterSize); dropped > 0 {
+				d.logger.Info("Cloud Event dead-letter buffer is full, dropping oldest events", "endpoint", endpoint.URL, "count", dropped)
+				d.addCount(ctx, d.meters.DroppedCount, endpoint.URL, dropped)
+			}
+			continue
+		}
+		d.addCount(ctx, d.meters.SentCount, endpoint.URL, len(events))
+		// the endpoint is reachable again, so the events of the dead-letter buffer can be retried
+		d.requeueDeadLetters(ctx, q, delivery)
+	}
+}
+
+// requeueDeadLetters drops the expired events of the dead-letter buffer and moves the remaining ones to the queue
+func (d *CloudEventDispatcher) requeueDeadLetters(ctx context.Context, q *endpointQueue, delivery config.CloudEventsDelivery) {
+	if expired := q.dropExpiredDeadLetters(time.Now().Add(-delivery.DeadLetterMaxAge)); expired > 0 {
+		endpoint := q.getEndpoint()
+		d.logger.Info("Cloud Events exceeded the maximum age of the dead-letter buffer, dropping them", "endpoint", endpoint.URL, "count", expired, "maxAge", delivery.DeadLetterMaxAge.String())
+		d.addCount(ctx, d.meters.DroppedCount, endpoint.URL, expired)
+	}
+	q.requeueDeadLetters(delivery.QueueSize)
+}
+
+func (d *CloudEventDispatcher) deliverWithRetries(ctx context.Context, endpoint config.CloudEventsEndpoint, events []ce.Event, delivery config.CloudEventsDelivery) error {
+	headers, err := d.getHeaders(ctx, endpoint.HeadersSecret)
+	if err != nil {
+		return err
+	}
+
+	backoff := delivery.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err = d.deliver(ctx, endpoint.URL, headers, events)
+		if err == nil || attempt >= delivery.MaxRetries {
+			return err
+		}
+		d.logger.V(5).Info("could not deliver Cloud Events, retrying", "endpoint", endpoint.URL, "attempt", attempt+1, "backoff", backoff.String(), "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxRetryBackoff)
+	}
+}
+
+// deliver sends a single Cloud Event in binary content mode, or several Cloud Events in batched content mode
+func (d *CloudEventDispatcher) deliver(ctx context.Context, url string, headers http.Header, events []ce.Event) error {
+	if len(events) == 1 {
+		ctx = cehttp.WithCustomHeader(ce.ContextWithTarget(ctx, url), headers)
+		if result := d.client.Send(ctx, events[0]); !ce.IsACK(result) {
+			return fmt.Errorf("%w: %w", controllererrors.ErrCloudEventsNotDelivered, result)
+		}
+		return nil
+	}
+
+	req, err := cehttp.NewHTTPRequestFromEvents(ctx, url, events)
+	if err != nil {
+		return err
+	}
+	for key, values := range headers {
+		req.Header[key] = values
+	}
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", controllererrors.ErrCloudEventsNotDelivered, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf(controllererrors.ErrCloudEventsNotDeliveredMsg, controllererrors.ErrCloudEventsNotDelivered, url, resp.StatusCode)
+	}
+	return nil
+}
+
+// getHeaders reads the HTTP headers added to each request from the given Secret
+func (d *CloudEventDispatcher) getHeaders(ctx context.Context, secretName types.NamespacedName) (http.Header, error) {
+	headers := http.Header{}
+	if secretName.Name == "" {
+		return headers, nil
+	}
+	secret := &corev1.Secret{}
+	if err := d.reader.Get(ctx, secretName, secret); err != nil {
+		return nil, fmt.Errorf("could not retrieve Cloud Events headers Secret %s: %w", secretName, err)
+	}
+	for key, value := range secret.Data {
+		headers.Set(key, string(value))
+	}
+	return headers, nil
+}
+
+func (d *CloudEventDispatcher) addCount(ctx context.Context, counter metric.Int64Counter, url string, count int) {
+	if counter != nil {
+		counter.Add(ctx, int64(count), metric.WithAttributes(attribute.String("keptn.cloudevents.endpoint", url)))
+	}
+}
+
+func (q *endpointQueue) setEndpoint(endpoint config.CloudEventsEndpoint) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.endpoint = endpoint
+}
+
+func (q *endpointQueue) getEndpoint() config.CloudEventsEndpoint {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return q.endpoint
+}
+
+// push adds the given event to the queue, unless the queue is full
+func (q *endpointQueue) push(event ce.Event, queueSize int) bool {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if len(q.queue) >= queueSize {
+		return false
+	}
+	q.queue = append(q.queue, event)
+	return true
+}
+
+func (q *endpointQueue) dequeue(batchSize int) []ce.Event {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	n := min(max(batchSize, 1), len(q.queue))
+	events := make([]ce.Event, n)
+	copy(events, q.queue)
+	q.queue = q.queue[n:]
+	return events
+}
+
+// clear removes all queued and dead-letter events and returns their number
+func (q *endpointQueue) clear() int {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	n := len(q.queue) + len(q.deadLetter)
+	q.queue = nil
+	q.deadLetter = nil
+	return n
+}
+
+// addDeadLetters adds the given events to the dead-letter buffer and returns the number of
+// oldest events that were dropped because the buffer is full
+func (q *endpointQueue) addDeadLetters(events []ce.Event, deadLetterSize int) int {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	q.deadLetter = append(q.deadLetter, events...)
+	overflow := max(len(q.deadLetter)-deadLetterSize, 0)
+	q.deadLetter = q.deadLetter[overflow:]
+	return overflow
+}
+
+// dropExpiredDeadLetters removes the events of the dead-letter buffer emitted before the given time
+// and returns their number
+func (q *endpointQueue) dropExpiredDeadLetters(expiry time.Time) int {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	deadLetter := q.deadLetter[:0]
+	for _, event := range q.deadLetter {
+		if event.Time().After(expiry) {
+			deadLetter = append(deadLetter, event)
+		}
+	}
+	expired := len(q.deadLetter) - len(deadLetter)
+	q.deadLetter = deadLetter
+	return expired
+}
+
+// requeueDeadLetters moves the events of the dead-letter buffer to the front of the queue, as far as it has capacity
+func (q *endpointQueue) requeueDeadLetters(queueSize int) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	n := min(max(queueSize-len(q.queue), 0), len(q.deadLetter))
+	if n == 0 {
+		return
+	}
+	queue := make([]ce.Event, 0, n+len(q.queue))
+	queue = append(queue, q.deadLetter[:n]...)
+	q.queue = append(queue, q.queue...)
+	q.deadLetter = q.deadLetter[n:]
+}
@@ -0,0 +1,362 @@
+package eventsender
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	fakeconfig "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config/fake"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCloudEventDispatcher_Retries(t *testing.T) {
+	var requests atomic.Int32
+	received := make(chan string, 1)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the receiver is restarting and fails the first two requests
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received <- r.Header.Get("Ce-Id")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	dispatcher, endpoint, meters := newTestDispatcher(svr.URL, config.CloudEventsDelivery{MaxRetries: 3}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		require.Nil(t, dispatcher.Start(ctx))
+	}()
+
+	dispatcher.Enqueue(endpoint, makeTestEvent("event-1"))
+
+	select {
+	case id := <-received:
+		require.Equal(t, "event-1", id)
+	case <-time.After(5 * time.Second):
+		t.Fatal("didn't receive the cloud event")
+	}
+	require.Eventually(t, func() bool {
+		return meters.sent.get() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(3), requests.Load())
+	require.Zero(t, meters.failed.get())
+}
+
+func TestCloudEventDispatcher_Batching(t *testing.T) {
+	received := make(chan []string, 1)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, ce.ApplicationCloudEventsBatchJSON, r.Header.Get("Content-Type"))
+		data, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		events := []ce.Event{}
+		require.Nil(t, json.Unmarshal(data, &events))
+		ids := []string{}
+		for _, event := range events {
+			ids = append(ids, event.ID())
+		}
+		received <- ids
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer svr.Close()
+
+	dispatcher, endpoint, meters := newTestDispatcher(svr.URL, config.CloudEventsDelivery{BatchSize: 3}, nil)
+	dispatcher.Enqueue(endpoint, makeTestEvent("event-1"))
+	dispatcher.Enqueue(endpoint, makeTestEvent("event-2"))
+	dispatcher.Enqueue(endpoint, makeTestEvent("event-3"))
+
+	dispatcher.deliverQueued(context.TODO(), dispatcher.getQueue(endpoint))
+
+	select {
+	case ids := <-received:
+		require.Equal(t, []string{"event-1", "event-2", "event-3"}, ids)
+	case <-time.After(5 * time.Second):
+		t.Fatal("didn't receive the cloud events")
+	}
+	require.Equal(t, int64(3), meters.sent.get())
+}
+
+func TestCloudEventDispatcher_DeadLetter(t *testing.T) {
+	var available atomic.Bool
+	var received []string
+	var mtx sync.Mutex
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		mtx.Lock()
+		received = append(received, r.Header.Get("Ce-Id"))
+		mtx.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	dispatcher, endpoint, meters := newTestDispatcher(svr.URL, config.CloudEventsDelivery{MaxRetries: 1, DeadLetterSize: 1}, nil)
+	q := dispatcher.getQueue(endpoint)
+
+	// the receiver is not available, the events end up in the dead-letter buffer
+	dispatcher.Enqueue(endpoint, makeTestEvent("event-1"))
+	dispatcher.Enqueue(endpoint, makeTestEvent("event-2"))
+	dispatcher.deliverQueued(context.TODO(), q)
+
+	require.Equal(t, int64(2), meters.failed.get())
+	require.Equal(t, int64(1), meters.dropped.get())
+	require.Len(t, q.deadLetter, 1)
+	require.Equal(t, "event-2", q.deadLetter[0].ID())
+
+	// once the receiver is available again, the dead-letter buffer is sent as well
+	available.Store(true)
+	dispatcher.Enqueue(endpoint, makeTestEvent("event-3"))
+	dispatcher.deliverQueued(context.TODO(), q)
+
+	require.Equal(t, []string{"event-3", "event-2"}, received)
+	require.Equal(t, int64(2), meters.sent.get())
+	require.Empty(t, q.deadLetter)
+	require.Empty(t, q.queue)
+}
+
+func TestCloudEventDispatcher_DeadLetterMaxAge(t *testing.T) {
+	received := make(chan string, 2)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("Ce-Id")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	dispatcher, endpoint, meters := newTestDispatcher(svr.URL, config.CloudEventsDelivery{DeadLetterMaxAge: time.Hour}, nil)
+	q := dispatcher.getQueue(endpoint)
+
+	expired := makeTestEvent("event-1")
+	expired.SetTime(time.Now().Add(-2 * time.Hour))
+	q.addDeadLetters([]ce.Event{expired, makeTestEvent("event-2")}, config.DefaultCloudEventsDeadLetterSize)
+
+	// only the events that did not exceed the maximum age are sent again
+	dispatcher.Enqueue(endpoint, makeTestEvent("event-3"))
+	dispatcher.deliverQueued(context.TODO(), q)
+
+	require.Equal(t, "event-3", <-received)
+	require.Equal(t, "event-2", <-received)
+	require.Equal(t, int64(2), meters.sent.get())
+	require.Equal(t, int64(1), meters.dropped.get())
+	require.Empty(t, q.deadLetter)
+}
+
+func TestCloudEventDispatcher_MultipleEndpoints(t *testing.T) {
+	received := make(chan string, 1)
+	available := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("Ce-Id")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer available.Close()
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+
+	dispatcher, unavailableEndpoint, _ := newTestDispatcher(unavailable.URL, config.CloudEventsDelivery{MaxRetries: 5, RetryBackoff: time.Hour}, nil)
+	availableEndpoint := config.CloudEventsEndpoint{URL: available.URL}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		require.Nil(t, dispatcher.Start(ctx))
+	}()
+
+	// the unavailable endpoint waits for its next retry and does not delay the other endpoint
+	dispatcher.Enqueue(unavailableEndpoint, makeTestEvent("event-1"))
+	dispatcher.Enqueue(availableEndpoint, makeTestEvent("event-1"))
+
+	select {
+	case id := <-received:
+		require.Equal(t, "event-1", id)
+	case <-time.After(5 * time.Second):
+		t.Fatal("didn't receive the cloud event")
+	}
+}
+
+func TestCloudEventDispatcher_SameURL(t *testing.T) {
+	dispatcher, endpoint, _ := newTestDispatcher("http://localhost", config.CloudEventsDelivery{}, nil)
+	cdEventsEndpoint := config.CloudEventsEndpoint{URL: endpoint.URL, Format: config.CloudEventsFormatCDEvents}
+	securedEndpoint := config.CloudEventsEndpoint{URL: endpoint.URL, HeadersSecret: types.NamespacedName{Namespace: "keptn-system", Name: "headers"}}
+
+	dispatcher.Enqueue(endpoint, makeTestEvent("event-1"))
+	dispatcher.Enqueue(cdEventsEndpoint, makeTestEvent("event-2"))
+	dispatcher.Enqueue(securedEndpoint, makeTestEvent("event-3"))
+
+	// endpoints with the same URL but a different format or headers Secret use separate queues
+	require.Len(t, dispatcher.queues, 3)
+	require.Equal(t, "event-1", dispatcher.getQueue(endpoint).queue[0].ID())
+	require.Equal(t, config.CloudEventsFormatCDEvents, dispatcher.getQueue(cdEventsEndpoint).getEndpoint().Format)
+	require.Equal(t, "event-3", dispatcher.getQueue(securedEndpoint).queue[0].ID())
+
+	// an explicit keptn format is the same endpoint as the default format
+	dispatcher.Enqueue(config.CloudEventsEndpoint{URL: endpoint.URL, Format: config.CloudEventsFormatKeptn}, makeTestEvent("event-4"))
+	require.Len(t, dispatcher.queues, 3)
+	require.Len(t, dispatcher.getQueue(endpoint).queue, 2)
+}
+
+func TestCloudEventDispatcher_QueueFull(t *testing.T) {
+	dispatcher, endpoint, meters := newTestDispatcher("http://localhost", config.CloudEventsDelivery{QueueSize: 1}, nil)
+	q := dispatcher.getQueue(endpoint)
+
+	dispatcher.Enqueue(endpoint, makeTestEvent("event-1"))
+	dispatcher.Enqueue(endpoint, makeTestEvent("event-2"))
+
+	require.Len(t, q.queue, 1)
+	require.Equal(t, "event-1", q.queue[0].ID())
+	require.Equal(t, int64(1), meters.dropped.get())
+}
+
+func TestCloudEventDispatcher_HeadersSecret(t *testing.T) {
+	received := make(chan string, 1)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloudevents-headers", Namespace: "keptn-system"},
+		Data: map[string][]byte{
+			"Authorization": []byte("Bearer my-token"),
+		},
+	}
+	dispatcher, endpoint, _ := newTestDispatcher(svr.URL, config.CloudEventsDelivery{}, testcommon.NewTestClient(secret))
+	endpoint.HeadersSecret = types.NamespacedName{Namespace: "keptn-system", Name: "cloudevents-headers"}
+
+	dispatcher.Enqueue(endpoint, makeTestEvent("event-1"))
+	dispatcher.deliverQueued(context.TODO(), dispatcher.getQueue(endpoint))
+
+	select {
+	case authorization := <-received:
+		require.Equal(t, "Bearer my-token", authorization)
+	case <-time.After(5 * time.Second):
+		t.Fatal("didn't receive the cloud event")
+	}
+}
+
+func TestCloudEventDispatcher_HeadersSecretMissing(t *testing.T) {
+	dispatcher, endpoint, meters := newTestDispatcher("http://localhost", config.CloudEventsDelivery{}, testcommon.NewTestClient())
+	endpoint.HeadersSecret = types.NamespacedName{Namespace: "keptn-system", Name: "cloudevents-headers"}
+
+	dispatcher.Enqueue(endpoint, makeTestEvent("event-1"))
+	q := dispatcher.getQueue(endpoint)
+	dispatcher.deliverQueued(context.TODO(), q)
+
+	require.Equal(t, int64(1), meters.failed.get())
+	require.Len(t, q.deadLetter, 1)
+}
+
+func TestCloudEventDispatcher_RemoveStaleQueues(t *testing.T) {
+	dispatcher, endpoint, meters := newTestDispatcher("http://localhost", config.CloudEventsDelivery{}, nil)
+
+	removedEndpoint := config.CloudEventsEndpoint{URL: "http://removed"}
+	dispatcher.Enqueue(removedEndpoint, makeTestEvent("event-1"))
+	removedQueue := dispatcher.getQueue(removedEndpoint)
+	removedQueue.addDeadLetters([]ce.Event{makeTestEvent("event-2")}, config.DefaultCloudEventsDeadLetterSize)
+	var stopped atomic.Bool
+	removedQueue.cancel = func() { stopped.Store(true) }
+	configuredQueue := dispatcher.getQueue(endpoint)
+	require.Len(t, dispatcher.queues, 2)
+
+	dispatcher.removeStaleQueues()
+
+	// the queue of the endpoint that is no longer configured is stopped and its events are dropped
+	require.Len(t, dispatcher.queues, 1)
+	require.Same(t, configuredQueue, dispatcher.getQueue(endpoint))
+	require.True(t, stopped.Load())
+	require.Empty(t, removedQueue.queue)
+	require.Empty(t, removedQueue.deadLetter)
+	require.Equal(t, int64(2), meters.dropped.get())
+
+	// a removed queue is created again once the endpoint is configured again
+	dispatcher.Enqueue(removedEndpoint, makeTestEvent("event-3"))
+	require.Len(t, dispatcher.queues, 2)
+	require.NotSame(t, removedQueue, dispatcher.getQueue(removedEndpoint))
+}
+
+type testMeters struct {
+	sent    *fakeCounter
+	failed  *fakeCounter
+	dropped *fakeCounter
+}
+
+type fakeCounter struct {
+	noop.Int64Counter
+	count atomic.Int64
+}
+
+func (c *fakeCounter) Add(_ context.Context, incr int64, _ ...metric.AddOption) {
+	c.count.Add(incr)
+}
+
+func (c *fakeCounter) get() int64 {
+	return c.count.Load()
+}
+
+func newTestDispatcher(url string, delivery config.CloudEventsDelivery, reader client.Reader) (*CloudEventDispatcher, config.CloudEventsEndpoint, testMeters) {
+	if delivery.QueueSize == 0 {
+		delivery.QueueSize = config.DefaultCloudEventsQueueSize
+	}
+	if delivery.RetryBackoff == 0 {
+		delivery.RetryBackoff = time.Millisecond
+	}
+	if delivery.BatchSize == 0 {
+		delivery.BatchSize = config.DefaultCloudEventsBatchSize
+	}
+	if delivery.DeadLetterSize == 0 {
+		delivery.DeadLetterSize = config.DefaultCloudEventsDeadLetterSize
+	}
+	if delivery.DeadLetterMaxAge == 0 {
+		delivery.DeadLetterMaxAge = config.DefaultCloudEventsDeadLetterMaxAge
+	}
+
+	meters := testMeters{sent: &fakeCounter{}, failed: &fakeCounter{}, dropped: &fakeCounter{}}
+	ceClient, err := ce.NewClientHTTP()
+	if err != nil {
+		panic(err)
+	}
+	dispatcher := NewCloudEventDispatcher(ctrl.Log.WithName("test"), ceClient, reader, apicommon.CloudEventMeters{
+		SentCount:    meters.sent,
+		FailedCount:  meters.failed,
+		DroppedCount: meters.dropped,
+	})
+	endpoint := config.CloudEventsEndpoint{URL: url}
+	dispatcher.config = &fakeconfig.MockConfig{
+		GetCloudEventsDeliveryFunc: func() config.CloudEventsDelivery {
+			return delivery
+		},
+		GetCloudEventsEndpointsFunc: func() []config.CloudEventsEndpoint {
+			return []config.CloudEventsEndpoint{endpoint}
+		},
+	}
+	return dispatcher, endpoint, meters
+}
+
+func makeTestEvent(id string) ce.Event {
+	event := ce.NewEvent()
+	event.SetID(id)
+	event.SetSource("keptn.sh")
+	event.SetType("test")
+	event.SetTime(time.Now())
+	return event
+}
@@ -1,9 +1,10 @@
 package eventsender
 
 import (
-	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	ce "github.com/cloudevents/sdk-go/v2"
 	"github.com/go-logr/logr"
@@ -11,6 +12,7 @@ import (
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
 	"golang.org/x/exp/maps"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -27,11 +29,12 @@ type EventMultiplexer struct {
 	emitters []IEvent
 }
 
-func NewEventMultiplexer(logger logr.Logger, recorder record.EventRecorder, client ce.Client) *EventMultiplexer {
+func NewEventMultiplexer(logger logr.Logger, recorder record.EventRecorder, dispatcher *CloudEventDispatcher) *EventMultiplexer {
 	multiplexer := &EventMultiplexer{
 		logger: logger,
 	}
-	multiplexer.register(newCloudEventSender(logger, client))
+	multiplexer.register(newCloudEventSender(logger, dispatcher))
+	multiplexer.register(newCDEventSender(logger, dispatcher))
 	multiplexer.register(NewK8sSender(recorder))
 	return multiplexer
 }
@@ -52,28 +55,32 @@ func (e *EventMultiplexer) Emit(phase apicommon.KeptnPhaseType, eventType string
 // ===== Cloud Event Sender =====
 
 type cloudEvent struct {
-	client ce.Client
-	logger logr.Logger
+	dispatcher *CloudEventDispatcher
+	logger     logr.Logger
 }
 
-func newCloudEventSender(logger logr.Logger, client ce.Client) *cloudEvent {
+func newCloudEventSender(logger logr.Logger, dispatcher *CloudEventDispatcher) *cloudEvent {
 	return &cloudEvent{
-		client: client,
-		logger: logger,
+		dispatcher: dispatcher,
+		logger:     logger,
 	}
 }
 
-// Emit creates a Cloud Event and send it to the endpoint
+// Emit creates a Cloud Event and queues it for delivery to all endpoints whose filter matches the event
 func (e *cloudEvent) Emit(phase apicommon.KeptnPhaseType, eventType string, reconcileObject client.Object, status string, message string, version string) {
-	endpoint := config.Instance().GetCloudEventsEndpoint()
-	if endpoint == "" {
-		// if no endpoint is configured we don't emit any event
-		if !strings.HasPrefix(endpoint, "http") {
-			e.logger.V(5).Info(fmt.Sprintf("CloudEvent endpoint configured but it does not start with http: %s", endpoint))
-		}
+	if e.dispatcher == nil {
 		return
 	}
+	endpoints := getEndpoints(e.logger, config.CloudEventsFormatKeptn, phase, eventType, reconcileObject, status)
+	if len(endpoints) == 0 {
+		// if no endpoint is configured or interested in the event we don't emit it
+		return
+	}
+
 	event := ce.NewEvent()
+	// the ID is set upfront, so that retried deliveries of the event can be recognized by the receiver
+	event.SetID(string(uuid.NewUUID()))
+	event.SetTime(time.Now())
 	event.SetSource("keptn.sh")
 	event.SetType(fmt.Sprintf("%s.%s", phase.LongName, status))
 
@@ -95,10 +102,48 @@ func (e *cloudEvent) Emit(phase apicommon.KeptnPhaseType, eventType string, reco
 		return
 	}
 
-	ctx := ce.ContextWithTarget(context.TODO(), endpoint)
-	if result := e.client.Send(ctx, event); ce.IsUndelivered(result) {
-		e.logger.V(5).Info(fmt.Sprintf("Failed to send CloudEvent: %v", event))
+	for _, endpoint := range endpoints {
+		e.dispatcher.Enqueue(endpoint, event.Clone())
+	}
+}
+
+// getEndpoints returns the endpoints of the given format whose filter matches the event
+func getEndpoints(logger logr.Logger, format string, phase apicommon.KeptnPhaseType, eventType string, reconcileObject client.Object, status string) []config.CloudEventsEndpoint {
+	endpoints := []config.CloudEventsEndpoint{}
+	for _, endpoint := range config.Instance().GetCloudEventsEndpoints() {
+		if getFormat(endpoint) != format {
+			continue
+		}
+		if !strings.HasPrefix(endpoint.URL, "http") {
+			logger.V(5).Info(fmt.Sprintf("CloudEvent endpoint configured but it does not start with http: %s", endpoint.URL))
+			continue
+		}
+		if matchesFilter(endpoint.Filter, reconcileObject.GetNamespace(), phase.ShortName, status, eventType) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// getFormat returns the format of the Cloud Events sent to the given endpoint
+func getFormat(endpoint config.CloudEventsEndpoint) string {
+	if endpoint.Format == "" {
+		return config.CloudEventsFormatKeptn
 	}
+	return endpoint.Format
+}
+
+// matchesFilter checks whether an event with the given properties is selected by the given filter
+func matchesFilter(filter config.CloudEventsFilter, namespace string, phase string, status string, eventType string) bool {
+	return matchesAny(filter.Namespaces, namespace) &&
+		matchesAny(filter.Phases, phase) &&
+		matchesAny(filter.Statuses, status) &&
+		matchesAny(filter.Types, eventType)
+}
+
+// matchesAny checks whether the value is contained in the given values, an empty list matches all values
+func matchesAny(values []string, value string) bool {
+	return len(values) == 0 || slices.Contains(values, value)
 }
 
 // ===== K8s Event Sender =====
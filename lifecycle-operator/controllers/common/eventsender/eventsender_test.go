@@ -1,6 +1,7 @@
 package eventsender
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -76,7 +77,13 @@ func TestEventSender_SendCloudEvent(t *testing.T) {
 	if err != nil {
 		log.Fatalf("failed to create client, %v", err)
 	}
-	ceSender := newCloudEventSender(ctrl.Log.WithName("testytest"), c)
+	dispatcher := NewCloudEventDispatcher(ctrl.Log.WithName("testytest"), c, nil, apicommon.CloudEventMeters{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		require.Nil(t, dispatcher.Start(ctx))
+	}()
+	ceSender := newCloudEventSender(ctrl.Log.WithName("testytest"), dispatcher)
 	ceSender.Emit(phase, eventType, &apilifecycle.KeptnAppVersion{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      name,
@@ -124,7 +131,8 @@ func TestEventSender_CloudEventNoFailure(t *testing.T) {
 		if err != nil {
 			log.Fatalf("failed to create client, %v", err)
 		}
-		ceSender := newCloudEventSender(ctrl.Log.WithName("testytest"), c)
+		dispatcher := NewCloudEventDispatcher(ctrl.Log.WithName("testytest"), c, nil, apicommon.CloudEventMeters{})
+		ceSender := newCloudEventSender(ctrl.Log.WithName("testytest"), dispatcher)
 		ceSender.Emit(apicommon.PhaseAppCompleted, "type", &apilifecycle.KeptnAppVersion{
 			ObjectMeta: v1.ObjectMeta{
 				Name:      "app",
@@ -135,6 +143,90 @@ func TestEventSender_CloudEventNoFailure(t *testing.T) {
 	}
 }
 
+func TestEventSender_CloudEventFilter(t *testing.T) {
+	config.Instance().SetCloudEventsEndpoint("http://audit")
+	config.Instance().SetCloudEventsEndpoints([]config.CloudEventsEndpoint{
+		{
+			URL: "http://paging",
+			Filter: config.CloudEventsFilter{
+				Namespaces: []string{"production"},
+				Statuses:   []string{apicommon.PhaseStateFailed},
+			},
+		},
+		{
+			URL: "http://tasks",
+			Filter: config.CloudEventsFilter{
+				Phases: []string{apicommon.PhaseWorkloadPreDeployment.ShortName},
+				Types:  []string{"Warning"},
+			},
+		},
+	})
+	defer config.Instance().SetCloudEventsEndpoint("")
+	defer config.Instance().SetCloudEventsEndpoints(nil)
+
+	tests := []struct {
+		name          string
+		namespace     string
+		phase         apicommon.KeptnPhaseType
+		eventType     string
+		status        string
+		wantEndpoints []string
+	}{
+		{
+			name:          "failure in production",
+			namespace:     "production",
+			phase:         apicommon.PhaseAppDeployment,
+			eventType:     "Warning",
+			status:        apicommon.PhaseStateFailed,
+			wantEndpoints: []string{"http://audit", "http://paging"},
+		},
+		{
+			name:          "failure in staging",
+			namespace:     "staging",
+			phase:         apicommon.PhaseAppDeployment,
+			eventType:     "Warning",
+			status:        apicommon.PhaseStateFailed,
+			wantEndpoints: []string{"http://audit"},
+		},
+		{
+			name:          "warning of pre-deployment tasks",
+			namespace:     "staging",
+			phase:         apicommon.PhaseWorkloadPreDeployment,
+			eventType:     "Warning",
+			status:        apicommon.PhaseStateFailed,
+			wantEndpoints: []string{"http://audit", "http://tasks"},
+		},
+		{
+			name:          "progress of pre-deployment tasks",
+			namespace:     "production",
+			phase:         apicommon.PhaseWorkloadPreDeployment,
+			eventType:     "Normal",
+			status:        apicommon.PhaseStateStarted,
+			wantEndpoints: []string{"http://audit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dispatcher := NewCloudEventDispatcher(ctrl.Log.WithName("testytest"), nil, nil, apicommon.CloudEventMeters{})
+			ceSender := newCloudEventSender(ctrl.Log.WithName("testytest"), dispatcher)
+			ceSender.Emit(tt.phase, tt.eventType, &apilifecycle.KeptnWorkloadVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "workload",
+					Namespace: tt.namespace,
+				},
+			}, tt.status, "message", "version")
+
+			endpoints := []string{}
+			for key, q := range dispatcher.queues {
+				require.Len(t, q.queue, 1)
+				endpoints = append(endpoints, key.url)
+			}
+			require.ElementsMatch(t, tt.wantEndpoints, endpoints)
+		})
+	}
+}
+
 func TestEventSender_Multiplexer_register(t *testing.T) {
 	tests := []struct {
 		input  IEvent
@@ -161,8 +253,8 @@ func TestEventSender_Multiplexer_new(t *testing.T) {
 	// init the object
 	em := NewEventMultiplexer(zap.New(), nil, nil)
 	// then assert
-	// k8s and ce are registered
-	require.Equal(t, 2, len(em.emitters))
+	// k8s, ce and cdevents are registered
+	require.Equal(t, 3, len(em.emitters))
 }
 
 func TestEventSender_Multiplexer_emit(t *testing.T) {
@@ -3,18 +3,38 @@ package common
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/common/expression"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	keptncontext "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/context"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
 	"golang.org/x/exp/maps"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// TaskDefinitionRefSeparator separates the namespace and the name of a KeptnTaskDefinition of a task definition library
+const TaskDefinitionRefSeparator = "/"
+
+// AllNamespaces can be used in the allowed namespaces of a task definition library to allow all namespaces to use it
+const AllNamespaces = "*"
+
+// AnalysisGVK is the GroupVersionKind of the Analysis resource of the metrics-operator
+var AnalysisGVK = schema.GroupVersionKind{Group: "metrics.keptn.sh", Version: "v1", Kind: "Analysis"}
+
+// AnalysisDefinitionGVK is the GroupVersionKind of the AnalysisDefinition resource of the metrics-operator
+var AnalysisDefinitionGVK = schema.GroupVersionKind{Group: "metrics.keptn.sh", Version: "v1", Kind: "AnalysisDefinition"}
+
 // GetItemStatus retrieves the state of the task/evaluation, if it does not exists, it creates a default one
 func GetItemStatus(name string, instanceStatus []apilifecycle.ItemStatus) apilifecycle.ItemStatus {
 	for _, status := range instanceStatus {
@@ -41,6 +61,28 @@ func GetOldStatus(name string, statuses []apilifecycle.ItemStatus) apicommon.Kep
 	return oldstatus
 }
 
+// GetExpressionVariables returns the variables the conditions of the tasks and evaluations of the given phase item are evaluated against
+func GetExpressionVariables(phaseCtx context.Context, piWrapper *interfaces.PhaseItemWrapper, checkType apicommon.CheckType) expression.Variables {
+	variables := expression.Variables{
+		AppName:         piWrapper.GetAppName(),
+		Version:         piWrapper.GetVersion(),
+		PreviousVersion: piWrapper.GetPreviousVersion(),
+		CheckType:       string(checkType),
+	}
+	if _, ok := piWrapper.Obj.(*apilifecycle.KeptnWorkloadVersion); ok {
+		variables.ObjectType = "Workload"
+		variables.WorkloadName = piWrapper.GetParentName()
+		variables.WorkloadVersion = piWrapper.GetVersion()
+	} else {
+		variables.ObjectType = "App"
+		variables.AppVersion = piWrapper.GetVersion()
+	}
+	if metadata, ok := keptncontext.GetAppMetadataFromContext(phaseCtx); ok {
+		variables.Metadata = metadata
+	}
+	return variables
+}
+
 func MergeMaps[M1 ~map[K]V, K comparable, V any](map1 M1, map2 M1) M1 {
 	merged := make(M1, len(map1)+len(map2))
 	// we copy the map1 first, so the values in the overlapping
@@ -50,14 +92,51 @@ func MergeMaps[M1 ~map[K]V, K comparable, V any](map1 M1, map2 M1) M1 {
 	return merged
 }
 
+// GetTaskDefinition retrieves the KeptnTaskDefinition with the given name from the given namespace, or from the Keptn namespace.
+// A KeptnTaskDefinition of a task definition library can be referenced as <namespace>/<name>,
+// in which case it is only retrieved if the given namespace is allowed to use the library.
 func GetTaskDefinition(k8sclient client.Client, log logr.Logger, ctx context.Context, definitionName string, namespace string) (*apilifecycle.KeptnTaskDefinition, error) {
 	definition := &apilifecycle.KeptnTaskDefinition{}
-	if err := getObject(k8sclient, log, ctx, definitionName, namespace, definition); err != nil {
+	libraryNamespace, name, isLibraryRef := strings.Cut(definitionName, TaskDefinitionRefSeparator)
+	if !isLibraryRef {
+		if err := getObject(k8sclient, log, ctx, definitionName, namespace, definition); err != nil {
+			return nil, err
+		}
+		return definition, nil
+	}
+	if !IsTaskDefinitionAccessAllowed(libraryNamespace, namespace) {
+		return nil, fmt.Errorf(controllererrors.ErrTaskDefinitionAccessDeniedMsg, controllererrors.ErrTaskDefinitionAccessDenied, namespace, definitionName)
+	}
+	if err := k8sclient.Get(ctx, types.NamespacedName{Name: name, Namespace: libraryNamespace}, definition); err != nil {
+		log.Info("Could not find resource in task definition library", "resource type", fmt.Sprintf("%T", definition), "definition name", name, "namespace", libraryNamespace)
 		return nil, err
 	}
 	return definition, nil
 }
 
+// IsTaskDefinitionAccessAllowed checks whether the KeptnTaskDefinitions of the library namespace may be used by the given namespace.
+// The own namespace and the Keptn namespace are always allowed, all other namespaces need to be configured
+// as task definition library in the KeptnConfig.
+func IsTaskDefinitionAccessAllowed(libraryNamespace string, namespace string) bool {
+	if libraryNamespace == namespace || libraryNamespace == config.Instance().GetDefaultNamespace() {
+		return true
+	}
+	allowedNamespaces, ok := config.Instance().GetTaskDefinitionLibraries()[libraryNamespace]
+	if !ok {
+		return false
+	}
+	return slices.Contains(allowedNamespaces, namespace) || slices.Contains(allowedNamespaces, AllNamespaces)
+}
+
+// GetTaskDefinitionRef returns the reference a KeptnTask in the given namespace uses for the given KeptnTaskDefinition.
+// KeptnTaskDefinitions located in neither the given namespace nor the Keptn namespace are referenced as <namespace>/<name>.
+func GetTaskDefinitionRef(definition apilifecycle.KeptnTaskDefinition, namespace string) string {
+	if definition.Namespace == "" || definition.Namespace == namespace || definition.Namespace == config.Instance().GetDefaultNamespace() {
+		return definition.Name
+	}
+	return definition.Namespace + TaskDefinitionRefSeparator + definition.Name
+}
+
 func GetEvaluationDefinition(k8sclient client.Client, log logr.Logger, ctx context.Context, definitionName string, namespace string) (*apilifecycle.KeptnEvaluationDefinition, error) {
 	definition := &apilifecycle.KeptnEvaluationDefinition{}
 	if err := getObject(k8sclient, log, ctx, definitionName, namespace, definition); err != nil {
@@ -66,6 +145,17 @@ func GetEvaluationDefinition(k8sclient client.Client, log logr.Logger, ctx conte
 	return definition, nil
 }
 
+// GetAnalysisDefinition retrieves the AnalysisDefinition of the metrics-operator with the given name
+// from the given namespace, or from the Keptn namespace.
+func GetAnalysisDefinition(k8sclient client.Client, log logr.Logger, ctx context.Context, definitionName string, namespace string) (*unstructured.Unstructured, error) {
+	definition := &unstructured.Unstructured{}
+	definition.SetGroupVersionKind(AnalysisDefinitionGVK)
+	if err := getObject(k8sclient, log, ctx, definitionName, namespace, definition); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
 func getObject(k8sclient client.Client, log logr.Logger, ctx context.Context, definitionName string, namespace string, definition client.Object) error {
 	err := k8sclient.Get(ctx, types.NamespacedName{Name: definitionName, Namespace: namespace}, definition)
 	if err != nil {
@@ -7,11 +7,16 @@ import (
 
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/common/expression"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	keptncontext "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/context"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -204,6 +209,87 @@ func Test_GetTaskDefinition(t *testing.T) {
 	}
 }
 
+func Test_GetTaskDefinitionFromLibrary(t *testing.T) {
+	libraryTaskDef := &apilifecycle.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "taskDef",
+			Namespace: "platform-tasks",
+		},
+	}
+	tests := []struct {
+		name        string
+		taskDefName string
+		namespace   string
+		libraries   map[string][]string
+		wantErr     error
+	}{
+		{
+			name:        "namespace allowed to use library",
+			taskDefName: "platform-tasks/taskDef",
+			namespace:   "app-a",
+			libraries:   map[string][]string{"platform-tasks": {"app-a"}},
+		},
+		{
+			name:        "all namespaces allowed to use library",
+			taskDefName: "platform-tasks/taskDef",
+			namespace:   "app-a",
+			libraries:   map[string][]string{"platform-tasks": {"*"}},
+		},
+		{
+			name:        "namespace not allowed to use library",
+			taskDefName: "platform-tasks/taskDef",
+			namespace:   "app-b",
+			libraries:   map[string][]string{"platform-tasks": {"app-a"}},
+			wantErr:     controllererrors.ErrTaskDefinitionAccessDenied,
+		},
+		{
+			name:        "namespace is no library",
+			taskDefName: "platform-tasks/taskDef",
+			namespace:   "app-a",
+			wantErr:     controllererrors.ErrTaskDefinitionAccessDenied,
+		},
+		{
+			name:        "own namespace is always allowed",
+			taskDefName: "platform-tasks/taskDef",
+			namespace:   "platform-tasks",
+		},
+	}
+
+	err := apilifecycle.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+
+	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
+	defer config.Instance().SetTaskDefinitionLibraries(nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Instance().SetTaskDefinitionLibraries(tt.libraries)
+			client := fake.NewClientBuilder().WithObjects(libraryTaskDef).Build()
+			d, err := GetTaskDefinition(client, ctrl.Log.WithName("testytest"), context.TODO(), tt.taskDefName, tt.namespace)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, d)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, libraryTaskDef.Name, d.Name)
+			require.Equal(t, libraryTaskDef.Namespace, d.Namespace)
+		})
+	}
+}
+
+func Test_GetTaskDefinitionRef(t *testing.T) {
+	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
+
+	makeDef := func(namespace string) apilifecycle.KeptnTaskDefinition {
+		return apilifecycle.KeptnTaskDefinition{ObjectMeta: metav1.ObjectMeta{Name: "taskDef", Namespace: namespace}}
+	}
+
+	require.Equal(t, "taskDef", GetTaskDefinitionRef(makeDef("app-a"), "app-a"))
+	require.Equal(t, "taskDef", GetTaskDefinitionRef(makeDef(testcommon.KeptnNamespace), "app-a"))
+	require.Equal(t, "platform-tasks/taskDef", GetTaskDefinitionRef(makeDef("platform-tasks"), "app-a"))
+}
+
 //nolint:dupl
 func Test_GetEvaluationDefinition(t *testing.T) {
 	tests := []struct {
@@ -287,6 +373,56 @@ func Test_GetEvaluationDefinition(t *testing.T) {
 	}
 }
 
+func Test_GetAnalysisDefinition(t *testing.T) {
+	newAnalysisDefinition := func(namespace string) *unstructured.Unstructured {
+		definition := &unstructured.Unstructured{}
+		definition.SetGroupVersionKind(AnalysisDefinitionGVK)
+		definition.SetName("analysisDef")
+		definition.SetNamespace(namespace)
+		return definition
+	}
+
+	tests := []struct {
+		name          string
+		definition    *unstructured.Unstructured
+		wantNamespace string
+		wantError     bool
+	}{
+		{
+			name:       "analysisDef not found",
+			definition: newAnalysisDefinition("some-other-namespace"),
+			wantError:  true,
+		},
+		{
+			name:          "analysisDef found",
+			definition:    newAnalysisDefinition("some-namespace"),
+			wantNamespace: "some-namespace",
+		},
+		{
+			name:          "analysisDef found in default Keptn namespace",
+			definition:    newAnalysisDefinition(testcommon.KeptnNamespace),
+			wantNamespace: testcommon.KeptnNamespace,
+		},
+	}
+
+	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithObjects(tt.definition).Build()
+			d, err := GetAnalysisDefinition(client, ctrl.Log.WithName("testytest"), context.TODO(), "analysisDef", "some-namespace")
+			if tt.wantError {
+				require.NotNil(t, err)
+				require.Nil(t, d)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, "analysisDef", d.GetName())
+			require.Equal(t, tt.wantNamespace, d.GetNamespace())
+		})
+	}
+}
+
 func TestGetRequestInfo(t *testing.T) {
 	req := ctrl.Request{
 		NamespacedName: types.NamespacedName{
@@ -302,6 +438,51 @@ func TestGetRequestInfo(t *testing.T) {
 	require.Equal(t, expected, info)
 }
 
+func TestGetExpressionVariables(t *testing.T) {
+	appVersion := &apilifecycle.KeptnAppVersion{
+		Spec: apilifecycle.KeptnAppVersionSpec{
+			KeptnAppSpec: apilifecycle.KeptnAppSpec{
+				Version: "2.0.0",
+			},
+			AppName:         "my-app",
+			PreviousVersion: "1.0.0",
+		},
+	}
+	workloadVersion := &apilifecycle.KeptnWorkloadVersion{
+		Spec: apilifecycle.KeptnWorkloadVersionSpec{
+			KeptnWorkloadSpec: apilifecycle.KeptnWorkloadSpec{
+				AppName: "my-app",
+				Version: "1.1.0",
+			},
+			WorkloadName:    "my-app-my-workload",
+			PreviousVersion: "1.0.0",
+		},
+	}
+	ctx := keptncontext.WithAppMetadata(context.TODO(), map[string]string{"env": "prod"})
+
+	variables := GetExpressionVariables(ctx, &interfaces.PhaseItemWrapper{Obj: appVersion}, apicommon.PreDeploymentCheckType)
+	require.Equal(t, expression.Variables{
+		AppName:         "my-app",
+		AppVersion:      "2.0.0",
+		Version:         "2.0.0",
+		PreviousVersion: "1.0.0",
+		ObjectType:      "App",
+		CheckType:       string(apicommon.PreDeploymentCheckType),
+		Metadata:        map[string]string{"env": "prod"},
+	}, variables)
+
+	variables = GetExpressionVariables(context.TODO(), &interfaces.PhaseItemWrapper{Obj: workloadVersion}, apicommon.PostDeploymentEvaluationCheckType)
+	require.Equal(t, expression.Variables{
+		AppName:         "my-app",
+		WorkloadName:    "my-app-my-workload",
+		WorkloadVersion: "1.1.0",
+		Version:         "1.1.0",
+		PreviousVersion: "1.0.0",
+		ObjectType:      "Workload",
+		CheckType:       string(apicommon.PostDeploymentEvaluationCheckType),
+	}, variables)
+}
+
 func Test_MergeMaps(t *testing.T) {
 	tests := []struct {
 		name string
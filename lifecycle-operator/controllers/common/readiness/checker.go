@@ -0,0 +1,73 @@
+package readiness
+
+import (
+	"context"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Checker determines whether a workload has been deployed
+type Checker interface {
+	// Check returns StateSucceeded if the given workload has been deployed,
+	// StateProgressing if its deployment is still ongoing, and StateFailed if its deployment failed.
+	Check(ctx context.Context, workload client.Object) (apicommon.KeptnState, error)
+}
+
+// NewChecker returns the Checker implementing the strategy of the given ReadinessCheck.
+// If no ReadinessCheck is given, a ReplicasChecker is returned.
+func NewChecker(k8sClient client.Reader, readinessCheck *apilifecycle.ReadinessCheck) (Checker, error) {
+	switch readinessCheck.GetStrategy() {
+	case apilifecycle.ReadinessStrategyReplicas:
+		return &ReplicasChecker{Client: k8sClient}, nil
+	case apilifecycle.ReadinessStrategyMinAvailability:
+		return &MinAvailabilityChecker{Percentage: readinessCheck.GetMinAvailablePercentage()}, nil
+	case apilifecycle.ReadinessStrategyProgressDeadline:
+		return &ProgressDeadlineChecker{Client: k8sClient}, nil
+	case apilifecycle.ReadinessStrategyPodReadiness:
+		return &PodReadinessChecker{Client: k8sClient, MaxRestarts: readinessCheck.GetMaxRestarts()}, nil
+	default:
+		return nil, controllererrors.ErrUnsupportedReadinessStrategy
+	}
+}
+
+// replicas contains the number of desired and available replicas of a workload and the selector of its pods
+type replicas struct {
+	desired   int32
+	available int32
+	selector  *metav1.LabelSelector
+}
+
+func getReplicas(workload client.Object) (replicas, error) {
+	switch w := workload.(type) {
+	case *appsv1.ReplicaSet:
+		return replicas{desired: getDesiredReplicas(w.Spec.Replicas), available: w.Status.AvailableReplicas, selector: w.Spec.Selector}, nil
+	case *appsv1.StatefulSet:
+		return replicas{desired: getDesiredReplicas(w.Spec.Replicas), available: w.Status.AvailableReplicas, selector: w.Spec.Selector}, nil
+	case *appsv1.DaemonSet:
+		return replicas{desired: w.Status.DesiredNumberScheduled, available: w.Status.NumberReady, selector: w.Spec.Selector}, nil
+	case *appsv1.Deployment:
+		return replicas{desired: getDesiredReplicas(w.Spec.Replicas), available: w.Status.AvailableReplicas, selector: w.Spec.Selector}, nil
+	default:
+		return replicas{}, controllererrors.ErrUnsupportedWorkloadVersionResourceReference
+	}
+}
+
+// getDesiredReplicas returns the number of desired replicas, which defaults to 1 if not set
+func getDesiredReplicas(desired *int32) int32 {
+	if desired == nil {
+		return 1
+	}
+	return *desired
+}
+
+func toState(ready bool) apicommon.KeptnState {
+	if ready {
+		return apicommon.StateSucceeded
+	}
+	return apicommon.StateProgressing
+}
@@ -0,0 +1,129 @@
+package readiness
+
+import (
+	"testing"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestNewChecker(t *testing.T) {
+	maxRestarts := int32(2)
+	tests := []struct {
+		name           string
+		readinessCheck *apilifecycle.ReadinessCheck
+		want           Checker
+		wantErr        error
+	}{
+		{
+			name: "default",
+			want: &ReplicasChecker{},
+		},
+		{
+			name:           "replicas",
+			readinessCheck: &apilifecycle.ReadinessCheck{Strategy: apilifecycle.ReadinessStrategyReplicas},
+			want:           &ReplicasChecker{},
+		},
+		{
+			name:           "minAvailability",
+			readinessCheck: &apilifecycle.ReadinessCheck{Strategy: apilifecycle.ReadinessStrategyMinAvailability, MinAvailablePercentage: 80},
+			want:           &MinAvailabilityChecker{Percentage: 80},
+		},
+		{
+			name:           "minAvailability without percentage",
+			readinessCheck: &apilifecycle.ReadinessCheck{Strategy: apilifecycle.ReadinessStrategyMinAvailability},
+			want:           &MinAvailabilityChecker{Percentage: 100},
+		},
+		{
+			name:           "progressDeadline",
+			readinessCheck: &apilifecycle.ReadinessCheck{Strategy: apilifecycle.ReadinessStrategyProgressDeadline},
+			want:           &ProgressDeadlineChecker{},
+		},
+		{
+			name:           "podReadiness",
+			readinessCheck: &apilifecycle.ReadinessCheck{Strategy: apilifecycle.ReadinessStrategyPodReadiness, MaxRestarts: &maxRestarts},
+			want:           &PodReadinessChecker{MaxRestarts: 2},
+		},
+		{
+			name:           "podReadiness with default restarts",
+			readinessCheck: &apilifecycle.ReadinessCheck{Strategy: apilifecycle.ReadinessStrategyPodReadiness},
+			want:           &PodReadinessChecker{MaxRestarts: 3},
+		},
+		{
+			name:           "unknown",
+			readinessCheck: &apilifecycle.ReadinessCheck{Strategy: "unknown"},
+			wantErr:        controllererrors.ErrUnsupportedReadinessStrategy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewChecker(nil, tt.readinessCheck)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_getReplicas(t *testing.T) {
+	desired := int32(3)
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}}
+
+	tests := []struct {
+		name     string
+		workload client.Object
+		want     replicas
+		wantErr  error
+	}{
+		{
+			name: "ReplicaSet",
+			workload: &appsv1.ReplicaSet{
+				Spec:   appsv1.ReplicaSetSpec{Replicas: &desired, Selector: selector},
+				Status: appsv1.ReplicaSetStatus{AvailableReplicas: 2},
+			},
+			want: replicas{desired: 3, available: 2, selector: selector},
+		},
+		{
+			name: "StatefulSet",
+			workload: &appsv1.StatefulSet{
+				Spec:   appsv1.StatefulSetSpec{Replicas: &desired, Selector: selector},
+				Status: appsv1.StatefulSetStatus{AvailableReplicas: 1},
+			},
+			want: replicas{desired: 3, available: 1, selector: selector},
+		},
+		{
+			name: "DaemonSet",
+			workload: &appsv1.DaemonSet{
+				Spec:   appsv1.DaemonSetSpec{Selector: selector},
+				Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 4, NumberReady: 3},
+			},
+			want: replicas{desired: 4, available: 3, selector: selector},
+		},
+		{
+			name: "Deployment without replicas",
+			workload: &appsv1.Deployment{
+				Spec:   appsv1.DeploymentSpec{Selector: selector},
+				Status: appsv1.DeploymentStatus{AvailableReplicas: 1},
+			},
+			want: replicas{desired: 1, available: 1, selector: selector},
+		},
+		{
+			name:     "unsupported",
+			workload: &batchv1.Job{},
+			wantErr:  controllererrors.ErrUnsupportedWorkloadVersionResourceReference,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getReplicas(tt.workload)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
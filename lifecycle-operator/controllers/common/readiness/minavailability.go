@@ -0,0 +1,21 @@
+package readiness
+
+import (
+	"context"
+
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MinAvailabilityChecker considers a workload deployed once the given percentage of its replicas is available
+type MinAvailabilityChecker struct {
+	Percentage int
+}
+
+func (c *MinAvailabilityChecker) Check(_ context.Context, workload client.Object) (apicommon.KeptnState, error) {
+	r, err := getReplicas(workload)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+	return toState(int64(r.available)*100 >= int64(c.Percentage)*int64(r.desired)), nil
+}
@@ -0,0 +1,58 @@
+package readiness
+
+import (
+	"context"
+	"testing"
+
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+func TestMinAvailabilityChecker_Check(t *testing.T) {
+	desired := int32(10)
+	tests := []struct {
+		name       string
+		percentage int
+		available  int32
+		want       apicommon.KeptnState
+	}{
+		{
+			name:       "all replicas available",
+			percentage: 100,
+			available:  10,
+			want:       apicommon.StateSucceeded,
+		},
+		{
+			name:       "enough replicas available",
+			percentage: 80,
+			available:  8,
+			want:       apicommon.StateSucceeded,
+		},
+		{
+			name:       "not enough replicas available",
+			percentage: 80,
+			available:  7,
+			want:       apicommon.StateProgressing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := &MinAvailabilityChecker{Percentage: tt.percentage}
+			got, err := checker.Check(context.TODO(), &appsv1.StatefulSet{
+				Spec:   appsv1.StatefulSetSpec{Replicas: &desired},
+				Status: appsv1.StatefulSetStatus{AvailableReplicas: tt.available},
+			})
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	checker := &MinAvailabilityChecker{Percentage: 100}
+	got, err := checker.Check(context.TODO(), &batchv1.Job{})
+	require.ErrorIs(t, err, controllererrors.ErrUnsupportedWorkloadVersionResourceReference)
+	require.Equal(t, apicommon.StateUnknown, got)
+}
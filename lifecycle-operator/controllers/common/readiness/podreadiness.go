@@ -0,0 +1,69 @@
+package readiness
+
+import (
+	"context"
+
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PodReadinessChecker considers a workload deployed once all of its pods are ready.
+// The deployment of the workload fails if a container of one of its pods restarted more than MaxRestarts times,
+// which detects crash-looping pods that are still reported as available.
+type PodReadinessChecker struct {
+	Client      client.Reader
+	MaxRestarts int32
+}
+
+func (c *PodReadinessChecker) Check(ctx context.Context, workload client.Object) (apicommon.KeptnState, error) {
+	r, err := getReplicas(workload)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(r.selector)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+
+	pods := &corev1.PodList{}
+	if err := c.Client.List(ctx, pods, client.InNamespace(workload.GetNamespace()), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return apicommon.StateUnknown, err
+	}
+
+	var total, ready int32
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if c.exceedsMaxRestarts(pod) {
+			return apicommon.StateFailed, nil
+		}
+		total++
+		if isPodReady(pod) {
+			ready++
+		}
+	}
+
+	return toState(total == r.desired && ready == r.desired), nil
+}
+
+func (c *PodReadinessChecker) exceedsMaxRestarts(pod corev1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.RestartCount > c.MaxRestarts {
+			return true
+		}
+	}
+	return false
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
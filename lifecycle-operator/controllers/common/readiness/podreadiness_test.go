@@ -0,0 +1,85 @@
+package readiness
+
+import (
+	"context"
+	"testing"
+
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPodReadinessChecker_Check(t *testing.T) {
+	desired := int32(2)
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-replicaset", Namespace: "default"},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: &desired,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}},
+		},
+		Status: appsv1.ReplicaSetStatus{AvailableReplicas: 2},
+	}
+	pod := func(name string, ready corev1.ConditionStatus, restarts int32) client.Object {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{"app": "my-app"},
+			},
+			Status: corev1.PodStatus{
+				Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "app", RestartCount: restarts}},
+			},
+		}
+	}
+	otherPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "other-app"},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "app", RestartCount: 10}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+		want    apicommon.KeptnState
+	}{
+		{
+			name:    "all pods ready",
+			objects: []client.Object{pod("pod-1", corev1.ConditionTrue, 0), pod("pod-2", corev1.ConditionTrue, 1), otherPod},
+			want:    apicommon.StateSucceeded,
+		},
+		{
+			name:    "pod not ready",
+			objects: []client.Object{pod("pod-1", corev1.ConditionTrue, 0), pod("pod-2", corev1.ConditionFalse, 0)},
+			want:    apicommon.StateProgressing,
+		},
+		{
+			name:    "pod missing",
+			objects: []client.Object{pod("pod-1", corev1.ConditionTrue, 0)},
+			want:    apicommon.StateProgressing,
+		},
+		{
+			name:    "crash-looping pod",
+			objects: []client.Object{pod("pod-1", corev1.ConditionTrue, 0), pod("pod-2", corev1.ConditionTrue, 4)},
+			want:    apicommon.StateFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := &PodReadinessChecker{Client: testcommon.NewTestClient(tt.objects...), MaxRestarts: 3}
+			got, err := checker.Check(context.TODO(), replicaSet)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
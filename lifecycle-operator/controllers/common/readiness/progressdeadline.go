@@ -0,0 +1,90 @@
+package readiness
+
+import (
+	"context"
+
+	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reasons of the Progressing condition of Deployments and Rollouts
+const (
+	newReplicaSetAvailableReason   = "NewReplicaSetAvailable"
+	progressDeadlineExceededReason = "ProgressDeadlineExceeded"
+)
+
+// ProgressDeadlineChecker uses the Progressing condition of the Deployment or Rollout managing a workload.
+// The workload is deployed once its new ReplicaSet is available,
+// and its deployment fails once the progress deadline of the Deployment or Rollout is exceeded.
+type ProgressDeadlineChecker struct {
+	Client client.Reader
+}
+
+// progressingCondition holds the fields of the Progressing condition that are common to Deployments and Rollouts
+type progressingCondition struct {
+	status corev1.ConditionStatus
+	reason string
+}
+
+func (c *ProgressDeadlineChecker) Check(ctx context.Context, workload client.Object) (apicommon.KeptnState, error) {
+	condition, err := c.getProgressingCondition(ctx, workload)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+	if condition == nil {
+		return apicommon.StateProgressing, nil
+	}
+	if condition.reason == progressDeadlineExceededReason {
+		return apicommon.StateFailed, nil
+	}
+	return toState(condition.status == corev1.ConditionTrue && condition.reason == newReplicaSetAvailableReason), nil
+}
+
+func (c *ProgressDeadlineChecker) getProgressingCondition(ctx context.Context, workload client.Object) (*progressingCondition, error) {
+	switch w := workload.(type) {
+	case *appsv1.Deployment:
+		return getDeploymentProgressingCondition(w), nil
+	case *appsv1.ReplicaSet:
+		for _, ownerRef := range w.OwnerReferences {
+			name := types.NamespacedName{Name: ownerRef.Name, Namespace: w.Namespace}
+			switch ownerRef.Kind {
+			case "Deployment":
+				deployment := &appsv1.Deployment{}
+				if err := c.Client.Get(ctx, name, deployment); err != nil {
+					return nil, err
+				}
+				return getDeploymentProgressingCondition(deployment), nil
+			case "Rollout":
+				rollout := &argov1alpha1.Rollout{}
+				if err := c.Client.Get(ctx, name, rollout); err != nil {
+					return nil, err
+				}
+				return getRolloutProgressingCondition(rollout), nil
+			}
+		}
+	}
+	return nil, controllererrors.ErrReadinessCheckNotSupported
+}
+
+func getDeploymentProgressingCondition(deployment *appsv1.Deployment) *progressingCondition {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing {
+			return &progressingCondition{status: condition.Status, reason: condition.Reason}
+		}
+	}
+	return nil
+}
+
+func getRolloutProgressingCondition(rollout *argov1alpha1.Rollout) *progressingCondition {
+	for _, condition := range rollout.Status.Conditions {
+		if condition.Type == argov1alpha1.RolloutProgressing {
+			return &progressingCondition{status: condition.Status, reason: condition.Reason}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package readiness
+
+import (
+	"context"
+	"testing"
+
+	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestProgressDeadlineChecker_Check(t *testing.T) {
+	deploymentWithCondition := func(status corev1.ConditionStatus, reason string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-deployment", Namespace: "default"},
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+					{Type: appsv1.DeploymentProgressing, Status: status, Reason: reason},
+				},
+			},
+		}
+	}
+	replicaSetOwnedBy := func(kind string, name string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "default",
+				OwnerReferences: []metav1.OwnerReference{{Kind: kind, Name: name}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		workload client.Object
+		objects  []client.Object
+		want     apicommon.KeptnState
+		wantErr  error
+	}{
+		{
+			name:     "Deployment finished progressing",
+			workload: deploymentWithCondition(corev1.ConditionTrue, newReplicaSetAvailableReason),
+			want:     apicommon.StateSucceeded,
+		},
+		{
+			name:     "Deployment still progressing",
+			workload: deploymentWithCondition(corev1.ConditionTrue, "ReplicaSetUpdated"),
+			want:     apicommon.StateProgressing,
+		},
+		{
+			name:     "Deployment exceeded progress deadline",
+			workload: deploymentWithCondition(corev1.ConditionFalse, progressDeadlineExceededReason),
+			want:     apicommon.StateFailed,
+		},
+		{
+			name:     "Deployment without Progressing condition",
+			workload: &appsv1.Deployment{},
+			want:     apicommon.StateProgressing,
+		},
+		{
+			name:     "ReplicaSet owned by Deployment",
+			workload: replicaSetOwnedBy("Deployment", "my-deployment"),
+			objects:  []client.Object{deploymentWithCondition(corev1.ConditionFalse, progressDeadlineExceededReason)},
+			want:     apicommon.StateFailed,
+		},
+		{
+			name:     "ReplicaSet owned by Rollout",
+			workload: replicaSetOwnedBy("Rollout", "my-rollout"),
+			objects: []client.Object{
+				&argov1alpha1.Rollout{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-rollout", Namespace: "default"},
+					Status: argov1alpha1.RolloutStatus{
+						Conditions: []argov1alpha1.RolloutCondition{
+							{Type: argov1alpha1.RolloutProgressing, Status: corev1.ConditionTrue, Reason: newReplicaSetAvailableReason},
+						},
+					},
+				},
+			},
+			want: apicommon.StateSucceeded,
+		},
+		{
+			name:     "ReplicaSet without owner",
+			workload: &appsv1.ReplicaSet{},
+			want:     apicommon.StateUnknown,
+			wantErr:  controllererrors.ErrReadinessCheckNotSupported,
+		},
+		{
+			name:     "StatefulSet",
+			workload: &appsv1.StatefulSet{},
+			want:     apicommon.StateUnknown,
+			wantErr:  controllererrors.ErrReadinessCheckNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := &ProgressDeadlineChecker{Client: testcommon.NewTestClient(tt.objects...)}
+			got, err := checker.Check(context.TODO(), tt.workload)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package readiness
+
+import (
+	"context"
+
+	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReplicasChecker considers a workload deployed once all of its replicas are available.
+// ReplicaSets managed by an Argo Rollout are deployed once the Rollout is healthy,
+// or once the Rollout is paused at one of its canary steps and the ReplicaSet is the fully available canary.
+type ReplicasChecker struct {
+	Client client.Reader
+}
+
+func (c *ReplicasChecker) Check(ctx context.Context, workload client.Object) (apicommon.KeptnState, error) {
+	switch w := workload.(type) {
+	case *appsv1.ReplicaSet:
+		for _, ownerRef := range w.OwnerReferences {
+			if ownerRef.Kind == "Rollout" {
+				return c.checkRollout(ctx, types.NamespacedName{Name: ownerRef.Name, Namespace: w.Namespace}, w)
+			}
+		}
+	case *appsv1.Deployment:
+		if w.Status.ObservedGeneration < w.Generation {
+			return apicommon.StateProgressing, nil
+		}
+		desired := getDesiredReplicas(w.Spec.Replicas)
+		return toState(desired == w.Status.UpdatedReplicas && desired == w.Status.AvailableReplicas), nil
+	}
+
+	r, err := getReplicas(workload)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+	return toState(r.desired == r.available), nil
+}
+
+func (c *ReplicasChecker) checkRollout(ctx context.Context, name types.NamespacedName, rep *appsv1.ReplicaSet) (apicommon.KeptnState, error) {
+	rollout := argov1alpha1.Rollout{}
+	err := c.Client.Get(ctx, name, &rollout)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+	if rollout.Status.Replicas == rollout.Status.UpdatedReplicas && rollout.Status.Phase == argov1alpha1.RolloutPhaseHealthy {
+		return apicommon.StateSucceeded, nil
+	}
+	return toState(isCanaryStepReached(rollout, rep)), nil
+}
+
+func isCanaryStepReached(rollout argov1alpha1.Rollout, rep *appsv1.ReplicaSet) bool {
+	canary := rollout.Spec.Strategy.Canary
+	if canary == nil || len(canary.Steps) == 0 || rollout.Status.Phase != argov1alpha1.RolloutPhasePaused {
+		return false
+	}
+	if rep.Labels[argov1alpha1.DefaultRolloutUniqueLabelKey] != rollout.Status.CurrentPodHash {
+		return false
+	}
+	return rep.Spec.Replicas != nil && *rep.Spec.Replicas > 0 && *rep.Spec.Replicas == rep.Status.AvailableReplicas
+}
@@ -0,0 +1,153 @@
+package readiness
+
+import (
+	"context"
+	"testing"
+
+	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReplicasChecker_Check(t *testing.T) {
+	desired := int32(2)
+	tests := []struct {
+		name     string
+		workload client.Object
+		objects  []client.Object
+		want     apicommon.KeptnState
+		wantErr  bool
+	}{
+		{
+			name: "ReplicaSet available",
+			workload: &appsv1.ReplicaSet{
+				Spec:   appsv1.ReplicaSetSpec{Replicas: &desired},
+				Status: appsv1.ReplicaSetStatus{AvailableReplicas: 2},
+			},
+			want: apicommon.StateSucceeded,
+		},
+		{
+			name: "ReplicaSet not available",
+			workload: &appsv1.ReplicaSet{
+				Spec:   appsv1.ReplicaSetSpec{Replicas: &desired},
+				Status: appsv1.ReplicaSetStatus{AvailableReplicas: 1},
+			},
+			want: apicommon.StateProgressing,
+		},
+		{
+			name: "StatefulSet available",
+			workload: &appsv1.StatefulSet{
+				Spec:   appsv1.StatefulSetSpec{Replicas: &desired},
+				Status: appsv1.StatefulSetStatus{AvailableReplicas: 2},
+			},
+			want: apicommon.StateSucceeded,
+		},
+		{
+			name: "DaemonSet not ready",
+			workload: &appsv1.DaemonSet{
+				Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, NumberReady: 1},
+			},
+			want: apicommon.StateProgressing,
+		},
+		{
+			name: "Deployment available",
+			workload: &appsv1.Deployment{
+				Spec:   appsv1.DeploymentSpec{Replicas: &desired},
+				Status: appsv1.DeploymentStatus{UpdatedReplicas: 2, AvailableReplicas: 2},
+			},
+			want: apicommon.StateSucceeded,
+		},
+		{
+			name: "Deployment not observed yet",
+			workload: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: &desired},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 2, AvailableReplicas: 2},
+			},
+			want: apicommon.StateProgressing,
+		},
+		{
+			name: "ReplicaSet of healthy Rollout",
+			workload: &appsv1.ReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "default",
+					OwnerReferences: []metav1.OwnerReference{{Kind: "Rollout", Name: "my-rollout"}},
+				},
+				Spec: appsv1.ReplicaSetSpec{Replicas: &desired},
+			},
+			objects: []client.Object{
+				&argov1alpha1.Rollout{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-rollout", Namespace: "default"},
+					Status: argov1alpha1.RolloutStatus{
+						Phase:           argov1alpha1.RolloutPhaseHealthy,
+						Replicas:        2,
+						UpdatedReplicas: 2,
+					},
+				},
+			},
+			want: apicommon.StateSucceeded,
+		},
+		{
+			name: "ReplicaSet of missing Rollout",
+			workload: &appsv1.ReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "default",
+					OwnerReferences: []metav1.OwnerReference{{Kind: "Rollout", Name: "my-rollout"}},
+				},
+				Spec: appsv1.ReplicaSetSpec{Replicas: &desired},
+			},
+			want:    apicommon.StateUnknown,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := &ReplicasChecker{Client: testcommon.NewTestClient(tt.objects...)}
+			got, err := checker.Check(context.TODO(), tt.workload)
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_isCanaryStepReached(t *testing.T) {
+	desired := int32(1)
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{argov1alpha1.DefaultRolloutUniqueLabelKey: "abc"},
+		},
+		Spec:   appsv1.ReplicaSetSpec{Replicas: &desired},
+		Status: appsv1.ReplicaSetStatus{AvailableReplicas: 1},
+	}
+	rollout := argov1alpha1.Rollout{
+		Spec: argov1alpha1.RolloutSpec{
+			Strategy: argov1alpha1.RolloutStrategy{
+				Canary: &argov1alpha1.CanaryStrategy{
+					Steps: []argov1alpha1.CanaryStep{{Pause: &argov1alpha1.RolloutPause{}}},
+				},
+			},
+		},
+		Status: argov1alpha1.RolloutStatus{
+			Phase:          argov1alpha1.RolloutPhasePaused,
+			CurrentPodHash: "abc",
+		},
+	}
+	require.True(t, isCanaryStepReached(rollout, replicaSet))
+
+	otherReplicaSet := replicaSet.DeepCopy()
+	otherReplicaSet.Labels[argov1alpha1.DefaultRolloutUniqueLabelKey] = "def"
+	require.False(t, isCanaryStepReached(rollout, otherReplicaSet))
+
+	unavailableReplicaSet := replicaSet.DeepCopy()
+	unavailableReplicaSet.Status.AvailableReplicas = 0
+	require.False(t, isCanaryStepReached(rollout, unavailableReplicaSet))
+
+	progressingRollout := *rollout.DeepCopy()
+	progressingRollout.Status.Phase = argov1alpha1.RolloutPhaseProgressing
+	require.False(t, isCanaryStepReached(progressingRollout, replicaSet))
+}
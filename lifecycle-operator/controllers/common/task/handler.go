@@ -2,12 +2,14 @@ package task
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/common/expression"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
 	keptncontext "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/context"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
@@ -39,6 +41,8 @@ type CreateTaskAttributes struct {
 	SpanName   string
 	Definition apilifecycle.KeptnTaskDefinition
 	CheckType  apicommon.CheckType
+	// Outputs contains the outputs of the KeptnTasks that already succeeded, indexed by their KeptnTaskDefinition
+	Outputs map[string]map[string]string
 }
 
 //nolint:gocognit,gocyclo
@@ -51,12 +55,14 @@ func (r Handler) ReconcileTasks(ctx context.Context, phaseCtx context.Context, r
 	phase := apicommon.PhaseReconcileTask
 
 	tasks, statuses := r.setupTasks(taskCreateAttributes, piWrapper)
+	variables := common.GetExpressionVariables(phaseCtx, piWrapper, taskCreateAttributes.CheckType)
 
 	var summary apicommon.StatusSummary
 	summary.Total = len(tasks)
 	// Check current state of the PrePostDeploymentTasks
 	var newStatus []apilifecycle.ItemStatus
-	for _, taskDefinitionName := range tasks {
+	for _, taskRef := range tasks {
+		taskDefinitionName := taskRef.Name
 		oldstatus := common.GetOldStatus(taskDefinitionName, statuses)
 
 		taskStatus := common.GetItemStatus(taskDefinitionName, statuses)
@@ -67,8 +73,8 @@ func (r Handler) ReconcileTasks(ctx context.Context, phaseCtx context.Context, r
 			r.EventSender.Emit(phase, "Normal", reconcileObject, apicommon.PhaseStateStatusChanged, fmt.Sprintf("task status changed from %s to %s", oldstatus, taskStatus.Status), piWrapper.GetVersion())
 		}
 
-		// Check if task has already succeeded or failed
-		if taskStatus.Status == apicommon.StateSucceeded || taskStatus.Status == apicommon.StateFailed {
+		// Check if task has already succeeded, failed or has been skipped
+		if taskStatus.Status == apicommon.StateSucceeded || taskStatus.Status == apicommon.StateFailed || taskStatus.Status == apicommon.StateSkipped {
 			newStatus = append(newStatus, taskStatus)
 			continue
 		}
@@ -84,8 +90,43 @@ func (r Handler) ReconcileTasks(ctx context.Context, phaseCtx context.Context, r
 			taskExists = true
 		}
 
+		// Wait for the tasks this task depends on before creating it
+		if !taskExists && len(taskRef.DependsOn) > 0 {
+			dependencyState := getDependencyState(taskRef, tasks, newStatus, statuses)
+			if !dependencyState.IsSucceeded() {
+				if dependencyState.IsFailed() {
+					taskStatus.Status = apicommon.StateSkipped
+					r.EventSender.Emit(phase, "Warning", reconcileObject, apicommon.PhaseStateStatusChanged, fmt.Sprintf("task %s skipped as one of its dependencies did not succeed", taskDefinitionName), piWrapper.GetVersion())
+				}
+				newStatus = append(newStatus, taskStatus)
+				continue
+			}
+		}
+
+		// Evaluate the condition of the task before creating it
+		if !taskExists && taskRef.When != "" {
+			conditionMet, err := expression.Evaluate(taskRef.When, variables)
+			if err != nil {
+				r.Log.Error(err, "Could not evaluate condition of task",
+					"taskDefinition", taskDefinitionName,
+					"namespace", piWrapper.GetNamespace(),
+				)
+				taskStatus.Status = apicommon.StateFailed
+				r.EventSender.Emit(phase, "Warning", reconcileObject, apicommon.PhaseStateFailed, fmt.Sprintf("could not evaluate condition of task %s: %s", taskDefinitionName, err.Error()), piWrapper.GetVersion())
+				newStatus = append(newStatus, taskStatus)
+				continue
+			}
+			if !conditionMet {
+				taskStatus.Status = apicommon.StateSkipped
+				r.EventSender.Emit(phase, "Normal", reconcileObject, apicommon.PhaseStateStatusChanged, fmt.Sprintf("task %s skipped as its condition is not met", taskDefinitionName), piWrapper.GetVersion())
+				newStatus = append(newStatus, taskStatus)
+				continue
+			}
+		}
+
 		// Create new Task if it does not exist
 		if !taskExists {
+			taskCreateAttributes.Outputs = r.getTaskOutputs(ctx, piWrapper, newStatus)
 			err := r.handleTaskNotExists(
 				ctx,
 				phaseCtx,
@@ -137,7 +178,9 @@ func (r Handler) CreateKeptnTask(ctx, phaseCtx context.Context, namespace string
 	phase := apicommon.PhaseCreateTask
 
 	newTask := piWrapper.GenerateTask(taskCreateAttributes.Definition, taskCreateAttributes.CheckType)
+	newTask.Spec.TaskDefinition = common.GetTaskDefinitionRef(taskCreateAttributes.Definition, namespace)
 	injectKeptnContext(phaseCtx, &newTask)
+	newTask.Spec.Context.Outputs = taskCreateAttributes.Outputs
 	err = controllerutil.SetControllerReference(reconcileObject, &newTask, r.Scheme)
 	if err != nil {
 		r.Log.Error(err, "could not set controller reference:")
@@ -164,31 +207,108 @@ func injectKeptnContext(phaseCtx context.Context, newTask *apilifecycle.KeptnTas
 	}
 }
 
-func (r Handler) setTaskFailureEvents(task *apilifecycle.KeptnTask, spanTrace trace.Span) {
+// getTaskOutputs collects the outputs of all KeptnTasks of the phase item that succeeded so far,
+// including the ones that succeeded in the current reconciliation
+func (r Handler) getTaskOutputs(ctx context.Context, piWrapper *interfaces.PhaseItemWrapper, currentStatus []apilifecycle.ItemStatus) map[string]map[string]string {
+	statuses := append([]apilifecycle.ItemStatus{}, currentStatus...)
+	statuses = append(statuses, piWrapper.GetPreDeploymentTaskStatus()...)
+	statuses = append(statuses, piWrapper.GetPostDeploymentTaskStatus()...)
+	statuses = append(statuses, piWrapper.GetPromotionTaskStatus()...)
+
+	outputs := map[string]map[string]string{}
+	checked := map[string]bool{}
+	for _, status := range statuses {
+		if status.Name == "" || checked[status.Name] || !status.Status.IsSucceeded() {
+			continue
+		}
+		checked[status.Name] = true
+		task := &apilifecycle.KeptnTask{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: status.Name, Namespace: piWrapper.GetNamespace()}, task); err != nil {
+			r.Log.Error(err, "could not retrieve outputs of KeptnTask", "task", status.Name)
+			continue
+		}
+		if len(task.Status.Outputs) > 0 {
+			outputs[task.Spec.TaskDefinition] = task.Status.Outputs
+		}
+	}
+	if len(outputs) == 0 {
+		return nil
+	}
+	return outputs
+}
+
+func (r Handler) setTaskFailureEvents(phaseCtx context.Context, task *apilifecycle.KeptnTask, spanTrace trace.Span) {
 	spanTrace.AddEvent(fmt.Sprintf("task '%s' failed with reason: '%s'", task.Name, task.Status.Message), trace.WithTimestamp(time.Now().UTC()))
+	if task.Status.TerminationReason == "" && task.Status.Logs == "" {
+		return
+	}
+	// attach the termination reason and logs of the failed container to the span of the phase item
+	trace.SpanFromContext(phaseCtx).AddEvent(
+		fmt.Sprintf("task '%s' terminated with reason: '%s'", task.Name, task.Status.TerminationReason),
+		trace.WithTimestamp(time.Now().UTC()),
+		trace.WithAttributes(
+			apicommon.TaskName.String(task.Name),
+			apicommon.TaskTerminationReason.String(task.Status.TerminationReason),
+			apicommon.TaskLogs.String(task.Status.Logs),
+		),
+	)
 }
 
-func (r Handler) setupTasks(taskCreateAttributes CreateTaskAttributes, piWrapper *interfaces.PhaseItemWrapper) ([]string, []apilifecycle.ItemStatus) {
-	var tasks []string
+func (r Handler) setupTasks(taskCreateAttributes CreateTaskAttributes, piWrapper *interfaces.PhaseItemWrapper) ([]apilifecycle.TaskReference, []apilifecycle.ItemStatus) {
 	var statuses []apilifecycle.ItemStatus
 
 	switch taskCreateAttributes.CheckType {
 	case apicommon.PreDeploymentCheckType:
-		tasks = piWrapper.GetPreDeploymentTasks()
 		statuses = piWrapper.GetPreDeploymentTaskStatus()
 	case apicommon.PostDeploymentCheckType:
-		tasks = piWrapper.GetPostDeploymentTasks()
 		statuses = piWrapper.GetPostDeploymentTaskStatus()
 	case apicommon.PromotionCheckType:
-		tasks = piWrapper.GetPromotionTasks()
 		statuses = piWrapper.GetPromotionTaskStatus()
 	}
-	return tasks, statuses
+	return piWrapper.GetTaskReferences(taskCreateAttributes.CheckType), statuses
+}
+
+// getDependencyState returns StateSucceeded if all dependencies of the given task succeeded,
+// StateFailed if at least one of them did not succeed, and StatePending otherwise.
+// The current state of the dependencies is taken from the statuses computed in the current reconciliation,
+// falling back to the statuses of the previous reconciliation
+func getDependencyState(taskRef apilifecycle.TaskReference, tasks []apilifecycle.TaskReference, newStatus []apilifecycle.ItemStatus, oldStatus []apilifecycle.ItemStatus) apicommon.KeptnState {
+	state := apicommon.StateSucceeded
+	statuses := make([]apilifecycle.ItemStatus, 0, len(newStatus)+len(oldStatus))
+	statuses = append(append(statuses, newStatus...), oldStatus...)
+	for _, dependency := range taskRef.DependsOn {
+		if !isTaskOfPhase(dependency, tasks) {
+			// dependencies outside the phase can never be fulfilled
+			return apicommon.StateFailed
+		}
+		dependencyStatus := common.GetItemStatus(dependency, statuses)
+		switch dependencyStatus.Status {
+		case apicommon.StateSucceeded:
+			continue
+		case apicommon.StateFailed, apicommon.StateSkipped, apicommon.StateDeprecated:
+			return apicommon.StateFailed
+		default:
+			state = apicommon.StatePending
+		}
+	}
+	return state
+}
+
+func isTaskOfPhase(name string, tasks []apilifecycle.TaskReference) bool {
+	for _, task := range tasks {
+		if task.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 func (r Handler) handleTaskNotExists(ctx context.Context, phaseCtx context.Context, taskCreateAttributes CreateTaskAttributes, taskName string, piWrapper *interfaces.PhaseItemWrapper, reconcileObject client.Object, task *apilifecycle.KeptnTask, taskStatus *apilifecycle.ItemStatus) error {
 	definition, err := common.GetTaskDefinition(r.Client, r.Log, ctx, taskName, piWrapper.GetNamespace())
 	if err != nil {
+		if goerrors.Is(err, controllererrors.ErrTaskDefinitionAccessDenied) {
+			r.EventSender.Emit(apicommon.PhaseCreateTask, "Warning", reconcileObject, apicommon.PhaseStateFailed, err.Error(), piWrapper.GetVersion())
+		}
 		return controllererrors.ErrCannotGetKeptnTaskDefinition
 	}
 	taskCreateAttributes.Definition = *definition
@@ -219,7 +339,7 @@ func (r Handler) handleTaskExists(phaseCtx context.Context, task *apilifecycle.K
 			spanTaskTrace.SetStatus(codes.Ok, "Finished")
 		} else {
 			spanTaskTrace.AddEvent(task.Name + " has failed")
-			r.setTaskFailureEvents(task, spanTaskTrace)
+			r.setTaskFailureEvents(phaseCtx, task, spanTaskTrace)
 			spanTaskTrace.SetStatus(codes.Error, "Failed")
 		}
 		spanTaskTrace.End()
@@ -15,13 +15,16 @@ import (
 	telemetryfake "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/telemetry/fake"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
 	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -430,6 +433,231 @@ func TestTaskHandler(t *testing.T) {
 			getSpanCalls:    1,
 			unbindSpanCalls: 1,
 		},
+		{
+			name: "task waits for its dependency",
+			object: &apilifecycle.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: apilifecycle.KeptnAppVersionSpec{
+					KeptnAppContextSpec: apilifecycle.KeptnAppContextSpec{
+						DeploymentTaskSpec: apilifecycle.DeploymentTaskSpec{
+							PreDeploymentTasks: []string{"task-def"},
+							PreDeploymentTaskRefs: []apilifecycle.TaskReference{
+								{Name: "other-task-def", DependsOn: []string{"task-def"}},
+							},
+						},
+					},
+				},
+				Status: apilifecycle.KeptnAppVersionStatus{
+					PreDeploymentTaskStatus: []apilifecycle.ItemStatus{
+						{
+							DefinitionName: "task-def",
+							Status:         apicommon.StateProgressing,
+							Name:           "pre-task-def-",
+						},
+					},
+				},
+			},
+			taskObj: apilifecycle.KeptnTask{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "pre-task-def-",
+				},
+				Status: apilifecycle.KeptnTaskStatus{
+					Status: apicommon.StateProgressing,
+				},
+			},
+			createAttr: CreateTaskAttributes{
+				CheckType: apicommon.PreDeploymentCheckType,
+			},
+			wantStatus: []apilifecycle.ItemStatus{
+				{
+					DefinitionName: "task-def",
+					Status:         apicommon.StateProgressing,
+					Name:           "pre-task-def-",
+				},
+				{
+					DefinitionName: "other-task-def",
+					Status:         apicommon.StatePending,
+					Name:           "",
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 2, Progressing: 1, Pending: 1},
+			wantErr:         nil,
+			getSpanCalls:    1,
+			unbindSpanCalls: 0,
+		},
+		{
+			name: "task is created after its dependency succeeded",
+			object: &apilifecycle.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: apilifecycle.KeptnAppVersionSpec{
+					KeptnAppContextSpec: apilifecycle.KeptnAppContextSpec{
+						DeploymentTaskSpec: apilifecycle.DeploymentTaskSpec{
+							PreDeploymentTaskRefs: []apilifecycle.TaskReference{
+								{Name: "other-task-def", DependsOn: []string{"task-def"}},
+								{Name: "task-def"},
+							},
+						},
+					},
+				},
+				Status: apilifecycle.KeptnAppVersionStatus{
+					PreDeploymentTaskStatus: []apilifecycle.ItemStatus{
+						{
+							DefinitionName: "task-def",
+							Status:         apicommon.StateSucceeded,
+							Name:           "pre-task-def-",
+						},
+					},
+				},
+			},
+			taskDef: &apilifecycle.KeptnTaskDefinition{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: testcommon.KeptnNamespace,
+					Name:      "other-task-def",
+				},
+			},
+			taskObj: apilifecycle.KeptnTask{},
+			createAttr: CreateTaskAttributes{
+				CheckType: apicommon.PreDeploymentCheckType,
+			},
+			wantStatus: []apilifecycle.ItemStatus{
+				{
+					DefinitionName: "other-task-def",
+					Status:         apicommon.StatePending,
+					Name:           "pre-other-task-def-",
+				},
+				{
+					DefinitionName: "task-def",
+					Status:         apicommon.StateSucceeded,
+					Name:           "pre-task-def-",
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 2, Succeeded: 1, Pending: 1},
+			wantErr:         nil,
+			getSpanCalls:    1,
+			unbindSpanCalls: 0,
+		},
+		{
+			name: "task is skipped if its dependency failed",
+			object: &apilifecycle.KeptnWorkloadVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: apilifecycle.KeptnWorkloadVersionSpec{
+					KeptnWorkloadSpec: apilifecycle.KeptnWorkloadSpec{
+						PostDeploymentTaskRefs: []apilifecycle.TaskReference{
+							{Name: "task-def"},
+							{Name: "other-task-def", DependsOn: []string{"task-def"}},
+							{Name: "third-task-def", DependsOn: []string{"other-task-def"}},
+						},
+					},
+				},
+				Status: apilifecycle.KeptnWorkloadVersionStatus{
+					PostDeploymentTaskStatus: []apilifecycle.ItemStatus{
+						{
+							DefinitionName: "task-def",
+							Status:         apicommon.StateFailed,
+							Name:           "post-task-def-",
+						},
+					},
+				},
+			},
+			taskObj: apilifecycle.KeptnTask{},
+			createAttr: CreateTaskAttributes{
+				CheckType: apicommon.PostDeploymentCheckType,
+			},
+			wantStatus: []apilifecycle.ItemStatus{
+				{
+					DefinitionName: "task-def",
+					Status:         apicommon.StateFailed,
+					Name:           "post-task-def-",
+				},
+				{
+					DefinitionName: "other-task-def",
+					Status:         apicommon.StateSkipped,
+					Name:           "",
+				},
+				{
+					DefinitionName: "third-task-def",
+					Status:         apicommon.StateSkipped,
+					Name:           "",
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 3, Failed: 1, Skipped: 2},
+			wantErr:         nil,
+			getSpanCalls:    0,
+			unbindSpanCalls: 0,
+		},
+		{
+			name: "tasks are created or skipped depending on their condition",
+			object: &apilifecycle.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: apilifecycle.KeptnAppVersionSpec{
+					KeptnAppSpec: apilifecycle.KeptnAppSpec{
+						Version: "2.0.0",
+					},
+					KeptnAppContextSpec: apilifecycle.KeptnAppContextSpec{
+						DeploymentTaskSpec: apilifecycle.DeploymentTaskSpec{
+							PreDeploymentTaskRefs: []apilifecycle.TaskReference{
+								{Name: "task-def", When: "majorVersion(version) > majorVersion(previousVersion)"},
+								{Name: "other-task-def", When: "objectType == 'Workload'"},
+								{Name: "third-task-def", When: "majorVersion(unknown) > 1"},
+								{Name: "fourth-task-def", When: `metadata["region"] == "eu"`},
+								{Name: "fifth-task-def", When: `has(metadata.region) && metadata.region == "eu"`},
+							},
+						},
+					},
+					PreviousVersion: "1.0.0",
+				},
+			},
+			taskDef: &apilifecycle.KeptnTaskDefinition{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: testcommon.KeptnNamespace,
+					Name:      "task-def",
+				},
+			},
+			taskObj: apilifecycle.KeptnTask{},
+			createAttr: CreateTaskAttributes{
+				CheckType: apicommon.PreDeploymentCheckType,
+			},
+			wantStatus: []apilifecycle.ItemStatus{
+				{
+					DefinitionName: "task-def",
+					Status:         apicommon.StatePending,
+					Name:           "pre-task-def-",
+				},
+				{
+					DefinitionName: "other-task-def",
+					Status:         apicommon.StateSkipped,
+					Name:           "",
+				},
+				{
+					DefinitionName: "third-task-def",
+					Status:         apicommon.StateFailed,
+					Name:           "",
+				},
+				{
+					DefinitionName: "fourth-task-def",
+					Status:         apicommon.StateFailed,
+					Name:           "",
+				},
+				{
+					DefinitionName: "fifth-task-def",
+					Status:         apicommon.StateSkipped,
+					Name:           "",
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 5, Pending: 1, Skipped: 2, Failed: 2},
+			wantErr:         nil,
+			getSpanCalls:    1,
+			unbindSpanCalls: 0,
+		},
 	}
 	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
 
@@ -477,11 +705,12 @@ func TestTaskHandler(t *testing.T) {
 
 func TestTaskHandler_createTask(t *testing.T) {
 	tests := []struct {
-		name       string
-		object     client.Object
-		createAttr CreateTaskAttributes
-		wantName   string
-		wantErr    error
+		name               string
+		object             client.Object
+		createAttr         CreateTaskAttributes
+		wantName           string
+		wantTaskDefinition string
+		wantErr            error
 	}{
 		{
 			name:       "cannot unwrap object",
@@ -513,8 +742,29 @@ func TestTaskHandler_createTask(t *testing.T) {
 					},
 				},
 			},
-			wantName: "pre-task-def-",
-			wantErr:  nil,
+			wantName:           "pre-task-def-",
+			wantTaskDefinition: "task-def",
+			wantErr:            nil,
+		},
+		{
+			name: "created task from task definition library",
+			object: &apilifecycle.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+			},
+			createAttr: CreateTaskAttributes{
+				CheckType: apicommon.PreDeploymentCheckType,
+				Definition: apilifecycle.KeptnTaskDefinition{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "task-def",
+						Namespace: "platform-tasks",
+					},
+				},
+			},
+			wantName:           "pre-task-def-",
+			wantTaskDefinition: "platform-tasks/task-def",
+			wantErr:            nil,
 		},
 	}
 
@@ -538,6 +788,13 @@ func TestTaskHandler_createTask(t *testing.T) {
 
 			require.True(t, strings.Contains(name, tt.wantName))
 			require.Equal(t, tt.wantErr, err)
+
+			if tt.wantTaskDefinition != "" {
+				task := &apilifecycle.KeptnTask{}
+				err = handler.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "namespace"}, task)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantTaskDefinition, task.Spec.TaskDefinition)
+			}
 		})
 	}
 }
@@ -571,3 +828,102 @@ func Test_injectKeptnContext(t *testing.T) {
 	)
 
 }
+
+func TestTaskHandler_getTaskOutputs(t *testing.T) {
+	makeTask := func(name, definition string, outputs map[string]string) *apilifecycle.KeptnTask {
+		return &apilifecycle.KeptnTask{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      name,
+				Namespace: "namespace",
+			},
+			Spec: apilifecycle.KeptnTaskSpec{
+				TaskDefinition: definition,
+			},
+			Status: apilifecycle.KeptnTaskStatus{
+				Outputs: outputs,
+			},
+		}
+	}
+
+	workloadVersion := &apilifecycle.KeptnWorkloadVersion{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "workload",
+			Namespace: "namespace",
+		},
+		Status: apilifecycle.KeptnWorkloadVersionStatus{
+			PreDeploymentTaskStatus: []apilifecycle.ItemStatus{
+				{DefinitionName: "build", Name: "pre-build", Status: apicommon.StateSucceeded},
+				{DefinitionName: "failing", Name: "pre-failing", Status: apicommon.StateFailed},
+				{DefinitionName: "smoke-test", Name: "pre-smoke-test", Status: apicommon.StateProgressing},
+			},
+		},
+	}
+
+	fakeClient := testcommon.NewTestClient(
+		workloadVersion,
+		makeTask("pre-build", "build", map[string]string{"buildId": "1234"}),
+		makeTask("pre-failing", "failing", map[string]string{"result": "failed"}),
+		makeTask("pre-smoke-test", "smoke-test", map[string]string{"score": "0.9"}),
+	)
+
+	handler := Handler{
+		Log:    ctrl.Log.WithName("controller"),
+		Client: fakeClient,
+	}
+
+	piWrapper, err := interfaces.NewPhaseItemWrapperFromClientObject(workloadVersion)
+	require.Nil(t, err)
+
+	// the smoke test succeeded in the current reconciliation only
+	outputs := handler.getTaskOutputs(context.TODO(), piWrapper, []apilifecycle.ItemStatus{
+		{DefinitionName: "smoke-test", Name: "pre-smoke-test", Status: apicommon.StateSucceeded},
+	})
+
+	require.Equal(t, map[string]map[string]string{
+		"build":      {"buildId": "1234"},
+		"smoke-test": {"score": "0.9"},
+	}, outputs)
+
+	// no outputs if no task succeeded
+	piWrapper, err = interfaces.NewPhaseItemWrapperFromClientObject(&apilifecycle.KeptnWorkloadVersion{})
+	require.Nil(t, err)
+	outputs = handler.getTaskOutputs(context.TODO(), piWrapper, nil)
+	require.Nil(t, outputs)
+}
+
+func TestTaskHandler_setTaskFailureEvents(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tp.Tracer("tracer")
+
+	phaseCtx, phaseSpan := tracer.Start(context.TODO(), "phase")
+	_, taskSpan := tracer.Start(phaseCtx, "task")
+
+	task := &apilifecycle.KeptnTask{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "my-task",
+		},
+		Status: apilifecycle.KeptnTaskStatus{
+			Message:           "Job has reached the specified backoff limit",
+			TerminationReason: "Error",
+			Logs:              "connection refused",
+		},
+	}
+
+	Handler{}.setTaskFailureEvents(phaseCtx, task, taskSpan)
+	taskSpan.End()
+	phaseSpan.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	taskEvents := spans[0].Events()
+	require.Len(t, taskEvents, 1)
+	require.Equal(t, "task 'my-task' failed with reason: 'Job has reached the specified backoff limit'", taskEvents[0].Name)
+
+	phaseEvents := spans[1].Events()
+	require.Len(t, phaseEvents, 1)
+	require.Equal(t, "task 'my-task' terminated with reason: 'Error'", phaseEvents[0].Name)
+	require.Contains(t, phaseEvents[0].Attributes, apicommon.TaskLogs.String("connection refused"))
+	require.Contains(t, phaseEvents[0].Attributes, apicommon.TaskTerminationReason.String("Error"))
+}
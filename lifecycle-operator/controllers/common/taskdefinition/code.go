@@ -0,0 +1,165 @@
+package taskdefinition
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxCodeSize limits the size of function code that is fetched from an HTTP reference
+const maxCodeSize = 1 << 20
+
+const fetchTimeout = 30 * time.Second
+
+// FetchCode downloads the function code from the given URL.
+// The URL, including the URLs it redirects to, has to match the given sources,
+// otherwise ErrFunctionCodeSourceNotAllowed is returned.
+func FetchCode(ctx context.Context, codeURL string, sources config.FunctionCodeSources) ([]byte, error) {
+	u, err := url.Parse(codeURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSource(u, sources); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, codeURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := newHTTPClient(sources).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch function code from %s: unexpected status code %d", codeURL, resp.StatusCode)
+	}
+	code, err := io.ReadAll(io.LimitReader(resp.Body, maxCodeSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(code) > maxCodeSize {
+		return nil, fmt.Errorf("could not fetch function code from %s: code exceeds %d bytes", codeURL, maxCodeSize)
+	}
+	return code, nil
+}
+
+// newHTTPClient returns a client that only follows redirects to allowed sources and,
+// unless private addresses are allowed, only connects to public addresses.
+// The addresses are checked after the host name is resolved, so that a host name cannot be
+// pointed to an internal address after the URL has been checked.
+func newHTTPClient(sources config.FunctionCodeSources) *http.Client {
+	dialer := &net.Dialer{Timeout: fetchTimeout}
+	if !sources.AllowPrivateAddresses {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isPrivateAddress(ip) {
+				return fmt.Errorf("%w: address %s is not public", controllererrors.ErrFunctionCodeSourceNotAllowed, host)
+			}
+			return nil
+		}
+	}
+	return &http.Client{
+		Timeout: fetchTimeout,
+		// the proxy of the environment is not used, since the addresses could not be checked otherwise
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: fetchTimeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return fmt.Errorf("stopped after 10 redirects")
+			}
+			return checkSource(req.URL, sources)
+		},
+	}
+}
+
+// checkSource returns ErrFunctionCodeSourceNotAllowed if the scheme or host of the given URL is not allowed
+func checkSource(u *url.URL, sources config.FunctionCodeSources) error {
+	if !slices.Contains(sources.Schemes, strings.ToLower(u.Scheme)) {
+		return fmt.Errorf("%w: scheme %q is not one of %v", controllererrors.ErrFunctionCodeSourceNotAllowed, u.Scheme, sources.Schemes)
+	}
+	if len(sources.Hosts) == 0 {
+		return nil
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range sources.Hosts {
+		allowed = strings.ToLower(allowed)
+		if host == allowed || (strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:])) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: host %q is not one of %v", controllererrors.ErrFunctionCodeSourceNotAllowed, host, sources.Hosts)
+}
+
+func isPrivateAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified()
+}
+
+// Checksum returns the hex encoded SHA-256 checksum of the given code
+func Checksum(code []byte) string {
+	sum := sha256.Sum256(code)
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyChecksum returns ErrChecksumMismatch if the SHA-256 checksum of the given code does not match the expected one
+func VerifyChecksum(code []byte, expected string) error {
+	if actual := Checksum(code); actual != strings.ToLower(expected) {
+		return fmt.Errorf("%w: expected sha256 %s, got %s", controllererrors.ErrChecksumMismatch, strings.ToLower(expected), actual)
+	}
+	return nil
+}
+
+// IsCacheValid checks whether the given ConfigMap contains the code of the given HTTP reference
+func IsCacheValid(cm *corev1.ConfigMap, ref apilifecycle.HttpReference) bool {
+	if cm.Annotations[apicommon.SourceURLAnnotation] != ref.Url {
+		return false
+	}
+	return ref.Sha256 == "" || cm.Annotations[apicommon.SourceDigestAnnotation] == "sha256:"+strings.ToLower(ref.Sha256)
+}
+
+// VerifyCachedCode checks that the ConfigMap referenced in the status of the given KeptnTaskDefinition
+// contains the code of its HTTP reference before the code is executed.
+// It returns ErrFunctionCodeNotCached if the code could not be cached for the current URL and checksum,
+// and ErrChecksumMismatch if the cached code has been modified.
+// KeptnTaskDefinitions that do not cache their code are not checked.
+func VerifyCachedCode(ctx context.Context, reader client.Reader, definition *apilifecycle.KeptnTaskDefinition) error {
+	spec := GetRuntimeSpec(definition)
+	if !IsCached(spec) {
+		return nil
+	}
+	if definition.Status.Function.ConfigMap == "" {
+		return fmt.Errorf("%w: code of %s has not been fetched", controllererrors.ErrFunctionCodeNotCached, spec.HttpReference.Url)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: definition.Status.Function.ConfigMap, Namespace: definition.Namespace}, cm); err != nil {
+		return fmt.Errorf(controllererrors.ErrCannotGetFunctionConfigMap, err)
+	}
+	if !IsCacheValid(cm, spec.HttpReference) {
+		return fmt.Errorf("%w: ConfigMap %s does not contain the code of %s", controllererrors.ErrFunctionCodeNotCached, cm.Name, spec.HttpReference.Url)
+	}
+	return VerifyChecksum([]byte(cm.Data["code"]), strings.TrimPrefix(cm.Annotations[apicommon.SourceDigestAnnotation], "sha256:"))
+}
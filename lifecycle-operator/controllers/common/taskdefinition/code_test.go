@@ -0,0 +1,205 @@
+package taskdefinition
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFetchCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/function.ts" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("console.log('hello');"))
+	}))
+	defer server.Close()
+	// the test server listens on a loopback address
+	sources := config.FunctionCodeSources{Schemes: []string{"http"}, AllowPrivateAddresses: true}
+
+	code, err := FetchCode(context.TODO(), server.URL+"/function.ts", sources)
+	require.Nil(t, err)
+	require.Equal(t, "console.log('hello');", string(code))
+
+	_, err = FetchCode(context.TODO(), server.URL+"/missing.ts", sources)
+	require.ErrorContains(t, err, "unexpected status code 404")
+}
+
+func TestFetchCode_SourceNotAllowed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "http://metadata.internal/function.ts", http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte("console.log('hello');"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		sources config.FunctionCodeSources
+		wantErr string
+	}{
+		{
+			name:    "scheme not allowed",
+			url:     server.URL + "/function.ts",
+			sources: config.FunctionCodeSources{Schemes: []string{"https"}, AllowPrivateAddresses: true},
+			wantErr: `scheme "http" is not one of [https]`,
+		},
+		{
+			name:    "host not allowed",
+			url:     server.URL + "/function.ts",
+			sources: config.FunctionCodeSources{Schemes: []string{"http"}, Hosts: []string{"*.example.com"}, AllowPrivateAddresses: true},
+			wantErr: `host "127.0.0.1" is not one of [*.example.com]`,
+		},
+		{
+			name:    "private address",
+			url:     server.URL + "/function.ts",
+			sources: config.FunctionCodeSources{Schemes: []string{"http"}},
+			wantErr: "address 127.0.0.1 is not public",
+		},
+		{
+			name:    "redirect to host not allowed",
+			url:     server.URL + "/redirect",
+			sources: config.FunctionCodeSources{Schemes: []string{"http"}, Hosts: []string{"127.0.0.1"}, AllowPrivateAddresses: true},
+			wantErr: `host "metadata.internal" is not one of [127.0.0.1]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FetchCode(context.TODO(), tt.url, tt.sources)
+			require.ErrorIs(t, err, controllererrors.ErrFunctionCodeSourceNotAllowed)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_checkSource(t *testing.T) {
+	sources := config.FunctionCodeSources{
+		Schemes: []string{"https"},
+		Hosts:   []string{"functions.keptn.sh", "*.example.com"},
+	}
+
+	tests := []struct {
+		url     string
+		allowed bool
+	}{
+		{url: "https://functions.keptn.sh/keptn/function.ts", allowed: true},
+		{url: "https://functions.example.com/function.ts", allowed: true},
+		{url: "HTTPS://Functions.Example.com/function.ts", allowed: true},
+		{url: "https://example.com/function.ts", allowed: false},
+		{url: "https://other-example.com/function.ts", allowed: false},
+		{url: "https://functions.example.com.attacker.io/function.ts", allowed: false},
+		{url: "http://functions.keptn.sh/keptn/function.ts", allowed: false},
+		{url: "file:///etc/passwd", allowed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			require.Nil(t, err)
+			require.Equal(t, tt.allowed, checkSource(u, sources) == nil)
+		})
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	code := []byte("console.log('hello');")
+	checksum := Checksum(code)
+
+	require.Len(t, checksum, 64)
+	require.Nil(t, VerifyChecksum(code, checksum))
+
+	err := VerifyChecksum([]byte("console.log('changed');"), checksum)
+	require.ErrorIs(t, err, controllererrors.ErrChecksumMismatch)
+	require.ErrorContains(t, err, checksum)
+}
+
+func TestVerifyCachedCode(t *testing.T) {
+	code := "console.log('hello');"
+	newDefinition := func(url string, sha256 string, configMap string) *apilifecycle.KeptnTaskDefinition {
+		return &apilifecycle.KeptnTaskDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-definition", Namespace: "default"},
+			Spec: apilifecycle.KeptnTaskDefinitionSpec{
+				Deno: &apilifecycle.RuntimeSpec{
+					HttpReference: apilifecycle.HttpReference{Url: url, Sha256: sha256, Cache: true},
+				},
+			},
+			Status: apilifecycle.KeptnTaskDefinitionStatus{
+				Function: apilifecycle.FunctionStatus{ConfigMap: configMap},
+			},
+		}
+	}
+	newConfigMap := func(data string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "keptnfn-my-definition",
+				Namespace: "default",
+				Annotations: map[string]string{
+					apicommon.SourceURLAnnotation:    "http://functions/hello.ts",
+					apicommon.SourceDigestAnnotation: "sha256:" + Checksum([]byte(code)),
+				},
+			},
+			Data: map[string]string{"code": data},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		definition *apilifecycle.KeptnTaskDefinition
+		configMap  *corev1.ConfigMap
+		wantErr    error
+	}{
+		{
+			name:       "valid cache",
+			definition: newDefinition("http://functions/hello.ts", Checksum([]byte(code)), "keptnfn-my-definition"),
+			configMap:  newConfigMap(code),
+		},
+		{
+			name:       "code not fetched",
+			definition: newDefinition("http://functions/hello.ts", "", ""),
+			configMap:  newConfigMap(code),
+			wantErr:    controllererrors.ErrFunctionCodeNotCached,
+		},
+		{
+			name:       "code of another URL",
+			definition: newDefinition("http://functions/other.ts", "", "keptnfn-my-definition"),
+			configMap:  newConfigMap(code),
+			wantErr:    controllererrors.ErrFunctionCodeNotCached,
+		},
+		{
+			name:       "code of another checksum",
+			definition: newDefinition("http://functions/hello.ts", Checksum([]byte("console.log('other');")), "keptnfn-my-definition"),
+			configMap:  newConfigMap(code),
+			wantErr:    controllererrors.ErrFunctionCodeNotCached,
+		},
+		{
+			name:       "modified code",
+			definition: newDefinition("http://functions/hello.ts", "", "keptnfn-my-definition"),
+			configMap:  newConfigMap("console.log('modified');"),
+			wantErr:    controllererrors.ErrChecksumMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyCachedCode(context.TODO(), testcommon.NewTestClient(tt.configMap), tt.definition)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+		})
+	}
+}
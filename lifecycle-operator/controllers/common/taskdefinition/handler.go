@@ -11,10 +11,13 @@ import (
 const (
 	FunctionRuntimeImageKey = "FUNCTION_RUNNER_IMAGE"
 	PythonRuntimeImageKey   = "PYTHON_RUNNER_IMAGE"
+	ShellRuntimeImageKey    = "SHELL_RUNNER_IMAGE"
 	FunctionScriptMountPath = "/var/data/function.ts"
 	PythonScriptMountPath   = "/var/data/function.py"
+	ShellScriptMountPath    = "/var/data/function.sh"
 	FunctionScriptKey       = "js"
 	PythonScriptKey         = "python"
+	ShellScriptKey          = "shell"
 )
 
 func GetRuntimeSpec(def *apilifecycle.KeptnTaskDefinition) *apilifecycle.RuntimeSpec {
@@ -24,6 +27,9 @@ func GetRuntimeSpec(def *apilifecycle.KeptnTaskDefinition) *apilifecycle.Runtime
 	if !IsRuntimeEmpty(def.Spec.Python) {
 		return def.Spec.Python
 	}
+	if !IsRuntimeEmpty(def.Spec.Shell) {
+		return def.Spec.Shell
+	}
 
 	return nil
 }
@@ -44,16 +50,23 @@ func IsInline(spec *apilifecycle.RuntimeSpec) bool {
 	return spec != nil && !reflect.DeepEqual(spec.Inline, apilifecycle.Inline{})
 }
 
+// IsCached returns true if the code referenced by the HTTP reference of the given spec is stored in a ConfigMap
+func IsCached(spec *apilifecycle.RuntimeSpec) bool {
+	return spec != nil && spec.HttpReference.Url != "" && spec.HttpReference.Cache
+}
+
 func GetRuntimeImage(def *apilifecycle.KeptnTaskDefinition) string {
 	image := os.Getenv(FunctionRuntimeImageKey)
 	if !IsRuntimeEmpty(def.Spec.Python) && IsRuntimeEmpty(def.Spec.Deno) {
 		image = os.Getenv(PythonRuntimeImageKey)
+	} else if isShellOnly(def) {
+		image = os.Getenv(ShellRuntimeImageKey)
 	}
 	return image
 }
 
 func GetCmName(functionName string, spec *apilifecycle.RuntimeSpec) string {
-	if IsInline(spec) {
+	if IsInline(spec) || IsCached(spec) {
 		return "keptnfn-" + apicommon.TruncateString(functionName, 245)
 	}
 	return spec.ConfigMapReference.Name
@@ -63,10 +76,16 @@ func GetRuntimeMountPath(def *apilifecycle.KeptnTaskDefinition) string {
 	path := FunctionScriptMountPath
 	if !IsRuntimeEmpty(def.Spec.Python) && IsRuntimeEmpty(def.Spec.Deno) {
 		path = PythonScriptMountPath
+	} else if isShellOnly(def) {
+		path = ShellScriptMountPath
 	}
 	return path
 }
 
+func isShellOnly(def *apilifecycle.KeptnTaskDefinition) bool {
+	return !IsRuntimeEmpty(def.Spec.Shell) && IsRuntimeEmpty(def.Spec.Deno) && IsRuntimeEmpty(def.Spec.Python)
+}
+
 // check if either the functions or container spec is set
 func SpecExists(definition *apilifecycle.KeptnTaskDefinition) bool {
 	if definition == nil {
@@ -13,6 +13,7 @@ func TestGetRuntimeImage(t *testing.T) {
 
 	t.Setenv(FunctionRuntimeImageKey, FunctionScriptKey)
 	t.Setenv(PythonRuntimeImageKey, PythonScriptKey)
+	t.Setenv(ShellRuntimeImageKey, ShellScriptKey)
 	tests := []struct {
 		name string
 		def  *apilifecycle.KeptnTaskDefinition
@@ -43,6 +44,19 @@ func TestGetRuntimeImage(t *testing.T) {
 			},
 			want: FunctionScriptKey,
 		},
+		{
+			name: ShellScriptKey,
+			def: &apilifecycle.KeptnTaskDefinition{
+				Spec: apilifecycle.KeptnTaskDefinitionSpec{
+					Shell: &apilifecycle.RuntimeSpec{
+						HttpReference: apilifecycle.HttpReference{
+							Url: "testy.com",
+						},
+					},
+				},
+			},
+			want: ShellScriptKey,
+		},
 		{
 			name: "deno and python defined, deno wins",
 			def: &apilifecycle.KeptnTaskDefinition{
@@ -111,6 +125,23 @@ func TestGetRuntimeSpec(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: ShellScriptKey,
+			def: &apilifecycle.KeptnTaskDefinition{
+				Spec: apilifecycle.KeptnTaskDefinitionSpec{
+					Shell: &apilifecycle.RuntimeSpec{
+						HttpReference: apilifecycle.HttpReference{
+							Url: "testy.com",
+						},
+					},
+				},
+			},
+			want: &apilifecycle.RuntimeSpec{
+				HttpReference: apilifecycle.HttpReference{
+					Url: "testy.com",
+				},
+			},
+		},
 		{
 			name: "deno & python exist",
 			def: &apilifecycle.KeptnTaskDefinition{
@@ -185,6 +216,17 @@ func TestGetRuntimeMountPath(t *testing.T) {
 			},
 			want: PythonScriptMountPath,
 		},
+		{
+			name: ShellScriptKey,
+			def: &apilifecycle.KeptnTaskDefinition{
+				Spec: apilifecycle.KeptnTaskDefinitionSpec{
+					Shell: &apilifecycle.RuntimeSpec{
+						CmdParameters: "hi",
+					},
+				},
+			},
+			want: ShellScriptMountPath,
+		},
 		{
 			name: "deno and python defined, deno wins",
 			def: &apilifecycle.KeptnTaskDefinition{
@@ -442,6 +484,17 @@ func TestGetCmName(t *testing.T) {
 			},
 			want: "configMapName",
 		},
+		{
+			name:         "cached http reference",
+			functionName: "funcName",
+			spec: &apilifecycle.RuntimeSpec{
+				HttpReference: apilifecycle.HttpReference{
+					Url:   "testy.com",
+					Cache: true,
+				},
+			},
+			want: "keptnfn-funcName",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -450,3 +503,16 @@ func TestGetCmName(t *testing.T) {
 		})
 	}
 }
+
+func TestIsCached(t *testing.T) {
+	require.False(t, IsCached(nil))
+	require.False(t, IsCached(&apilifecycle.RuntimeSpec{
+		HttpReference: apilifecycle.HttpReference{Url: "testy.com"},
+	}))
+	require.False(t, IsCached(&apilifecycle.RuntimeSpec{
+		HttpReference: apilifecycle.HttpReference{Cache: true},
+	}))
+	require.True(t, IsCached(&apilifecycle.RuntimeSpec{
+		HttpReference: apilifecycle.HttpReference{Url: "testy.com", Cache: true},
+	}))
+}
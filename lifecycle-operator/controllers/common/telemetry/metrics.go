@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
 	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
 	"go.opentelemetry.io/otel/metric"
@@ -106,3 +108,36 @@ func ObserveActiveInstances(ctx context.Context, client client.Client, reconcile
 
 	return nil
 }
+
+// ObserveQueuedTasks observes the number of KeptnTasks waiting for a free slot before their Job is created,
+// per namespace and KeptnTaskDefinition
+func ObserveQueuedTasks(ctx context.Context, client client.Client, gauge metric.Int64ObservableGauge, o metric.Observer) error {
+	tasks := &apilifecycle.KeptnTaskList{}
+	if err := client.List(ctx, tasks); err != nil {
+		return fmt.Errorf(controllererrors.ErrCannotRetrieveInstancesMsg, err)
+	}
+
+	type queueKey struct {
+		namespace      string
+		taskDefinition string
+	}
+	queued := map[queueKey]int64{}
+	for _, task := range tasks.Items {
+		key := queueKey{namespace: task.Namespace, taskDefinition: task.Spec.TaskDefinition}
+		if _, ok := queued[key]; !ok {
+			// report empty queues as well, so that the gauge drops to 0 once all tasks of a queue are started
+			queued[key] = 0
+		}
+		if task.IsQueued() {
+			queued[key]++
+		}
+	}
+
+	for key, count := range queued {
+		o.ObserveInt64(gauge, count, metric.WithAttributes(
+			apicommon.TaskNamespace.String(key.namespace),
+			apicommon.TaskDefinition.String(key.taskDefinition),
+		))
+	}
+	return nil
+}
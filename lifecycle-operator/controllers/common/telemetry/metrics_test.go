@@ -6,6 +6,7 @@ import (
 	"time"
 
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
 	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
 	"github.com/stretchr/testify/require"
@@ -161,6 +162,54 @@ func TestMetrics_ObserveActiveInstances(t *testing.T) {
 	}
 }
 
+// recordingObserver records the values observed for Int64 gauges
+type recordingObserver struct {
+	noop.Observer
+	values map[string]int64
+}
+
+func (o *recordingObserver) ObserveInt64(_ metric.Int64Observable, value int64, options ...metric.ObserveOption) {
+	attrs := metric.NewObserveConfig(options).Attributes()
+	namespace, _ := attrs.Value(apicommon.TaskNamespace)
+	definition, _ := attrs.Value(apicommon.TaskDefinition)
+	o.values[namespace.AsString()+"/"+definition.AsString()] = value
+}
+
+func TestMetrics_ObserveQueuedTasks(t *testing.T) {
+	err := apilifecycle.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+
+	makeTask := func(name, namespace, definition string, status apilifecycle.KeptnTaskStatus) apilifecycle.KeptnTask {
+		return apilifecycle.KeptnTask{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       apilifecycle.KeptnTaskSpec{TaskDefinition: definition},
+			Status:     status,
+		}
+	}
+	queued := apilifecycle.KeptnTaskStatus{Status: apicommon.StatePending, Reason: apilifecycle.TaskQueuedReason}
+	running := apilifecycle.KeptnTaskStatus{Status: apicommon.StateProgressing, JobName: "job"}
+
+	client := fake.NewClientBuilder().WithLists(&apilifecycle.KeptnTaskList{
+		Items: []apilifecycle.KeptnTask{
+			makeTask("task-1", "ns-1", "load-test", queued),
+			makeTask("task-2", "ns-1", "load-test", queued),
+			makeTask("task-3", "ns-1", "load-test", running),
+			makeTask("task-4", "ns-2", "load-test", queued),
+			makeTask("task-5", "ns-2", "notify", running),
+		},
+	}).Build()
+
+	observer := &recordingObserver{values: map[string]int64{}}
+	err = ObserveQueuedTasks(context.TODO(), client, noop.Int64ObservableGauge{}, observer)
+	require.Nil(t, err)
+
+	require.Equal(t, map[string]int64{
+		"ns-1/load-test": 2,
+		"ns-2/load-test": 1,
+		"ns-2/notify":    0,
+	}, observer.values)
+}
+
 func TestMetrics_ObserveDeploymentInterval(t *testing.T) {
 	tests := []struct {
 		name          string
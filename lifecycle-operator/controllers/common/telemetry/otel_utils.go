@@ -170,6 +170,10 @@ func SetUpKeptnMeters(meter interfaces.IMeter, mgr client.Client) {
 	if err != nil {
 		logger.Error(err, "unable to initialize active tasks OTel gauge")
 	}
+	taskQueuedGauge, err := meter.Int64ObservableGauge("keptn.task.queued", metric.WithDescription("a gauge of the Keptn Tasks waiting for a free slot to be executed"))
+	if err != nil {
+		logger.Error(err, "unable to initialize queued tasks OTel gauge")
+	}
 	appActiveGauge, err := meter.Int64ObservableGauge("keptn.app.active", metric.WithDescription("a simple counter of active Keptn Apps"))
 	if err != nil {
 		logger.Error(err, "unable to initialize active apps OTel gauge")
@@ -201,12 +205,16 @@ func SetUpKeptnMeters(meter interfaces.IMeter, mgr client.Client) {
 	_, err = meter.RegisterCallback(
 		func(ctx context.Context, o metric.Observer) error {
 			observeActiveInstances(ctx, mgr, deploymentActiveGauge, appActiveGauge, taskActiveGauge, evaluationActiveGauge, o)
+			if err := ObserveQueuedTasks(ctx, mgr, taskQueuedGauge, o); err != nil {
+				logger.Error(err, "unable to gather queued tasks")
+			}
 			observeDeploymentInterval(ctx, mgr, appDeploymentIntervalGauge, workloadDeploymentIntervalGauge, o)
 			observeDuration(ctx, mgr, appDeploymentDurationGauge, workloadDeploymentDurationGauge, o)
 			return nil
 		},
 		deploymentActiveGauge,
 		taskActiveGauge,
+		taskQueuedGauge,
 		appActiveGauge,
 		evaluationActiveGauge,
 		appDeploymentIntervalGauge,
@@ -317,3 +325,24 @@ func SetUpKeptnTaskMeters(meter interfaces.IMeter) common.KeptnMeters {
 	}
 	return meters
 }
+
+func SetUpCloudEventMeters(meter interfaces.IMeter) common.CloudEventMeters {
+	sentCount, err := meter.Int64Counter("keptn.cloudevents.sent", metric.WithDescription("a counter of Cloud Events delivered to the Cloud Events endpoint"))
+	if err != nil {
+		logger.Error(err, "unable to initialize sent Cloud Events OTel counter")
+	}
+	failedCount, err := meter.Int64Counter("keptn.cloudevents.failed", metric.WithDescription("a counter of Cloud Events that could not be delivered after all retries"))
+	if err != nil {
+		logger.Error(err, "unable to initialize failed Cloud Events OTel counter")
+	}
+	droppedCount, err := meter.Int64Counter("keptn.cloudevents.dropped", metric.WithDescription("a counter of Cloud Events dropped because the queue or the dead-letter buffer was full"))
+	if err != nil {
+		logger.Error(err, "unable to initialize dropped Cloud Events OTel counter")
+	}
+
+	return common.CloudEventMeters{
+		SentCount:    sentCount,
+		FailedCount:  failedCount,
+		DroppedCount: droppedCount,
+	}
+}
@@ -194,6 +194,14 @@ func TestSetUpKeptnTaskMeters_ErrorCase(t *testing.T) {
 	require.Nil(t, got.PromotionCount)
 }
 
+func TestSetUpCloudEventMeters(t *testing.T) {
+	got := SetUpCloudEventMeters(noop.NewMeterProvider().Meter(("test")))
+
+	require.NotNil(t, got.SentCount)
+	require.NotNil(t, got.FailedCount)
+	require.NotNil(t, got.DroppedCount)
+}
+
 func Test_otelConfig_GetTracer(t *testing.T) {
 	otelConfig := GetOtelInstance()
 
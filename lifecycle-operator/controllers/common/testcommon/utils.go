@@ -1,13 +1,17 @@
 package testcommon
 
 import (
+	"context"
 	"fmt"
 
+	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
 	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -16,6 +20,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 const KeptnNamespace = "keptn"
@@ -26,12 +31,35 @@ func NewTestClient(objs ...client.Object) client.Client {
 	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithStatusSubresource(objs...).WithObjects(objs...).Build()
 }
 
+// NewTestClientWithReviews returns a fake Client like NewTestClient, which answers TokenReviews and SubjectAccessReviews.
+// The given tokens are authenticated as the users they are mapped to, and allow decides about the access of a user.
+func NewTestClientWithReviews(tokens map[string]string, allow func(user string, attributes *authorizationv1.ResourceAttributes) bool, objs ...client.Object) client.Client {
+	SetupSchemes()
+	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithStatusSubresource(objs...).WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			switch review := obj.(type) {
+			case *authenticationv1.TokenReview:
+				if user, ok := tokens[review.Spec.Token]; ok {
+					review.Status.Authenticated = true
+					review.Status.User = authenticationv1.UserInfo{Username: user}
+				}
+				return nil
+			case *authorizationv1.SubjectAccessReview:
+				review.Status.Allowed = allow(review.Spec.User, review.Spec.ResourceAttributes)
+				return nil
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+}
+
 func SetupSchemes() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme.Scheme))
 	utilruntime.Must(corev1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(apiv1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(apilifecycle.AddToScheme(scheme.Scheme))
 	utilruntime.Must(optionsv1alpha1.AddToScheme(scheme.Scheme))
+	utilruntime.Must(argov1alpha1.AddToScheme(scheme.Scheme))
 }
 
 func GetApp(name string) *apilifecycle.KeptnApp {
@@ -18,9 +18,20 @@ var ErrInvalidOperator = fmt.Errorf("invalid operator")
 var ErrCannotMarshalParams = fmt.Errorf("could not marshal parameters")
 var ErrNoTaskDefinitionSpec = fmt.Errorf("the TaskDefinition specs are empty")
 var ErrUnsupportedWorkloadVersionResourceReference = fmt.Errorf("unsupported Resource Reference")
+var ErrUnsupportedReadinessStrategy = fmt.Errorf("unsupported readiness strategy")
+var ErrReadinessCheckNotSupported = fmt.Errorf("readiness check is not supported for this resource")
 var ErrCannotGetKeptnTaskDefinition = fmt.Errorf("cannot retrieve KeptnTaskDefinition")
 var ErrCannotGetKeptnEvaluationDefinition = fmt.Errorf("cannot retrieve KeptnEvaluationDefinition")
+var ErrCannotGetAnalysisDefinition = fmt.Errorf("cannot retrieve AnalysisDefinition")
 var ErrNoMatchingAppVersionFound = fmt.Errorf("no matching KeptnAppVersion found")
+var ErrNoPreviousAppVersionFound = fmt.Errorf("no succeeded KeptnAppVersion for the previous version found")
+var ErrNoPreviousRevisionFound = fmt.Errorf("no revision deployed with the previous version found")
+var ErrChecksumMismatch = fmt.Errorf("checksum of function code does not match")
+var ErrFunctionCodeNotCached = fmt.Errorf("function code is not cached")
+var ErrFunctionCodeSourceNotAllowed = fmt.Errorf("function code source is not allowed")
+var ErrTaskDefinitionAccessDenied = fmt.Errorf("access to KeptnTaskDefinition denied")
+var ErrCloudEventsNotDelivered = fmt.Errorf("could not deliver Cloud Events")
+var ErrNotAuthenticated = fmt.Errorf("request does not provide a valid bearer token")
 
 var ErrCannotRetrieveConfigMsg = "could not retrieve KeptnConfig: %w"
 var ErrCannotRetrieveInstancesMsg = "could not retrieve instances: %w"
@@ -33,6 +44,9 @@ var ErrNoConfigMapMsg = "no ConfigMap specified or HTTP source specified in Task
 var ErrCannotGetFunctionConfigMap = "could not get function configMap: %w"
 var ErrCannotFetchAppVersionForWorkloadVersionMsg = "could not fetch AppVersion for KeptnWorkloadVersion: %s"
 var ErrCouldNotUnbindSpan = "could not unbind span for %s"
+var ErrTaskDefinitionAccessDeniedMsg = "%w: namespace %s is not allowed to use KeptnTaskDefinition %s"
+var ErrInvalidTaskMountMsg = "mount %s must reference either a Secret or a ConfigMap"
+var ErrCloudEventsNotDeliveredMsg = "%w: endpoint %s responded with status code %d"
 
 // IgnoreReferencedResourceNotFound returns nil on NotFound errors.
 // All other values that are not NotFound errors or nil are returned unmodified.
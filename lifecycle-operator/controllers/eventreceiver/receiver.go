@@ -0,0 +1,369 @@
+package eventreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+	"time"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/go-logr/logr"
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	controllercommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/auth"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnapproval"
+	batchv1 "k8s.io/api/batch/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+const (
+	// EventAppRetry triggers another deployment of a KeptnApp by bumping its revision
+	EventAppRetry = "sh.keptn.app.retry"
+	// EventApprovalApproved approves a KeptnAppVersion waiting in its approval phase
+	EventApprovalApproved = "sh.keptn.approval.approved"
+	// EventApprovalRejected rejects a KeptnAppVersion waiting in its approval phase
+	EventApprovalRejected = "sh.keptn.approval.rejected"
+	// EventTaskFinished marks a KeptnTask as finished
+	EventTaskFinished = "sh.keptn.task.finished"
+	// EventAppContextMetadata adds metadata to a KeptnAppContext
+	EventAppContextMetadata = "sh.keptn.appcontext.metadata"
+	// cdEventTaskRunFinishedPrefix is the prefix of the taskrun.finished CDEvents of all versions,
+	// which mark a KeptnTask as finished
+	cdEventTaskRunFinishedPrefix = "dev.cdevents.taskrun.finished."
+)
+
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnapps,verbs=get;update
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnappcontexts,verbs=get;update
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnappversions,verbs=get
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnapprovals,verbs=create
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntasks,verbs=get
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntasks/status,verbs=update
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntaskdefinitions,verbs=get
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=delete
+
+// AppRequest is the data of the sh.keptn.app.retry and sh.keptn.appcontext.metadata events
+type AppRequest struct {
+	Namespace string            `json:"namespace"`
+	App       string            `json:"app"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// TaskRequest is the data of the sh.keptn.task.finished event
+type TaskRequest struct {
+	Namespace string               `json:"namespace"`
+	Task      string               `json:"task"`
+	Status    apicommon.KeptnState `json:"status"`
+	Message   string               `json:"message,omitempty"`
+}
+
+// requestError is an error that is returned to the sender of an event with the given status code
+type requestError struct {
+	code int
+	msg  string
+}
+
+func (e requestError) Error() string {
+	return e.msg
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return requestError{code: http.StatusBadRequest, msg: fmt.Sprintf(format, args...)}
+}
+
+// Receiver exposes an HTTP endpoint accepting Cloud Events, which allows external systems
+// such as CI pipelines to control deployments without access to the Kubernetes API.
+// Besides the Keptn events, the taskrun.finished CDEvent is accepted to finish a KeptnTask.
+// The sender authenticates with a bearer token and needs the RBAC permissions of the change
+// triggered by the event in the namespace of the referenced resource.
+// Events are received via HTTPS with the certificate found in certDir.
+type Receiver struct {
+	client  client.Client
+	log     logr.Logger
+	port    int
+	certDir string
+	auth    *auth.Authenticator
+}
+
+func NewReceiver(client client.Client, log logr.Logger, port int, certDir string) *Receiver {
+	return &Receiver{
+		client:  client,
+		log:     log,
+		port:    port,
+		certDir: certDir,
+		auth:    auth.NewAuthenticator(client, log),
+	}
+}
+
+// Start runs the HTTPS server until the given context is cancelled
+func (rc *Receiver) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", rc.port),
+		Handler:           rc.Handler(),
+		ReadHeaderTimeout: shutdownTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			rc.log.Error(err, "could not shut down Cloud Events receiver")
+		}
+	}()
+
+	rc.log.Info("receiving Cloud Events", "port", rc.port)
+	if err := auth.ListenAndServeTLS(ctx, rc.log, server, rc.certDir); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection implements LeaderElectionRunnable, events are received by all replicas
+func (rc *Receiver) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the http.Handler receiving Cloud Events in binary or structured content mode
+func (rc *Receiver) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /events", rc.handle)
+	return rc.auth.Middleware(mux)
+}
+
+func (rc *Receiver) handle(w http.ResponseWriter, r *http.Request) {
+	event, err := cehttp.NewEventFromHTTPRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("could not read Cloud Event: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if err := rc.process(r.Context(), *event); err != nil {
+		var reqErr requestError
+		if errors.As(err, &reqErr) {
+			http.Error(w, reqErr.msg, reqErr.code)
+			return
+		}
+		rc.log.Error(err, "could not process Cloud Event", "type", event.Type(), "id", event.ID())
+		http.Error(w, "could not process Cloud Event", http.StatusInternalServerError)
+		return
+	}
+
+	rc.log.Info("processed Cloud Event", "type", event.Type(), "id", event.ID(), "source", event.Source())
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (rc *Receiver) process(ctx context.Context, event ce.Event) error {
+	switch {
+	case event.Type() == EventAppRetry:
+		return rc.retryApp(ctx, event)
+	case event.Type() == EventApprovalApproved:
+		return rc.approve(ctx, event, apilifecycle.ApprovalDecisionApproved)
+	case event.Type() == EventApprovalRejected:
+		return rc.approve(ctx, event, apilifecycle.ApprovalDecisionRejected)
+	case event.Type() == EventTaskFinished:
+		request := TaskRequest{}
+		if err := event.DataAs(&request); err != nil {
+			return badRequest("could not decode data of %s: %s", event.Type(), err)
+		}
+		return rc.finishTask(ctx, request)
+	case strings.HasPrefix(event.Type(), cdEventTaskRunFinishedPrefix):
+		request, err := getTaskRequestFromCDEvent(event)
+		if err != nil {
+			return err
+		}
+		return rc.finishTask(ctx, request)
+	case event.Type() == EventAppContextMetadata:
+		return rc.addMetadata(ctx, event)
+	}
+	return badRequest("unsupported Cloud Event type %s", event.Type())
+}
+
+// retryApp bumps the revision of a KeptnApp, which triggers another deployment of its version
+func (rc *Receiver) retryApp(ctx context.Context, event ce.Event) error {
+	request := AppRequest{}
+	if err := event.DataAs(&request); err != nil {
+		return badRequest("could not decode data of %s: %s", event.Type(), err)
+	}
+	if request.Namespace == "" || request.App == "" {
+		return badRequest("namespace and app are required")
+	}
+
+	if err := rc.authorize(ctx, "update", "keptnapps", "", request.Namespace); err != nil {
+		return err
+	}
+
+	app := &apilifecycle.KeptnApp{}
+	if err := rc.get(ctx, "KeptnApp", request.Namespace, request.App, app); err != nil {
+		return err
+	}
+	app.Spec.Revision++
+	return conflictError(app, rc.client.Update(ctx, app))
+}
+
+// approve creates a KeptnApproval for a KeptnAppVersion waiting in its approval phase, the sender is the approver
+func (rc *Receiver) approve(ctx context.Context, event ce.Event, decision apilifecycle.ApprovalDecision) error {
+	request := keptnapproval.CallbackRequest{}
+	if err := event.DataAs(&request); err != nil {
+		return badRequest("could not decode data of %s: %s", event.Type(), err)
+	}
+	if request.Namespace == "" || request.AppVersion == "" {
+		return badRequest("namespace and appVersion are required")
+	}
+	if err := rc.authorize(ctx, "create", "keptnapprovals", "", request.Namespace); err != nil {
+		return err
+	}
+
+	appVersion := &apilifecycle.KeptnAppVersion{}
+	if err := rc.get(ctx, "KeptnAppVersion", request.Namespace, request.AppVersion, appVersion); err != nil {
+		return err
+	}
+	return rc.client.Create(ctx, keptnapproval.NewApproval(appVersion, auth.UserFrom(ctx).Username, request, decision))
+}
+
+// finishTask sets the status of a KeptnTask which has not completed yet, e.g. a task waiting for an external system.
+// Only KeptnTasks whose KeptnTaskDefinition allows it can be finished, their Job is deleted.
+func (rc *Receiver) finishTask(ctx context.Context, request TaskRequest) error {
+	if request.Namespace == "" || request.Task == "" {
+		return badRequest("namespace and task are required")
+	}
+	if request.Status != apicommon.StateSucceeded && request.Status != apicommon.StateFailed {
+		return badRequest("status must be %s or %s", apicommon.StateSucceeded, apicommon.StateFailed)
+	}
+	if err := rc.authorize(ctx, "update", "keptntasks", "status", request.Namespace); err != nil {
+		return err
+	}
+
+	task := &apilifecycle.KeptnTask{}
+	if err := rc.get(ctx, "KeptnTask", request.Namespace, request.Task, task); err != nil {
+		return err
+	}
+	if task.Status.Status.IsCompleted() {
+		return requestError{code: http.StatusConflict, msg: fmt.Sprintf("KeptnTask %s is already completed", request.Task)}
+	}
+	// the KeptnTaskDefinition is resolved like in the task controller, including task definition libraries
+	definition, err := controllercommon.GetTaskDefinition(rc.client, rc.log, ctx, task.Spec.TaskDefinition, task.Namespace)
+	if errors.Is(err, controllererrors.ErrTaskDefinitionAccessDenied) {
+		return requestError{code: http.StatusForbidden, msg: err.Error()}
+	}
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	if err != nil || !definition.Spec.AllowFinishByEvent {
+		return requestError{code: http.StatusConflict, msg: fmt.Sprintf("KeptnTaskDefinition %s of KeptnTask %s does not allow to finish its tasks by events", task.Spec.TaskDefinition, request.Task)}
+	}
+
+	task.Status.Status = request.Status
+	task.Status.Message = request.Message
+	task.Status.Reason = apilifecycle.TaskFinishedByEventReason
+	if err := rc.client.Status().Update(ctx, task); err != nil {
+		return conflictError(task, err)
+	}
+	return rc.deleteJob(ctx, task)
+}
+
+// deleteJob stops the Job of a KeptnTask that has been finished by an event
+func (rc *Receiver) deleteJob(ctx context.Context, task *apilifecycle.KeptnTask) error {
+	if task.Status.JobName == "" {
+		return nil
+	}
+	job := &batchv1.Job{}
+	job.Name = task.Status.JobName
+	job.Namespace = task.Namespace
+	err := rc.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	return client.IgnoreNotFound(err)
+}
+
+// addMetadata adds the given metadata to a KeptnAppContext, overwriting existing keys
+func (rc *Receiver) addMetadata(ctx context.Context, event ce.Event) error {
+	request := AppRequest{}
+	if err := event.DataAs(&request); err != nil {
+		return badRequest("could not decode data of %s: %s", event.Type(), err)
+	}
+	if request.Namespace == "" || request.App == "" || len(request.Metadata) == 0 {
+		return badRequest("namespace, app and metadata are required")
+	}
+
+	if err := rc.authorize(ctx, "update", "keptnappcontexts", "", request.Namespace); err != nil {
+		return err
+	}
+
+	appContext := &apilifecycle.KeptnAppContext{}
+	if err := rc.get(ctx, "KeptnAppContext", request.Namespace, request.App, appContext); err != nil {
+		return err
+	}
+	if appContext.Spec.Metadata == nil {
+		appContext.Spec.Metadata = map[string]string{}
+	}
+	maps.Copy(appContext.Spec.Metadata, request.Metadata)
+	return conflictError(appContext, rc.client.Update(ctx, appContext))
+}
+
+func (rc *Receiver) get(ctx context.Context, kind string, namespace string, name string, obj client.Object) error {
+	err := rc.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj)
+	if k8serrors.IsNotFound(err) {
+		return requestError{code: http.StatusNotFound, msg: fmt.Sprintf("%s %s not found in namespace %s", kind, name, namespace)}
+	}
+	return err
+}
+
+// conflictError asks the sender to retry the event if the given object was modified concurrently
+func conflictError(obj client.Object, err error) error {
+	if k8serrors.IsConflict(err) {
+		return requestError{code: http.StatusConflict, msg: fmt.Sprintf("%s was modified concurrently, please retry", obj.GetName())}
+	}
+	return err
+}
+
+// authorize checks whether the sender of the event may perform the verb on the resource in the given namespace
+func (rc *Receiver) authorize(ctx context.Context, verb string, resource string, subresource string, namespace string) error {
+	user := auth.UserFrom(ctx)
+	allowed, err := rc.auth.Authorize(ctx, user, verb, resource, subresource, namespace)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		if subresource != "" {
+			resource += "/" + subresource
+		}
+		return requestError{code: http.StatusForbidden, msg: fmt.Sprintf("%s is not allowed to %s %s in namespace %s", user.Username, verb, resource, namespace)}
+	}
+	return nil
+}
+
+// getTaskRequestFromCDEvent maps a taskrun.finished CDEvent to a TaskRequest.
+// The subject ID of the CDEvent is the same as the one of the task run CDEvents emitted for the KeptnTask.
+func getTaskRequestFromCDEvent(event ce.Event) (TaskRequest, error) {
+	cdEvent := eventsender.CDEvent{}
+	if err := event.DataAs(&cdEvent); err != nil {
+		return TaskRequest{}, badRequest("could not decode CDEvent %s: %s", event.Type(), err)
+	}
+	namespace, task, ok := eventsender.ParseTaskRunSubjectID(cdEvent.Subject.ID)
+	if !ok {
+		return TaskRequest{}, badRequest("subject id must be <namespace>/<task>, got %s", cdEvent.Subject.ID)
+	}
+
+	request := TaskRequest{
+		Namespace: namespace,
+		Task:      task,
+		Status:    apicommon.StateFailed,
+	}
+	if outcome, _ := cdEvent.Subject.Content["outcome"].(string); outcome == "success" {
+		request.Status = apicommon.StateSucceeded
+	}
+	if errs, ok := cdEvent.Subject.Content["errors"].(string); ok {
+		request.Message = errs
+	}
+	return request, nil
+}
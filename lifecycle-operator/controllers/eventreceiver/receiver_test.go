@@ -0,0 +1,320 @@
+package eventreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReceiver_RetryApp(t *testing.T) {
+	app := &apilifecycle.KeptnApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "default"},
+		Spec:       apilifecycle.KeptnAppSpec{Version: "1.0.0", Revision: 1},
+	}
+	fakeClient := newTestClient(app)
+	receiver := NewReceiver(fakeClient, ctrl.Log.WithName("test"), 8084, "")
+
+	rec := send(receiver, newEventRequest(EventAppRetry, `{"namespace":"default","app":"my-app"}`))
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	updated := &apilifecycle.KeptnApp{}
+	require.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-app"}, updated))
+	require.Equal(t, uint(2), updated.Spec.Revision)
+
+	rec = send(receiver, newEventRequest(EventAppRetry, `{"namespace":"default","app":"other-app"}`))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = send(receiver, withToken(newEventRequest(EventAppRetry, `{"namespace":"default","app":"my-app"}`), "other-token"))
+	require.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestReceiver_Approval(t *testing.T) {
+	appVersion := &apilifecycle.KeptnAppVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app-1.0.0", Namespace: "default"},
+	}
+	fakeClient := newTestClient(appVersion)
+	receiver := NewReceiver(fakeClient, ctrl.Log.WithName("test"), 8084, "")
+
+	rec := send(receiver, newEventRequest(EventApprovalRejected, `{"namespace":"default"}`))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = send(receiver, withToken(newEventRequest(EventApprovalApproved, `{"namespace":"default","appVersion":"my-app-1.0.0"}`), "other-token"))
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	// the approver of the data is ignored, the sender is the approver
+	rec = send(receiver, newEventRequest(EventApprovalApproved, `{"namespace":"default","appVersion":"my-app-1.0.0","approver":"alice"}`))
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	approvals := &apilifecycle.KeptnApprovalList{}
+	require.Nil(t, fakeClient.List(context.TODO(), approvals, client.InNamespace("default")))
+	require.Len(t, approvals.Items, 1)
+	require.Equal(t, "my-app-1.0.0", approvals.Items[0].Spec.AppVersion)
+	require.Equal(t, "ci", approvals.Items[0].Spec.Approver)
+	require.Equal(t, apilifecycle.ApprovalDecisionApproved, approvals.Items[0].Spec.Decision)
+}
+
+func TestReceiver_FinishTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        *http.Request
+		definition string
+		taskStatus apicommon.KeptnState
+		wantCode   int
+		wantStatus apicommon.KeptnState
+		wantMsg    string
+	}{
+		{
+			name:       "keptn event",
+			req:        newEventRequest(EventTaskFinished, `{"namespace":"default","task":"my-task","status":"Succeeded"}`),
+			taskStatus: apicommon.StateProgressing,
+			wantCode:   http.StatusAccepted,
+			wantStatus: apicommon.StateSucceeded,
+		},
+		{
+			name:       "cdevent",
+			req:        newCDEventRequest(`{"context":{"version":"0.4.1","type":"dev.cdevents.taskrun.finished.0.2.0"},"subject":{"id":"default/my-task","content":{"outcome":"failure","errors":"tests failed"}}}`),
+			taskStatus: apicommon.StateProgressing,
+			wantCode:   http.StatusAccepted,
+			wantStatus: apicommon.StateFailed,
+			wantMsg:    "tests failed",
+		},
+		{
+			name:       "invalid status",
+			req:        newEventRequest(EventTaskFinished, `{"namespace":"default","task":"my-task","status":"Progressing"}`),
+			taskStatus: apicommon.StateProgressing,
+			wantCode:   http.StatusBadRequest,
+			wantStatus: apicommon.StateProgressing,
+		},
+		{
+			name:       "already completed",
+			req:        newEventRequest(EventTaskFinished, `{"namespace":"default","task":"my-task","status":"Failed"}`),
+			taskStatus: apicommon.StateSucceeded,
+			wantCode:   http.StatusConflict,
+			wantStatus: apicommon.StateSucceeded,
+		},
+		{
+			name:       "definition does not allow to finish by event",
+			req:        newEventRequest(EventTaskFinished, `{"namespace":"default","task":"my-task","status":"Succeeded"}`),
+			definition: "job-definition",
+			taskStatus: apicommon.StateProgressing,
+			wantCode:   http.StatusConflict,
+			wantStatus: apicommon.StateProgressing,
+		},
+		{
+			name:       "unknown definition",
+			req:        newEventRequest(EventTaskFinished, `{"namespace":"default","task":"my-task","status":"Succeeded"}`),
+			definition: "unknown-definition",
+			taskStatus: apicommon.StateProgressing,
+			wantCode:   http.StatusConflict,
+			wantStatus: apicommon.StateProgressing,
+		},
+		{
+			name:       "definition of task definition library",
+			req:        newEventRequest(EventTaskFinished, `{"namespace":"default","task":"my-task","status":"Succeeded"}`),
+			definition: "platform-tasks/library-definition",
+			taskStatus: apicommon.StateProgressing,
+			wantCode:   http.StatusAccepted,
+			wantStatus: apicommon.StateSucceeded,
+		},
+		{
+			name:       "definition of task definition library not allowed for namespace",
+			req:        newEventRequest(EventTaskFinished, `{"namespace":"default","task":"my-task","status":"Succeeded"}`),
+			definition: "other-tasks/library-definition",
+			taskStatus: apicommon.StateProgressing,
+			wantCode:   http.StatusForbidden,
+			wantStatus: apicommon.StateProgressing,
+		},
+		{
+			name:       "sender without access",
+			req:        withToken(newEventRequest(EventTaskFinished, `{"namespace":"default","task":"my-task","status":"Succeeded"}`), "other-token"),
+			taskStatus: apicommon.StateProgressing,
+			wantCode:   http.StatusForbidden,
+			wantStatus: apicommon.StateProgressing,
+		},
+	}
+
+	config.Instance().SetTaskDefinitionLibraries(map[string][]string{
+		"platform-tasks": {"default"},
+		"other-tasks":    {"other-namespace"},
+	})
+	defer config.Instance().SetTaskDefinitionLibraries(nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definition := tt.definition
+			if definition == "" {
+				definition = "external-definition"
+			}
+			task := &apilifecycle.KeptnTask{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-task", Namespace: "default"},
+				Spec:       apilifecycle.KeptnTaskSpec{TaskDefinition: definition},
+				Status:     apilifecycle.KeptnTaskStatus{Status: tt.taskStatus, JobName: "my-task-job"},
+			}
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-task-job", Namespace: "default"},
+			}
+			fakeClient := newTestClient(
+				task,
+				job,
+				makeTaskDefinition("external-definition", true),
+				makeTaskDefinition("job-definition", false),
+				makeLibraryTaskDefinition("platform-tasks"),
+				makeLibraryTaskDefinition("other-tasks"),
+			)
+			receiver := NewReceiver(fakeClient, ctrl.Log.WithName("test"), 8084, "")
+
+			rec := send(receiver, tt.req)
+			require.Equal(t, tt.wantCode, rec.Code)
+
+			updated := &apilifecycle.KeptnTask{}
+			require.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-task"}, updated))
+			require.Equal(t, tt.wantStatus, updated.Status.Status)
+			require.Equal(t, tt.wantMsg, updated.Status.Message)
+
+			err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-task-job"}, &batchv1.Job{})
+			if tt.wantCode == http.StatusAccepted {
+				require.Equal(t, apilifecycle.TaskFinishedByEventReason, updated.Status.Reason)
+				require.True(t, k8serrors.IsNotFound(err))
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestReceiver_AppContextMetadata(t *testing.T) {
+	appContext := &apilifecycle.KeptnAppContext{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "default"},
+		Spec: apilifecycle.KeptnAppContextSpec{
+			Metadata: map[string]string{"commit": "abc", "team": "payments"},
+		},
+	}
+	fakeClient := newTestClient(appContext)
+	receiver := NewReceiver(fakeClient, ctrl.Log.WithName("test"), 8084, "")
+
+	rec := send(receiver, newEventRequest(EventAppContextMetadata, `{"namespace":"default","app":"my-app","metadata":{"commit":"def","pipeline":"42"}}`))
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	updated := &apilifecycle.KeptnAppContext{}
+	require.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-app"}, updated))
+	require.Equal(t, map[string]string{"commit": "def", "team": "payments", "pipeline": "42"}, updated.Spec.Metadata)
+}
+
+func TestReceiver_InvalidRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *http.Request
+		token    string
+		wantCode int
+	}{
+		{
+			name:     "missing token",
+			req:      newEventRequest(EventAppRetry, `{"namespace":"default","app":"my-app"}`),
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "invalid token",
+			req:      newEventRequest(EventAppRetry, `{"namespace":"default","app":"my-app"}`),
+			token:    "wrong",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "unsupported type",
+			req:      newEventRequest("sh.keptn.unknown", `{}`),
+			token:    "ci-token",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "cdevent with subject id of a pipeline run",
+			req:      newCDEventRequest(`{"context":{"version":"0.4.1","type":"dev.cdevents.taskrun.finished.0.2.0"},"subject":{"id":"default/my-app/my-workload/1.0.0/WorkloadPreDeployTasks","content":{"outcome":"success"}}}`),
+			token:    "ci-token",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "no cloud event",
+			req:      httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(`{}`)),
+			token:    "ci-token",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := NewReceiver(newTestClient(), ctrl.Log.WithName("test"), 8084, "")
+			require.Equal(t, tt.wantCode, send(receiver, withToken(tt.req, tt.token)).Code)
+		})
+	}
+}
+
+// newTestClient authenticates ci, who may change the Keptn resources of the default namespace, and other,
+// who has no permissions
+func newTestClient(objs ...client.Object) client.Client {
+	tokens := map[string]string{"ci-token": "ci", "other-token": "other"}
+	return testcommon.NewTestClientWithReviews(tokens, func(user string, attributes *authorizationv1.ResourceAttributes) bool {
+		return user == "ci" && attributes.Namespace == "default"
+	}, objs...)
+}
+
+func makeTaskDefinition(name string, allowFinishByEvent bool) *apilifecycle.KeptnTaskDefinition {
+	return &apilifecycle.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       apilifecycle.KeptnTaskDefinitionSpec{AllowFinishByEvent: allowFinishByEvent},
+	}
+}
+
+func makeLibraryTaskDefinition(namespace string) *apilifecycle.KeptnTaskDefinition {
+	definition := makeTaskDefinition("library-definition", true)
+	definition.Namespace = namespace
+	return definition
+}
+
+func withToken(req *http.Request, token string) *http.Request {
+	if token == "" {
+		req.Header.Del("Authorization")
+	} else {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func send(receiver *Receiver, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	receiver.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+// newEventRequest creates a request containing a Cloud Event in binary content mode
+func newEventRequest(eventType string, data string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(data))
+	req.Header.Set("Ce-Specversion", "1.0")
+	req.Header.Set("Ce-Id", "event-1")
+	req.Header.Set("Ce-Source", "ci")
+	req.Header.Set("Ce-Type", eventType)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer ci-token")
+	return req
+}
+
+// newCDEventRequest creates a request containing a taskrun.finished CDEvent in structured content mode
+func newCDEventRequest(data string) *http.Request {
+	body := `{"specversion":"1.0","id":"event-1","source":"ci","type":"dev.cdevents.taskrun.finished.0.2.0","datacontenttype":"application/json","data":` + data + `}`
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	req.Header.Set("Authorization", "Bearer ci-token")
+	return req
+}
@@ -30,15 +30,30 @@ import (
 //			GenerateTaskFunc: func(taskDefinition apilifecycle.KeptnTaskDefinition, checkType apicommon.CheckType) apilifecycle.KeptnTask {
 //				panic("mock out the GenerateTask method")
 //			},
+//			GetAnalysesFunc: func(checkType apicommon.CheckType) []string {
+//				panic("mock out the GetAnalyses method")
+//			},
+//			GetAnalysisStatusFunc: func(checkType apicommon.CheckType) []apilifecycle.ItemStatus {
+//				panic("mock out the GetAnalysisStatus method")
+//			},
+//			GetAnalysisTimeframeFunc: func() time.Duration {
+//				panic("mock out the GetAnalysisTimeframe method")
+//			},
 //			GetAppNameFunc: func() string {
 //				panic("mock out the GetAppName method")
 //			},
 //			GetCurrentPhaseFunc: func() string {
 //				panic("mock out the GetCurrentPhase method")
 //			},
+//			GetDeploymentEndTimeFunc: func() time.Time {
+//				panic("mock out the GetDeploymentEndTime method")
+//			},
 //			GetEndTimeFunc: func() time.Time {
 //				panic("mock out the GetEndTime method")
 //			},
+//			GetEvaluationReferencesFunc: func(checkType apicommon.CheckType) []apilifecycle.EvaluationReference {
+//				panic("mock out the GetEvaluationReferences method")
+//			},
 //			GetNamespaceFunc: func() string {
 //				panic("mock out the GetNamespace method")
 //			},
@@ -87,6 +102,9 @@ import (
 //			GetStateFunc: func() apicommon.KeptnState {
 //				panic("mock out the GetState method")
 //			},
+//			GetTaskReferencesFunc: func(checkType apicommon.CheckType) []apilifecycle.TaskReference {
+//				panic("mock out the GetTaskReferences method")
+//			},
 //			GetVersionFunc: func() string {
 //				panic("mock out the GetVersion method")
 //			},
@@ -121,15 +139,30 @@ type PhaseItemMock struct {
 	// GenerateTaskFunc mocks the GenerateTask method.
 	GenerateTaskFunc func(taskDefinition apilifecycle.KeptnTaskDefinition, checkType apicommon.CheckType) apilifecycle.KeptnTask
 
+	// GetAnalysesFunc mocks the GetAnalyses method.
+	GetAnalysesFunc func(checkType apicommon.CheckType) []string
+
+	// GetAnalysisStatusFunc mocks the GetAnalysisStatus method.
+	GetAnalysisStatusFunc func(checkType apicommon.CheckType) []apilifecycle.ItemStatus
+
+	// GetAnalysisTimeframeFunc mocks the GetAnalysisTimeframe method.
+	GetAnalysisTimeframeFunc func() time.Duration
+
 	// GetAppNameFunc mocks the GetAppName method.
 	GetAppNameFunc func() string
 
 	// GetCurrentPhaseFunc mocks the GetCurrentPhase method.
 	GetCurrentPhaseFunc func() string
 
+	// GetDeploymentEndTimeFunc mocks the GetDeploymentEndTime method.
+	GetDeploymentEndTimeFunc func() time.Time
+
 	// GetEndTimeFunc mocks the GetEndTime method.
 	GetEndTimeFunc func() time.Time
 
+	// GetEvaluationReferencesFunc mocks the GetEvaluationReferences method.
+	GetEvaluationReferencesFunc func(checkType apicommon.CheckType) []apilifecycle.EvaluationReference
+
 	// GetNamespaceFunc mocks the GetNamespace method.
 	GetNamespaceFunc func() string
 
@@ -178,6 +211,9 @@ type PhaseItemMock struct {
 	// GetStateFunc mocks the GetState method.
 	GetStateFunc func() apicommon.KeptnState
 
+	// GetTaskReferencesFunc mocks the GetTaskReferences method.
+	GetTaskReferencesFunc func(checkType apicommon.CheckType) []apilifecycle.TaskReference
+
 	// GetVersionFunc mocks the GetVersion method.
 	GetVersionFunc func() string
 
@@ -217,15 +253,36 @@ type PhaseItemMock struct {
 			// CheckType is the checkType argument value.
 			CheckType apicommon.CheckType
 		}
+		// GetAnalyses holds details about calls to the GetAnalyses method.
+		GetAnalyses []struct {
+			// CheckType is the checkType argument value.
+			CheckType apicommon.CheckType
+		}
+		// GetAnalysisStatus holds details about calls to the GetAnalysisStatus method.
+		GetAnalysisStatus []struct {
+			// CheckType is the checkType argument value.
+			CheckType apicommon.CheckType
+		}
+		// GetAnalysisTimeframe holds details about calls to the GetAnalysisTimeframe method.
+		GetAnalysisTimeframe []struct {
+		}
 		// GetAppName holds details about calls to the GetAppName method.
 		GetAppName []struct {
 		}
 		// GetCurrentPhase holds details about calls to the GetCurrentPhase method.
 		GetCurrentPhase []struct {
 		}
+		// GetDeploymentEndTime holds details about calls to the GetDeploymentEndTime method.
+		GetDeploymentEndTime []struct {
+		}
 		// GetEndTime holds details about calls to the GetEndTime method.
 		GetEndTime []struct {
 		}
+		// GetEvaluationReferences holds details about calls to the GetEvaluationReferences method.
+		GetEvaluationReferences []struct {
+			// CheckType is the checkType argument value.
+			CheckType apicommon.CheckType
+		}
 		// GetNamespace holds details about calls to the GetNamespace method.
 		GetNamespace []struct {
 		}
@@ -274,6 +331,11 @@ type PhaseItemMock struct {
 		// GetState holds details about calls to the GetState method.
 		GetState []struct {
 		}
+		// GetTaskReferences holds details about calls to the GetTaskReferences method.
+		GetTaskReferences []struct {
+			// CheckType is the checkType argument value.
+			CheckType apicommon.CheckType
+		}
 		// GetVersion holds details about calls to the GetVersion method.
 		GetVersion []struct {
 		}
@@ -300,9 +362,14 @@ type PhaseItemMock struct {
 	lockDeprecateRemainingPhases              sync.RWMutex
 	lockGenerateEvaluation                    sync.RWMutex
 	lockGenerateTask                          sync.RWMutex
+	lockGetAnalyses                           sync.RWMutex
+	lockGetAnalysisStatus                     sync.RWMutex
+	lockGetAnalysisTimeframe                  sync.RWMutex
 	lockGetAppName                            sync.RWMutex
 	lockGetCurrentPhase                       sync.RWMutex
+	lockGetDeploymentEndTime                  sync.RWMutex
 	lockGetEndTime                            sync.RWMutex
+	lockGetEvaluationReferences               sync.RWMutex
 	lockGetNamespace                          sync.RWMutex
 	lockGetParentName                         sync.RWMutex
 	lockGetPostDeploymentEvaluationTaskStatus sync.RWMutex
@@ -319,6 +386,7 @@ type PhaseItemMock struct {
 	lockGetSpanAttributes                     sync.RWMutex
 	lockGetStartTime                          sync.RWMutex
 	lockGetState                              sync.RWMutex
+	lockGetTaskReferences                     sync.RWMutex
 	lockGetVersion                            sync.RWMutex
 	lockIsEndTimeSet                          sync.RWMutex
 	lockSetCurrentPhase                       sync.RWMutex
@@ -457,6 +525,97 @@ func (mock *PhaseItemMock) GenerateTaskCalls() []struct {
 	return calls
 }
 
+// GetAnalyses calls GetAnalysesFunc.
+func (mock *PhaseItemMock) GetAnalyses(checkType apicommon.CheckType) []string {
+	if mock.GetAnalysesFunc == nil {
+		panic("PhaseItemMock.GetAnalysesFunc: method is nil but PhaseItem.GetAnalyses was just called")
+	}
+	callInfo := struct {
+		CheckType apicommon.CheckType
+	}{
+		CheckType: checkType,
+	}
+	mock.lockGetAnalyses.Lock()
+	mock.calls.GetAnalyses = append(mock.calls.GetAnalyses, callInfo)
+	mock.lockGetAnalyses.Unlock()
+	return mock.GetAnalysesFunc(checkType)
+}
+
+// GetAnalysesCalls gets all the calls that were made to GetAnalyses.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetAnalysesCalls())
+func (mock *PhaseItemMock) GetAnalysesCalls() []struct {
+	CheckType apicommon.CheckType
+} {
+	var calls []struct {
+		CheckType apicommon.CheckType
+	}
+	mock.lockGetAnalyses.RLock()
+	calls = mock.calls.GetAnalyses
+	mock.lockGetAnalyses.RUnlock()
+	return calls
+}
+
+// GetAnalysisStatus calls GetAnalysisStatusFunc.
+func (mock *PhaseItemMock) GetAnalysisStatus(checkType apicommon.CheckType) []apilifecycle.ItemStatus {
+	if mock.GetAnalysisStatusFunc == nil {
+		panic("PhaseItemMock.GetAnalysisStatusFunc: method is nil but PhaseItem.GetAnalysisStatus was just called")
+	}
+	callInfo := struct {
+		CheckType apicommon.CheckType
+	}{
+		CheckType: checkType,
+	}
+	mock.lockGetAnalysisStatus.Lock()
+	mock.calls.GetAnalysisStatus = append(mock.calls.GetAnalysisStatus, callInfo)
+	mock.lockGetAnalysisStatus.Unlock()
+	return mock.GetAnalysisStatusFunc(checkType)
+}
+
+// GetAnalysisStatusCalls gets all the calls that were made to GetAnalysisStatus.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetAnalysisStatusCalls())
+func (mock *PhaseItemMock) GetAnalysisStatusCalls() []struct {
+	CheckType apicommon.CheckType
+} {
+	var calls []struct {
+		CheckType apicommon.CheckType
+	}
+	mock.lockGetAnalysisStatus.RLock()
+	calls = mock.calls.GetAnalysisStatus
+	mock.lockGetAnalysisStatus.RUnlock()
+	return calls
+}
+
+// GetAnalysisTimeframe calls GetAnalysisTimeframeFunc.
+func (mock *PhaseItemMock) GetAnalysisTimeframe() time.Duration {
+	if mock.GetAnalysisTimeframeFunc == nil {
+		panic("PhaseItemMock.GetAnalysisTimeframeFunc: method is nil but PhaseItem.GetAnalysisTimeframe was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetAnalysisTimeframe.Lock()
+	mock.calls.GetAnalysisTimeframe = append(mock.calls.GetAnalysisTimeframe, callInfo)
+	mock.lockGetAnalysisTimeframe.Unlock()
+	return mock.GetAnalysisTimeframeFunc()
+}
+
+// GetAnalysisTimeframeCalls gets all the calls that were made to GetAnalysisTimeframe.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetAnalysisTimeframeCalls())
+func (mock *PhaseItemMock) GetAnalysisTimeframeCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetAnalysisTimeframe.RLock()
+	calls = mock.calls.GetAnalysisTimeframe
+	mock.lockGetAnalysisTimeframe.RUnlock()
+	return calls
+}
+
 // GetAppName calls GetAppNameFunc.
 func (mock *PhaseItemMock) GetAppName() string {
 	if mock.GetAppNameFunc == nil {
@@ -511,6 +670,33 @@ func (mock *PhaseItemMock) GetCurrentPhaseCalls() []struct {
 	return calls
 }
 
+// GetDeploymentEndTime calls GetDeploymentEndTimeFunc.
+func (mock *PhaseItemMock) GetDeploymentEndTime() time.Time {
+	if mock.GetDeploymentEndTimeFunc == nil {
+		panic("PhaseItemMock.GetDeploymentEndTimeFunc: method is nil but PhaseItem.GetDeploymentEndTime was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetDeploymentEndTime.Lock()
+	mock.calls.GetDeploymentEndTime = append(mock.calls.GetDeploymentEndTime, callInfo)
+	mock.lockGetDeploymentEndTime.Unlock()
+	return mock.GetDeploymentEndTimeFunc()
+}
+
+// GetDeploymentEndTimeCalls gets all the calls that were made to GetDeploymentEndTime.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetDeploymentEndTimeCalls())
+func (mock *PhaseItemMock) GetDeploymentEndTimeCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetDeploymentEndTime.RLock()
+	calls = mock.calls.GetDeploymentEndTime
+	mock.lockGetDeploymentEndTime.RUnlock()
+	return calls
+}
+
 // GetEndTime calls GetEndTimeFunc.
 func (mock *PhaseItemMock) GetEndTime() time.Time {
 	if mock.GetEndTimeFunc == nil {
@@ -538,6 +724,38 @@ func (mock *PhaseItemMock) GetEndTimeCalls() []struct {
 	return calls
 }
 
+// GetEvaluationReferences calls GetEvaluationReferencesFunc.
+func (mock *PhaseItemMock) GetEvaluationReferences(checkType apicommon.CheckType) []apilifecycle.EvaluationReference {
+	if mock.GetEvaluationReferencesFunc == nil {
+		panic("PhaseItemMock.GetEvaluationReferencesFunc: method is nil but PhaseItem.GetEvaluationReferences was just called")
+	}
+	callInfo := struct {
+		CheckType apicommon.CheckType
+	}{
+		CheckType: checkType,
+	}
+	mock.lockGetEvaluationReferences.Lock()
+	mock.calls.GetEvaluationReferences = append(mock.calls.GetEvaluationReferences, callInfo)
+	mock.lockGetEvaluationReferences.Unlock()
+	return mock.GetEvaluationReferencesFunc(checkType)
+}
+
+// GetEvaluationReferencesCalls gets all the calls that were made to GetEvaluationReferences.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetEvaluationReferencesCalls())
+func (mock *PhaseItemMock) GetEvaluationReferencesCalls() []struct {
+	CheckType apicommon.CheckType
+} {
+	var calls []struct {
+		CheckType apicommon.CheckType
+	}
+	mock.lockGetEvaluationReferences.RLock()
+	calls = mock.calls.GetEvaluationReferences
+	mock.lockGetEvaluationReferences.RUnlock()
+	return calls
+}
+
 // GetNamespace calls GetNamespaceFunc.
 func (mock *PhaseItemMock) GetNamespace() string {
 	if mock.GetNamespaceFunc == nil {
@@ -970,6 +1188,38 @@ func (mock *PhaseItemMock) GetStateCalls() []struct {
 	return calls
 }
 
+// GetTaskReferences calls GetTaskReferencesFunc.
+func (mock *PhaseItemMock) GetTaskReferences(checkType apicommon.CheckType) []apilifecycle.TaskReference {
+	if mock.GetTaskReferencesFunc == nil {
+		panic("PhaseItemMock.GetTaskReferencesFunc: method is nil but PhaseItem.GetTaskReferences was just called")
+	}
+	callInfo := struct {
+		CheckType apicommon.CheckType
+	}{
+		CheckType: checkType,
+	}
+	mock.lockGetTaskReferences.Lock()
+	mock.calls.GetTaskReferences = append(mock.calls.GetTaskReferences, callInfo)
+	mock.lockGetTaskReferences.Unlock()
+	return mock.GetTaskReferencesFunc(checkType)
+}
+
+// GetTaskReferencesCalls gets all the calls that were made to GetTaskReferences.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetTaskReferencesCalls())
+func (mock *PhaseItemMock) GetTaskReferencesCalls() []struct {
+	CheckType apicommon.CheckType
+} {
+	var calls []struct {
+		CheckType apicommon.CheckType
+	}
+	mock.lockGetTaskReferences.RLock()
+	calls = mock.calls.GetTaskReferences
+	mock.lockGetTaskReferences.RUnlock()
+	return calls
+}
+
 // GetVersion calls GetVersionFunc.
 func (mock *PhaseItemMock) GetVersion() string {
 	if mock.GetVersionFunc == nil {
@@ -34,10 +34,16 @@ type PhaseItem interface {
 	GetPreDeploymentTaskStatus() []apilifecycle.ItemStatus
 	GetPostDeploymentTaskStatus() []apilifecycle.ItemStatus
 	GetPromotionTaskStatus() []apilifecycle.ItemStatus
+	GetTaskReferences(checkType apicommon.CheckType) []apilifecycle.TaskReference
 	GetPreDeploymentEvaluations() []string
 	GetPostDeploymentEvaluations() []string
+	GetEvaluationReferences(checkType apicommon.CheckType) []apilifecycle.EvaluationReference
 	GetPreDeploymentEvaluationTaskStatus() []apilifecycle.ItemStatus
 	GetPostDeploymentEvaluationTaskStatus() []apilifecycle.ItemStatus
+	GetAnalyses(checkType apicommon.CheckType) []string
+	GetAnalysisStatus(checkType apicommon.CheckType) []apilifecycle.ItemStatus
+	GetAnalysisTimeframe() time.Duration
+	GetDeploymentEndTime() time.Time
 	GenerateTask(taskDefinition apilifecycle.KeptnTaskDefinition, checkType apicommon.CheckType) apilifecycle.KeptnTask
 	GenerateEvaluation(evaluationDefinition apilifecycle.KeptnEvaluationDefinition, checkType apicommon.CheckType) apilifecycle.KeptnEvaluation
 	GetSpanAttributes() []attribute.KeyValue
@@ -168,3 +174,27 @@ func (pw PhaseItemWrapper) GetPromotionTasks() []string {
 func (pw PhaseItemWrapper) GetPromotionTaskStatus() []apilifecycle.ItemStatus {
 	return pw.Obj.GetPromotionTaskStatus()
 }
+
+func (pw PhaseItemWrapper) GetTaskReferences(checkType apicommon.CheckType) []apilifecycle.TaskReference {
+	return pw.Obj.GetTaskReferences(checkType)
+}
+
+func (pw PhaseItemWrapper) GetEvaluationReferences(checkType apicommon.CheckType) []apilifecycle.EvaluationReference {
+	return pw.Obj.GetEvaluationReferences(checkType)
+}
+
+func (pw PhaseItemWrapper) GetAnalyses(checkType apicommon.CheckType) []string {
+	return pw.Obj.GetAnalyses(checkType)
+}
+
+func (pw PhaseItemWrapper) GetAnalysisStatus(checkType apicommon.CheckType) []apilifecycle.ItemStatus {
+	return pw.Obj.GetAnalysisStatus(checkType)
+}
+
+func (pw PhaseItemWrapper) GetAnalysisTimeframe() time.Duration {
+	return pw.Obj.GetAnalysisTimeframe()
+}
+
+func (pw PhaseItemWrapper) GetDeploymentEndTime() time.Time {
+	return pw.Obj.GetDeploymentEndTime()
+}
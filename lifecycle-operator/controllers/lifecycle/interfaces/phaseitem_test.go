@@ -98,6 +98,24 @@ func TestPhaseItem(t *testing.T) {
 		GetPromotionTasksFunc: func() []string {
 			return []string{}
 		},
+		GetTaskReferencesFunc: func(checkType apicommon.CheckType) []apilifecycle.TaskReference {
+			return nil
+		},
+		GetEvaluationReferencesFunc: func(checkType apicommon.CheckType) []apilifecycle.EvaluationReference {
+			return nil
+		},
+		GetAnalysesFunc: func(checkType apicommon.CheckType) []string {
+			return nil
+		},
+		GetAnalysisStatusFunc: func(checkType apicommon.CheckType) []apilifecycle.ItemStatus {
+			return nil
+		},
+		GetAnalysisTimeframeFunc: func() time.Duration {
+			return 0
+		},
+		GetDeploymentEndTimeFunc: func() time.Time {
+			return time.Now().UTC()
+		},
 		GetPromotionTaskStatusFunc: func() []apilifecycle.ItemStatus {
 			return []apilifecycle.ItemStatus{}
 		},
@@ -199,4 +217,22 @@ func TestPhaseItem(t *testing.T) {
 	_ = wrapper.GetPromotionTasks()
 	require.Len(t, phaseItemMock.GetPromotionTasksCalls(), 1)
 
+	_ = wrapper.GetTaskReferences(apicommon.PreDeploymentCheckType)
+	require.Len(t, phaseItemMock.GetTaskReferencesCalls(), 1)
+
+	_ = wrapper.GetEvaluationReferences(apicommon.PreDeploymentEvaluationCheckType)
+	require.Len(t, phaseItemMock.GetEvaluationReferencesCalls(), 1)
+
+	_ = wrapper.GetAnalyses(apicommon.PreDeploymentEvaluationCheckType)
+	require.Len(t, phaseItemMock.GetAnalysesCalls(), 1)
+
+	_ = wrapper.GetAnalysisStatus(apicommon.PreDeploymentEvaluationCheckType)
+	require.Len(t, phaseItemMock.GetAnalysisStatusCalls(), 1)
+
+	_ = wrapper.GetAnalysisTimeframe()
+	require.Len(t, phaseItemMock.GetAnalysisTimeframeCalls(), 1)
+
+	_ = wrapper.GetDeploymentEndTime()
+	require.Len(t, phaseItemMock.GetDeploymentEndTimeCalls(), 1)
+
 }
@@ -0,0 +1,153 @@
+package keptnapproval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/auth"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnapprovals,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnappversions,verbs=get
+
+// CallbackRequest is the payload expected by the approval callback endpoints
+type CallbackRequest struct {
+	Namespace  string `json:"namespace"`
+	AppVersion string `json:"appVersion"`
+	Comment    string `json:"comment,omitempty"`
+}
+
+// CallbackServer exposes HTTP endpoints that allow external systems to approve or reject
+// a KeptnAppVersion waiting in its approval phase by creating a KeptnApproval.
+// The caller has to authenticate with a bearer token and is recorded as the approver,
+// so that each identity is counted only once towards the required approvals.
+// The endpoints are served via HTTPS with the certificate found in certDir.
+type CallbackServer struct {
+	client  client.Client
+	log     logr.Logger
+	port    int
+	certDir string
+	auth    *auth.Authenticator
+}
+
+func NewCallbackServer(client client.Client, log logr.Logger, port int, certDir string) *CallbackServer {
+	return &CallbackServer{
+		client:  client,
+		log:     log,
+		port:    port,
+		certDir: certDir,
+		auth:    auth.NewAuthenticator(client, log),
+	}
+}
+
+// Start runs the HTTPS server until the given context is cancelled
+func (s *CallbackServer) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", s.port),
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: shutdownTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.log.Error(err, "could not shut down approval callback server")
+		}
+	}()
+
+	s.log.Info("serving approval callbacks", "port", s.port)
+	if err := auth.ListenAndServeTLS(ctx, s.log, server, s.certDir); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection implements LeaderElectionRunnable, the callbacks are served by all replicas
+func (s *CallbackServer) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the http.Handler serving the approval callback endpoints
+func (s *CallbackServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /approve", s.handle(apilifecycle.ApprovalDecisionApproved))
+	mux.HandleFunc("POST /reject", s.handle(apilifecycle.ApprovalDecisionRejected))
+	return s.auth.Middleware(mux)
+}
+
+func (s *CallbackServer) handle(decision apilifecycle.ApprovalDecision) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		request := CallbackRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("could not decode request: %s", err), http.StatusBadRequest)
+			return
+		}
+		if request.Namespace == "" || request.AppVersion == "" {
+			http.Error(w, "namespace and appVersion are required", http.StatusBadRequest)
+			return
+		}
+
+		user := auth.UserFrom(r.Context())
+		allowed, err := s.auth.Authorize(r.Context(), user, "create", "keptnapprovals", "", request.Namespace)
+		if err != nil {
+			s.log.Error(err, "could not review access of approver", "approver", user.Username)
+			http.Error(w, "could not review access", http.StatusInternalServerError)
+			return
+		}
+		if !allowed {
+			http.Error(w, fmt.Sprintf("%s is not allowed to create KeptnApprovals in namespace %s", user.Username, request.Namespace), http.StatusForbidden)
+			return
+		}
+
+		appVersion := &apilifecycle.KeptnAppVersion{}
+		if err := s.client.Get(r.Context(), types.NamespacedName{Namespace: request.Namespace, Name: request.AppVersion}, appVersion); err != nil {
+			if k8serrors.IsNotFound(err) {
+				http.Error(w, fmt.Sprintf("KeptnAppVersion %s not found", request.AppVersion), http.StatusNotFound)
+				return
+			}
+			s.log.Error(err, "could not retrieve KeptnAppVersion", "appVersion", request.AppVersion)
+			http.Error(w, "could not retrieve KeptnAppVersion", http.StatusInternalServerError)
+			return
+		}
+
+		approval := NewApproval(appVersion, user.Username, request, decision)
+		if err := s.client.Create(r.Context(), approval); err != nil {
+			s.log.Error(err, "could not create KeptnApproval", "appVersion", request.AppVersion)
+			http.Error(w, "could not create KeptnApproval", http.StatusInternalServerError)
+			return
+		}
+
+		s.log.Info("created KeptnApproval", "appVersion", appVersion.Name, "approver", user.Username, "decision", decision)
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+// NewApproval returns a KeptnApproval recording the decision of the given approver for the given KeptnAppVersion
+func NewApproval(appVersion *apilifecycle.KeptnAppVersion, approver string, request CallbackRequest, decision apilifecycle.ApprovalDecision) *apilifecycle.KeptnApproval {
+	return &apilifecycle.KeptnApproval{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: appVersion.Name + "-",
+			Namespace:    appVersion.Namespace,
+		},
+		Spec: apilifecycle.KeptnApprovalSpec{
+			AppVersion: appVersion.Name,
+			Approver:   approver,
+			Decision:   decision,
+			Comment:    request.Comment,
+		},
+	}
+}
@@ -0,0 +1,150 @@
+package keptnapproval
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCallbackServer(t *testing.T) {
+	appVersion := &apilifecycle.KeptnAppVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app-1.0.0",
+			Namespace: "default",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		token        string
+		body         string
+		wantCode     int
+		wantApprover string
+		wantDecision apilifecycle.ApprovalDecision
+	}{
+		{
+			name:         "approve",
+			path:         "/approve",
+			token:        "alice-token",
+			body:         `{"namespace":"default","appVersion":"my-app-1.0.0","comment":"lgtm"}`,
+			wantCode:     http.StatusCreated,
+			wantApprover: "alice",
+			wantDecision: apilifecycle.ApprovalDecisionApproved,
+		},
+		{
+			name:         "reject",
+			path:         "/reject",
+			token:        "alice-token",
+			body:         `{"namespace":"default","appVersion":"my-app-1.0.0"}`,
+			wantCode:     http.StatusCreated,
+			wantApprover: "alice",
+			wantDecision: apilifecycle.ApprovalDecisionRejected,
+		},
+		{
+			name:         "approver of the body is ignored",
+			path:         "/approve",
+			token:        "alice-token",
+			body:         `{"namespace":"default","appVersion":"my-app-1.0.0","approver":"bob"}`,
+			wantCode:     http.StatusCreated,
+			wantApprover: "alice",
+			wantDecision: apilifecycle.ApprovalDecisionApproved,
+		},
+		{
+			name:     "missing token",
+			path:     "/approve",
+			body:     `{"namespace":"default","appVersion":"my-app-1.0.0"}`,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "invalid token",
+			path:     "/approve",
+			token:    "wrong",
+			body:     `{"namespace":"default","appVersion":"my-app-1.0.0"}`,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "approver without access to the namespace",
+			path:     "/approve",
+			token:    "bob-token",
+			body:     `{"namespace":"default","appVersion":"my-app-1.0.0"}`,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "missing app version",
+			path:     "/approve",
+			token:    "alice-token",
+			body:     `{"namespace":"default"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid body",
+			path:     "/approve",
+			token:    "alice-token",
+			body:     `{`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown app version",
+			path:     "/approve",
+			token:    "alice-token",
+			body:     `{"namespace":"default","appVersion":"other-app-1.0.0"}`,
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := newTestClient(appVersion)
+			server := NewCallbackServer(fakeClient, ctrl.Log.WithName("test"), 8082, "")
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantCode, rec.Code)
+
+			approvals := &apilifecycle.KeptnApprovalList{}
+			require.Nil(t, fakeClient.List(context.TODO(), approvals, client.InNamespace("default")))
+			if tt.wantCode != http.StatusCreated {
+				require.Empty(t, approvals.Items)
+				return
+			}
+			require.Len(t, approvals.Items, 1)
+			require.Equal(t, "my-app-1.0.0", approvals.Items[0].Spec.AppVersion)
+			require.Equal(t, tt.wantApprover, approvals.Items[0].Spec.Approver)
+			require.Equal(t, tt.wantDecision, approvals.Items[0].Spec.Decision)
+		})
+	}
+}
+
+func TestCallbackServer_MethodNotAllowed(t *testing.T) {
+	server := NewCallbackServer(newTestClient(), ctrl.Log.WithName("test"), 8082, "")
+
+	req := httptest.NewRequest(http.MethodGet, "/approve", nil)
+	req.Header.Set("Authorization", "Bearer alice-token")
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+// newTestClient authenticates alice and bob, only alice may create KeptnApprovals in the default namespace
+func newTestClient(objs ...client.Object) client.Client {
+	tokens := map[string]string{"alice-token": "alice", "bob-token": "bob"}
+	return testcommon.NewTestClientWithReviews(tokens, func(user string, attributes *authorizationv1.ResourceAttributes) bool {
+		return user == "alice" && attributes.Verb == "create" && attributes.Resource == "keptnapprovals" && attributes.Namespace == "default"
+	}, objs...)
+}
@@ -68,6 +68,11 @@ type KeptnAppVersionReconciler struct {
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnappversions/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnappversions/finalizers,verbs=update
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnworkloadversions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnapprovals,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metrics.keptn.sh,resources=analyses,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=metrics.keptn.sh,resources=analysisdefinitions,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -168,6 +173,20 @@ func (r *KeptnAppVersionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			return r.reconcilePrePostEvaluation(ctx, phaseCtx, appVersion, apicommon.PostDeploymentEvaluationCheckType)
 		}
 		result, err := r.PhaseHandler.HandlePhase(ctx, ctxAppTrace, r.getTracer(), appVersion, currentPhase, reconcilePostEval)
+		if !result.Continue {
+			if appVersion.IsPostDeploymentEvaluationFailed() && appVersion.IsRollbackEnabled() && !appVersion.IsRollbackCompleted() {
+				return r.reconcileRollback(ctx, ctxAppTrace, appVersion)
+			}
+			return result.Result, err
+		}
+	}
+
+	if appVersion.IsApprovalRequired() && !appVersion.IsApprovalCompleted() {
+		currentPhase = apicommon.PhaseAppApproval
+		reconcileApprovalFunc := func(phaseCtx context.Context) (apicommon.KeptnState, error) {
+			return r.reconcileApproval(ctx, appVersion)
+		}
+		result, err := r.PhaseHandler.HandlePhase(ctx, ctxAppTrace, r.getTracer(), appVersion, currentPhase, reconcileApprovalFunc)
 		if !result.Continue {
 			return result.Result, err
 		}
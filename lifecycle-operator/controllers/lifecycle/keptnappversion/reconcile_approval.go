@@ -0,0 +1,88 @@
+package keptnappversion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (r *KeptnAppVersionReconciler) reconcileApproval(ctx context.Context, appVersion *apilifecycle.KeptnAppVersion) (apicommon.KeptnState, error) {
+	r.Log.Info("Reconciling Approval")
+	phase := apicommon.PhaseAppApproval
+
+	if appVersion.Status.ApprovalStartTime.IsZero() {
+		appVersion.Status.ApprovalStartTime = metav1.NewTime(time.Now().UTC())
+	}
+
+	approvals, err := r.getApprovals(ctx, appVersion)
+	if err != nil {
+		r.Log.Error(err, "Could not retrieve approvals of appVersion", "appVersion", appVersion.Name)
+		return apicommon.StateUnknown, err
+	}
+
+	approvers := []string{}
+	state := apicommon.StateProgressing
+	for _, approval := range approvals {
+		if approval.IsRejected() {
+			r.EventSender.Emit(phase, "Warning", appVersion, apicommon.PhaseStateFailed, fmt.Sprintf("rejected by %s", approval.Spec.Approver), appVersion.GetVersion())
+			state = apicommon.StateFailed
+			continue
+		}
+		approvers = appendApprover(approvers, approval.Spec.Approver)
+	}
+	appVersion.Status.Approvers = approvers
+
+	if state != apicommon.StateFailed {
+		state = r.getApprovalState(appVersion)
+	}
+	appVersion.Status.ApprovalStatus = state
+
+	// Write Status Field
+	err = r.Client.Status().Update(ctx, appVersion)
+	return state, err
+}
+
+func (r *KeptnAppVersionReconciler) getApprovalState(appVersion *apilifecycle.KeptnAppVersion) apicommon.KeptnState {
+	if len(appVersion.Status.Approvers) >= appVersion.GetRequiredApprovals() {
+		return apicommon.StateSucceeded
+	}
+	timeout := appVersion.Spec.Approval.Timeout.Duration
+	if timeout > 0 && time.Since(appVersion.Status.ApprovalStartTime.Time) > timeout {
+		r.EventSender.Emit(apicommon.PhaseAppApproval, "Warning", appVersion, apicommon.PhaseStateFailed, fmt.Sprintf("received %d of %d required approvals within %s", len(appVersion.Status.Approvers), appVersion.GetRequiredApprovals(), timeout), appVersion.GetVersion())
+		return apicommon.StateFailed
+	}
+	return apicommon.StateProgressing
+}
+
+func (r *KeptnAppVersionReconciler) getApprovals(ctx context.Context, appVersion *apilifecycle.KeptnAppVersion) ([]apilifecycle.KeptnApproval, error) {
+	approvalList := &apilifecycle.KeptnApprovalList{}
+	if err := r.Client.List(ctx, approvalList, client.InNamespace(appVersion.Namespace)); err != nil {
+		return nil, err
+	}
+	approvals := make([]apilifecycle.KeptnApproval, 0, len(approvalList.Items))
+	for _, approval := range approvalList.Items {
+		if approval.Spec.AppVersion == appVersion.Name {
+			approvals = append(approvals, approval)
+		}
+	}
+	return approvals, nil
+}
+
+func appendApprover(approvers []string, approver string) []string {
+	approver = strings.TrimSpace(approver)
+	if approver == "" {
+		return approvers
+	}
+	for _, a := range approvers {
+		if a == approver {
+			return approvers
+		}
+	}
+	return append(approvers, approver)
+}
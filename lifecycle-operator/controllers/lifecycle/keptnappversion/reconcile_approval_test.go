@@ -0,0 +1,139 @@
+package keptnappversion
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestKeptnAppVersionReconciler_reconcileApproval(t *testing.T) {
+	tests := []struct {
+		name          string
+		approval      apilifecycle.ApprovalSpec
+		startTime     metav1.Time
+		approvals     []apilifecycle.KeptnApproval
+		wantState     apicommon.KeptnState
+		wantApprovers []string
+		wantEvent     string
+	}{
+		{
+			name:      "no approvals yet",
+			approval:  apilifecycle.ApprovalSpec{RequiredApprovals: 1},
+			wantState: apicommon.StateProgressing,
+		},
+		{
+			name:     "approved by distinct approvers",
+			approval: apilifecycle.ApprovalSpec{RequiredApprovals: 2},
+			approvals: []apilifecycle.KeptnApproval{
+				getApproval("approval-alice", "my-app-1.0.0", "alice", apilifecycle.ApprovalDecisionApproved),
+				getApproval("approval-alice-2", "my-app-1.0.0", "alice", apilifecycle.ApprovalDecisionApproved),
+				getApproval("approval-bob", "my-app-1.0.0", "bob", apilifecycle.ApprovalDecisionApproved),
+				getApproval("approval-other", "my-app-2.0.0", "carol", apilifecycle.ApprovalDecisionApproved),
+			},
+			wantState:     apicommon.StateSucceeded,
+			wantApprovers: []string{"alice", "bob"},
+		},
+		{
+			name:     "same approver counted once",
+			approval: apilifecycle.ApprovalSpec{RequiredApprovals: 2},
+			approvals: []apilifecycle.KeptnApproval{
+				getApproval("approval-alice", "my-app-1.0.0", "alice", apilifecycle.ApprovalDecisionApproved),
+				getApproval("approval-alice-2", "my-app-1.0.0", "alice", apilifecycle.ApprovalDecisionApproved),
+			},
+			wantState:     apicommon.StateProgressing,
+			wantApprovers: []string{"alice"},
+		},
+		{
+			name:     "not enough approvals",
+			approval: apilifecycle.ApprovalSpec{RequiredApprovals: 2},
+			approvals: []apilifecycle.KeptnApproval{
+				getApproval("approval-bob", "my-app-1.0.0", "bob", apilifecycle.ApprovalDecisionApproved),
+			},
+			wantState:     apicommon.StateProgressing,
+			wantApprovers: []string{"bob"},
+		},
+		{
+			name:     "rejected",
+			approval: apilifecycle.ApprovalSpec{RequiredApprovals: 2},
+			approvals: []apilifecycle.KeptnApproval{
+				getApproval("approval-alice", "my-app-1.0.0", "alice", apilifecycle.ApprovalDecisionApproved),
+				getApproval("approval-bob", "my-app-1.0.0", "bob", apilifecycle.ApprovalDecisionRejected),
+			},
+			wantState:     apicommon.StateFailed,
+			wantApprovers: []string{"alice"},
+			wantEvent:     "rejected by bob",
+		},
+		{
+			name: "timed out",
+			approval: apilifecycle.ApprovalSpec{
+				RequiredApprovals: 1,
+				Timeout:           metav1.Duration{Duration: time.Minute},
+			},
+			startTime: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+			wantState: apicommon.StateFailed,
+			wantEvent: "received 0 of 1 required approvals within 1m0s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approval := tt.approval
+			appVersion := &apilifecycle.KeptnAppVersion{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-app-1.0.0",
+					Namespace: "default",
+				},
+				Spec: apilifecycle.KeptnAppVersionSpec{
+					KeptnAppContextSpec: apilifecycle.KeptnAppContextSpec{
+						Approval: &approval,
+					},
+					AppName: "my-app",
+				},
+				Status: apilifecycle.KeptnAppVersionStatus{
+					ApprovalStartTime: tt.startTime,
+				},
+			}
+			objs := []client.Object{appVersion}
+			for i := range tt.approvals {
+				objs = append(objs, &tt.approvals[i])
+			}
+			r, eventChannel, _ := setupReconciler(objs...)
+
+			state, err := r.reconcileApproval(context.TODO(), appVersion)
+			require.Nil(t, err)
+			require.Equal(t, tt.wantState, state)
+
+			err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: appVersion.Namespace, Name: appVersion.Name}, appVersion)
+			require.Nil(t, err)
+			require.Equal(t, tt.wantState, appVersion.Status.ApprovalStatus)
+			require.Equal(t, tt.wantApprovers, appVersion.Status.Approvers)
+			require.False(t, appVersion.Status.ApprovalStartTime.IsZero())
+
+			if tt.wantEvent != "" {
+				require.True(t, strings.Contains(<-eventChannel, tt.wantEvent))
+			}
+		})
+	}
+}
+
+func getApproval(name string, appVersion string, approver string, decision apilifecycle.ApprovalDecision) apilifecycle.KeptnApproval {
+	return apilifecycle.KeptnApproval{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: apilifecycle.KeptnApprovalSpec{
+			AppVersion: appVersion,
+			Approver:   approver,
+			Decision:   decision,
+		},
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/analysis"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/evaluation"
 )
 
@@ -29,15 +30,29 @@ func (r *KeptnAppVersionReconciler) reconcilePrePostEvaluation(ctx context.Conte
 		return apicommon.StateUnknown, err
 	}
 
-	overallState := apicommon.GetOverallStateBlockedDeployment(state, r.Config.GetBlockDeployment())
+	analysisHandler := analysis.NewHandler(
+		r.Client,
+		r.EventSender,
+		r.Log,
+		r.Client.Scheme(),
+	)
+
+	newAnalysisStatus, analysisState, err := analysisHandler.ReconcileAnalyses(ctx, appVersion, checkType)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+
+	overallState := apicommon.GetOverallEvaluationState(apicommon.MergeStatusSummaries(state, analysisState), r.Config.GetBlockDeployment())
 
 	switch checkType {
 	case apicommon.PreDeploymentEvaluationCheckType:
 		appVersion.Status.PreDeploymentEvaluationStatus = overallState
 		appVersion.Status.PreDeploymentEvaluationTaskStatus = newStatus
+		appVersion.Status.PreDeploymentAnalysisStatus = newAnalysisStatus
 	case apicommon.PostDeploymentEvaluationCheckType:
 		appVersion.Status.PostDeploymentEvaluationStatus = overallState
 		appVersion.Status.PostDeploymentEvaluationTaskStatus = newStatus
+		appVersion.Status.PostDeploymentAnalysisStatus = newAnalysisStatus
 	}
 
 	// Write Status Field
@@ -0,0 +1,205 @@
+package keptnappversion
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"go.opentelemetry.io/otel/codes"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileRollback rolls back the workloads of a failed KeptnAppVersion to the state
+// they had in the last succeeded KeptnAppVersion of the previous version.
+// Transient errors, e.g. conflicting updates of a workload, are retried,
+// while the rollback fails if it cannot succeed at all, e.g. because the previous revision does not exist anymore.
+func (r *KeptnAppVersionReconciler) reconcileRollback(ctx context.Context, ctxTrace context.Context, appVersion *apilifecycle.KeptnAppVersion) (ctrl.Result, error) {
+	phase := apicommon.PhaseAppRollback
+
+	if appVersion.Status.CurrentPhase != phase.ShortName {
+		r.EventSender.Emit(phase, "Normal", appVersion, apicommon.PhaseStateStarted, "has started", appVersion.GetVersion())
+		appVersion.Status.CurrentPhase = phase.ShortName
+	}
+
+	_, spanRollbackTrace, err := r.SpanHandler.GetSpan(ctxTrace, r.getTracer(), appVersion, phase.ShortName)
+	if err != nil {
+		r.Log.Error(err, "could not get span")
+	}
+
+	err = r.rollbackWorkloads(ctx, appVersion)
+	if err != nil && !isPermanentRollbackError(err) {
+		r.Log.Error(err, "could not roll back KeptnAppVersion, retrying", "appVersion", appVersion.Name)
+		spanRollbackTrace.AddEvent(phase.LongName + " will be retried: " + err.Error())
+		appVersion.Status.RollbackStatus = apicommon.StateProgressing
+		if err := r.Client.Status().Update(ctx, appVersion); err != nil {
+			return ctrl.Result{Requeue: true}, err
+		}
+		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+	}
+
+	if err != nil {
+		r.Log.Error(err, "could not roll back KeptnAppVersion", "appVersion", appVersion.Name)
+		appVersion.Status.RollbackStatus = apicommon.StateFailed
+		spanRollbackTrace.AddEvent(phase.LongName + " has failed")
+		spanRollbackTrace.SetStatus(codes.Error, "Failed")
+		r.EventSender.Emit(phase, "Warning", appVersion, apicommon.PhaseStateFailed, fmt.Sprintf("has failed: %s", err.Error()), appVersion.GetVersion())
+	} else {
+		appVersion.Status.RollbackStatus = apicommon.StateSucceeded
+		spanRollbackTrace.AddEvent(phase.LongName + " has succeeded")
+		spanRollbackTrace.SetStatus(codes.Ok, "Succeeded")
+		r.EventSender.Emit(phase, "Normal", appVersion, apicommon.PhaseStateFinished, fmt.Sprintf("has finished: rolled back to version %s", appVersion.Spec.PreviousVersion), appVersion.GetVersion())
+	}
+	spanRollbackTrace.End()
+	if err := r.SpanHandler.UnbindSpan(appVersion, phase.ShortName); err != nil {
+		r.Log.Error(err, controllererrors.ErrCouldNotUnbindSpan, appVersion.Name)
+	}
+
+	if err := r.Client.Status().Update(ctx, appVersion); err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *KeptnAppVersionReconciler) rollbackWorkloads(ctx context.Context, appVersion *apilifecycle.KeptnAppVersion) error {
+	previousAppVersion, err := r.getPreviousAppVersion(ctx, appVersion)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range previousAppVersion.Spec.Workloads {
+		if isWorkloadPartOfAppVersion(w, appVersion) {
+			// the workload has not been changed with the new version, so there is nothing to roll back
+			continue
+		}
+		workloadVersion := &apilifecycle.KeptnWorkloadVersion{}
+		workloadVersionName := getWorkloadVersionName(previousAppVersion.Spec.AppName, w.Name, w.Version)
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: appVersion.Namespace, Name: workloadVersionName}, workloadVersion); err != nil {
+			return fmt.Errorf(controllererrors.ErrCannotRetrieveWorkloadVersionMsg, err)
+		}
+		r.Log.Info("Rolling back workload", "workloadVersion", workloadVersion.Name, "resource", workloadVersion.Spec.ResourceReference.Name)
+		if err := r.rollbackResource(ctx, workloadVersion); err != nil {
+			return fmt.Errorf("could not roll back workload %s: %w", w.Name, err)
+		}
+	}
+	return nil
+}
+
+// getPreviousAppVersion returns the most recent succeeded KeptnAppVersion matching the previous version of the given KeptnAppVersion
+func (r *KeptnAppVersionReconciler) getPreviousAppVersion(ctx context.Context, appVersion *apilifecycle.KeptnAppVersion) (*apilifecycle.KeptnAppVersion, error) {
+	appVersionList := &apilifecycle.KeptnAppVersionList{}
+	if err := r.Client.List(ctx, appVersionList, client.InNamespace(appVersion.Namespace)); err != nil {
+		return nil, err
+	}
+
+	var previousAppVersion *apilifecycle.KeptnAppVersion
+	for i := range appVersionList.Items {
+		item := &appVersionList.Items[i]
+		if item.Spec.AppName != appVersion.Spec.AppName || item.Spec.Version != appVersion.Spec.PreviousVersion || !item.Status.Status.IsSucceeded() {
+			continue
+		}
+		if previousAppVersion == nil || previousAppVersion.CreationTimestamp.Before(&item.CreationTimestamp) {
+			previousAppVersion = item
+		}
+	}
+
+	if previousAppVersion == nil {
+		return nil, controllererrors.ErrNoPreviousAppVersionFound
+	}
+	return previousAppVersion, nil
+}
+
+// rollbackResource restores the state in which the resource referenced by the given KeptnWorkloadVersion has been deployed
+func (r *KeptnAppVersionReconciler) rollbackResource(ctx context.Context, workloadVersion *apilifecycle.KeptnWorkloadVersion) error {
+	namespace := workloadVersion.Namespace
+	ref := workloadVersion.Spec.ResourceReference
+	switch ref.Kind {
+	case "ReplicaSet":
+		return r.rollbackReplicaSetOwner(ctx, namespace, ref.Name)
+	case "StatefulSet":
+		sts := &appsv1.StatefulSet{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, sts); err != nil {
+			return err
+		}
+		return r.rollbackToRevision(ctx, sts, workloadVersion.Status.DeployedRevision)
+	case "DaemonSet":
+		ds := &appsv1.DaemonSet{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, ds); err != nil {
+			return err
+		}
+		return r.rollbackToRevision(ctx, ds, workloadVersion.Status.DeployedRevision)
+	default:
+		return controllererrors.ErrUnsupportedWorkloadVersionResourceReference
+	}
+}
+
+// rollbackReplicaSetOwner restores the pod template of the given ReplicaSet in the Deployment owning it
+func (r *KeptnAppVersionReconciler) rollbackReplicaSetOwner(ctx context.Context, namespace string, name string) error {
+	rs := &appsv1.ReplicaSet{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, rs); err != nil {
+		return err
+	}
+
+	owner := metav1.GetControllerOf(rs)
+	if owner == nil || owner.Kind != "Deployment" {
+		return controllererrors.ErrUnsupportedWorkloadVersionResourceReference
+	}
+
+	dep := &appsv1.Deployment{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: owner.Name}, dep); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(dep.DeepCopy())
+	template := rs.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	dep.Spec.Template = *template
+	return r.Client.Patch(ctx, dep, patch)
+}
+
+// rollbackToRevision applies the given ControllerRevision of the StatefulSet or DaemonSet,
+// i.e. the revision that has been deployed with the previous KeptnWorkloadVersion
+func (r *KeptnAppVersionReconciler) rollbackToRevision(ctx context.Context, obj client.Object, revisionName string) error {
+	if revisionName == "" {
+		return controllererrors.ErrNoPreviousRevisionFound
+	}
+	rev := &appsv1.ControllerRevision{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: revisionName}, rev); err != nil {
+		if errors.IsNotFound(err) {
+			return controllererrors.ErrNoPreviousRevisionFound
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(rev, obj) {
+		return controllererrors.ErrNoPreviousRevisionFound
+	}
+	return r.Client.Patch(ctx, obj, client.RawPatch(types.StrategicMergePatchType, rev.Data.Raw))
+}
+
+// isPermanentRollbackError returns true if retrying the rollback cannot resolve the given error,
+// e.g. because there is no previous revision or the rolled back resource does not exist anymore
+func isPermanentRollbackError(err error) bool {
+	return goerrors.Is(err, controllererrors.ErrNoPreviousAppVersionFound) ||
+		goerrors.Is(err, controllererrors.ErrNoPreviousRevisionFound) ||
+		goerrors.Is(err, controllererrors.ErrUnsupportedWorkloadVersionResourceReference) ||
+		errors.IsNotFound(err) ||
+		errors.IsInvalid(err) ||
+		errors.IsBadRequest(err)
+}
+
+func isWorkloadPartOfAppVersion(workload apilifecycle.KeptnWorkloadRef, appVersion *apilifecycle.KeptnAppVersion) bool {
+	for _, w := range appVersion.Spec.Workloads {
+		if w.Name == workload.Name && w.Version == workload.Version {
+			return true
+		}
+	}
+	return false
+}
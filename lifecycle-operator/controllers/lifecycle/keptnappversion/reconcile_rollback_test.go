@@ -0,0 +1,323 @@
+package keptnappversion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestKeptnAppVersionReconciler_reconcileRollback_Deployment(t *testing.T) {
+	current, previous := getRollbackAppVersions()
+	workloadVersion := getRollbackWorkloadVersion(apilifecycle.ResourceReference{Kind: "ReplicaSet", Name: "my-deployment-old"})
+
+	rs, dep := getRollbackDeployment()
+
+	r, eventChannel, _ := setupReconciler(current, previous, workloadVersion, rs, dep)
+
+	result, err := r.reconcileRollback(context.TODO(), context.TODO(), current)
+	require.Nil(t, err)
+	require.False(t, result.Requeue)
+	require.Equal(t, apicommon.StateSucceeded, current.Status.RollbackStatus)
+	require.Equal(t, apicommon.PhaseAppRollback.ShortName, current.Status.CurrentPhase)
+
+	updatedDep := &appsv1.Deployment{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-deployment"}, updatedDep)
+	require.Nil(t, err)
+	require.Equal(t, "nginx:1.0", updatedDep.Spec.Template.Spec.Containers[0].Image)
+	require.NotContains(t, updatedDep.Spec.Template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	require.True(t, strings.Contains(<-eventChannel, "AppRollbackStarted"))
+	require.True(t, strings.Contains(<-eventChannel, "AppRollbackFinished"))
+}
+
+func TestKeptnAppVersionReconciler_reconcileRollback_StatefulSet(t *testing.T) {
+	current, previous := getRollbackAppVersions()
+	workloadVersion := getRollbackWorkloadVersion(apilifecycle.ResourceReference{Kind: "StatefulSet", Name: "my-sts"})
+	workloadVersion.Status.DeployedRevision = "my-sts-1"
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-sts",
+			Namespace: "default",
+			UID:       "sts-uid",
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Template: getPodTemplate("nginx:2.0", map[string]string{"app": "my-workload"}),
+		},
+		Status: appsv1.StatefulSetStatus{
+			UpdateRevision: "my-sts-3",
+		},
+	}
+
+	// the revision preceding the current one has not been deployed with the previous version
+	r, _, _ := setupReconciler(
+		current,
+		previous,
+		workloadVersion,
+		sts,
+		getControllerRevision("my-sts-1", 1, sts, `{"spec":{"template":{"metadata":{"labels":{"app":"my-workload"}},"spec":{"containers":[{"name":"app","image":"nginx:1.0"}]},"$patch":"replace"}}}`),
+		getControllerRevision("my-sts-2", 2, sts, `{"spec":{"template":{"metadata":{"labels":{"app":"my-workload"}},"spec":{"containers":[{"name":"app","image":"nginx:1.5"}]},"$patch":"replace"}}}`),
+		getControllerRevision("my-sts-3", 3, sts, `{"spec":{"template":{"metadata":{"labels":{"app":"my-workload"}},"spec":{"containers":[{"name":"app","image":"nginx:2.0"}]},"$patch":"replace"}}}`),
+	)
+
+	_, err := r.reconcileRollback(context.TODO(), context.TODO(), current)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateSucceeded, current.Status.RollbackStatus)
+
+	updatedSts := &appsv1.StatefulSet{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-sts"}, updatedSts)
+	require.Nil(t, err)
+	require.Equal(t, "nginx:1.0", updatedSts.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestKeptnAppVersionReconciler_reconcileRollback_StatefulSetWithoutDeployedRevision(t *testing.T) {
+	current, previous := getRollbackAppVersions()
+	workloadVersion := getRollbackWorkloadVersion(apilifecycle.ResourceReference{Kind: "StatefulSet", Name: "my-sts"})
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-sts",
+			Namespace: "default",
+			UID:       "sts-uid",
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Template: getPodTemplate("nginx:2.0", map[string]string{"app": "my-workload"}),
+		},
+	}
+
+	r, eventChannel, _ := setupReconciler(
+		current,
+		previous,
+		workloadVersion,
+		sts,
+		getControllerRevision("my-sts-1", 1, sts, `{"spec":{"template":{"metadata":{"labels":{"app":"my-workload"}},"spec":{"containers":[{"name":"app","image":"nginx:1.0"}]},"$patch":"replace"}}}`),
+		getControllerRevision("my-sts-2", 2, sts, `{"spec":{"template":{"metadata":{"labels":{"app":"my-workload"}},"spec":{"containers":[{"name":"app","image":"nginx:2.0"}]},"$patch":"replace"}}}`),
+	)
+
+	_, err := r.reconcileRollback(context.TODO(), context.TODO(), current)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateFailed, current.Status.RollbackStatus)
+
+	require.True(t, strings.Contains(<-eventChannel, "AppRollbackStarted"))
+	require.True(t, strings.Contains(<-eventChannel, controllererrors.ErrNoPreviousRevisionFound.Error()))
+
+	updatedSts := &appsv1.StatefulSet{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-sts"}, updatedSts)
+	require.Nil(t, err)
+	require.Equal(t, "nginx:2.0", updatedSts.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestKeptnAppVersionReconciler_reconcileRollback_NoPreviousAppVersion(t *testing.T) {
+	current, _ := getRollbackAppVersions()
+
+	r, eventChannel, _ := setupReconciler(current)
+
+	_, err := r.reconcileRollback(context.TODO(), context.TODO(), current)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateFailed, current.Status.RollbackStatus)
+
+	require.True(t, strings.Contains(<-eventChannel, "AppRollbackStarted"))
+	event := <-eventChannel
+	require.True(t, strings.Contains(event, "AppRollbackFailed"))
+	require.True(t, strings.Contains(event, controllererrors.ErrNoPreviousAppVersionFound.Error()))
+}
+
+func TestKeptnAppVersionReconciler_reconcileRollback_Conflict(t *testing.T) {
+	current, previous := getRollbackAppVersions()
+	workloadVersion := getRollbackWorkloadVersion(apilifecycle.ResourceReference{Kind: "ReplicaSet", Name: "my-deployment-old"})
+	rs, dep := getRollbackDeployment()
+
+	r, eventChannel, _ := setupReconciler(current, previous, workloadVersion, rs, dep)
+	fakeClient := r.Client
+	r.Client = interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			return apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, obj.GetName(), fmt.Errorf("the object has been modified"))
+		},
+	})
+
+	// the rollback is retried after a conflicting update of the Deployment
+	result, err := r.reconcileRollback(context.TODO(), context.TODO(), current)
+	require.Nil(t, err)
+	require.True(t, result.Requeue)
+	require.Equal(t, apicommon.StateProgressing, current.Status.RollbackStatus)
+	require.False(t, current.IsRollbackCompleted())
+	require.True(t, strings.Contains(<-eventChannel, "AppRollbackStarted"))
+
+	r.Client = fakeClient
+	result, err = r.reconcileRollback(context.TODO(), context.TODO(), current)
+	require.Nil(t, err)
+	require.False(t, result.Requeue)
+	require.Equal(t, apicommon.StateSucceeded, current.Status.RollbackStatus)
+	require.True(t, strings.Contains(<-eventChannel, "AppRollbackFinished"))
+}
+
+func TestKeptnAppVersionReconciler_reconcileRollback_ReplicaSetNotFound(t *testing.T) {
+	current, previous := getRollbackAppVersions()
+	workloadVersion := getRollbackWorkloadVersion(apilifecycle.ResourceReference{Kind: "ReplicaSet", Name: "my-deployment-old"})
+	_, dep := getRollbackDeployment()
+
+	r, eventChannel, _ := setupReconciler(current, previous, workloadVersion, dep)
+
+	// the ReplicaSet of the previous version has been deleted, so the rollback cannot succeed
+	result, err := r.reconcileRollback(context.TODO(), context.TODO(), current)
+	require.Nil(t, err)
+	require.False(t, result.Requeue)
+	require.Equal(t, apicommon.StateFailed, current.Status.RollbackStatus)
+
+	require.True(t, strings.Contains(<-eventChannel, "AppRollbackStarted"))
+	require.True(t, strings.Contains(<-eventChannel, "AppRollbackFailed"))
+}
+
+func TestKeptnAppVersionReconciler_rollbackResource_Unsupported(t *testing.T) {
+	r, _, _ := setupReconciler()
+
+	err := r.rollbackResource(context.TODO(), getRollbackWorkloadVersion(apilifecycle.ResourceReference{Kind: "Pod", Name: "my-pod"}))
+	require.ErrorIs(t, err, controllererrors.ErrUnsupportedWorkloadVersionResourceReference)
+}
+
+func getRollbackDeployment() (*appsv1.ReplicaSet, *appsv1.Deployment) {
+	isController := true
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-deployment-old",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       "my-deployment",
+					UID:        "dep-uid",
+					Controller: &isController,
+				},
+			},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: getPodTemplate("nginx:1.0", map[string]string{
+				"app":                                  "my-workload",
+				appsv1.DefaultDeploymentUniqueLabelKey: "abcde",
+			}),
+		},
+	}
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-deployment",
+			Namespace: "default",
+			UID:       "dep-uid",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: getPodTemplate("nginx:2.0", map[string]string{"app": "my-workload"}),
+		},
+	}
+	return rs, dep
+}
+
+func getRollbackAppVersions() (*apilifecycle.KeptnAppVersion, *apilifecycle.KeptnAppVersion) {
+	current := &apilifecycle.KeptnAppVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app-2.0.0",
+			Namespace: "default",
+		},
+		Spec: apilifecycle.KeptnAppVersionSpec{
+			KeptnAppContextSpec: apilifecycle.KeptnAppContextSpec{
+				RollbackOnFailure: true,
+			},
+			KeptnAppSpec: apilifecycle.KeptnAppSpec{
+				Version: "2.0.0",
+				Workloads: []apilifecycle.KeptnWorkloadRef{
+					{Name: "my-workload", Version: "2.0.0"},
+				},
+			},
+			AppName:         "my-app",
+			PreviousVersion: "1.0.0",
+		},
+		Status: apilifecycle.KeptnAppVersionStatus{
+			PostDeploymentEvaluationStatus: apicommon.StateFailed,
+			Status:                         apicommon.StateFailed,
+		},
+	}
+	previous := &apilifecycle.KeptnAppVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app-1.0.0",
+			Namespace: "default",
+		},
+		Spec: apilifecycle.KeptnAppVersionSpec{
+			KeptnAppSpec: apilifecycle.KeptnAppSpec{
+				Version: "1.0.0",
+				Workloads: []apilifecycle.KeptnWorkloadRef{
+					{Name: "my-workload", Version: "1.0.0"},
+				},
+			},
+			AppName: "my-app",
+		},
+		Status: apilifecycle.KeptnAppVersionStatus{
+			Status: apicommon.StateSucceeded,
+		},
+	}
+	return current, previous
+}
+
+func getRollbackWorkloadVersion(ref apilifecycle.ResourceReference) *apilifecycle.KeptnWorkloadVersion {
+	return &apilifecycle.KeptnWorkloadVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getWorkloadVersionName("my-app", "my-workload", "1.0.0"),
+			Namespace: "default",
+		},
+		Spec: apilifecycle.KeptnWorkloadVersionSpec{
+			KeptnWorkloadSpec: apilifecycle.KeptnWorkloadSpec{
+				AppName:           "my-app",
+				Version:           "1.0.0",
+				ResourceReference: ref,
+			},
+			WorkloadName: "my-workload",
+		},
+	}
+}
+
+func getPodTemplate(image string, labels map[string]string) v1.PodTemplateSpec {
+	return v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: labels,
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "app", Image: image},
+			},
+		},
+	}
+}
+
+func getControllerRevision(name string, revision int64, owner client.Object, data string) *appsv1.ControllerRevision {
+	isController := true
+	return &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: owner.GetNamespace(),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "apps/v1",
+					Kind:       "StatefulSet",
+					Name:       owner.GetName(),
+					UID:        owner.GetUID(),
+					Controller: &isController,
+				},
+			},
+		},
+		Revision: revision,
+		Data:     runtime.RawExtension{Raw: []byte(data)},
+	}
+}
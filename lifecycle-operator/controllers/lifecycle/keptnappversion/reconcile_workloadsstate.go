@@ -53,6 +53,9 @@ func (r *KeptnAppVersionReconciler) reconcileWorkloads(ctx context.Context, appV
 
 	overallState := apicommon.GetOverallState(summary)
 	appVersion.Status.WorkloadOverallStatus = overallState
+	if overallState.IsCompleted() {
+		appVersion.SetWorkloadDeploymentEndTime()
+	}
 	r.Log.Info("Overall state of workloads", "state", appVersion.Status.WorkloadOverallStatus)
 
 	appVersion.Status.WorkloadStatus = newStatus
@@ -31,6 +31,7 @@ func TestKeptnAppVersionReconciler_reconcileWorkloads_noWorkloads(t *testing.T)
 	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: appVersion.Namespace, Name: appVersion.Name}, appVersion)
 	require.Nil(t, err)
 	require.Equal(t, apicommon.StateSucceeded, appVersion.Status.WorkloadOverallStatus)
+	require.False(t, appVersion.Status.WorkloadDeploymentEndTime.IsZero())
 	require.Len(t, appVersion.Status.WorkloadStatus, 0)
 }
 
@@ -64,6 +65,7 @@ func TestKeptnAppVersionReconciler_reconcileWorkloads(t *testing.T) {
 	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: appVersion.Namespace, Name: appVersion.Name}, appVersion)
 	require.Nil(t, err)
 	require.Equal(t, apicommon.StatePending, appVersion.Status.WorkloadOverallStatus)
+	require.True(t, appVersion.Status.WorkloadDeploymentEndTime.IsZero())
 	require.Len(t, appVersion.Status.WorkloadStatus, 1)
 	require.Equal(t, []apilifecycle.WorkloadStatus{
 		{
@@ -99,6 +101,7 @@ func TestKeptnAppVersionReconciler_reconcileWorkloads(t *testing.T) {
 	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: appVersion.Namespace, Name: appVersion.Name}, appVersion)
 	require.Nil(t, err)
 	require.Equal(t, apicommon.StatePending, appVersion.Status.WorkloadOverallStatus)
+	require.True(t, appVersion.Status.WorkloadDeploymentEndTime.IsZero())
 	require.Len(t, appVersion.Status.WorkloadStatus, 1)
 	require.Equal(t, []apilifecycle.WorkloadStatus{
 		{
@@ -168,6 +171,7 @@ func TestKeptnAppVersionReconciler_reconcileWorkloads(t *testing.T) {
 	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: appVersion.Namespace, Name: appVersion.Name}, appVersion)
 	require.Nil(t, err)
 	require.Equal(t, apicommon.StateSucceeded, appVersion.Status.WorkloadOverallStatus)
+	require.False(t, appVersion.Status.WorkloadDeploymentEndTime.IsZero())
 	require.Len(t, appVersion.Status.WorkloadStatus, 1)
 	require.Equal(t, []apilifecycle.WorkloadStatus{
 		{
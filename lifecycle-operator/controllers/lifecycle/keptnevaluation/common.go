@@ -6,34 +6,45 @@ import (
 	"strconv"
 
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/common/evaluationtarget"
 )
 
-func checkValue(objective apilifecycle.Objective, item *apilifecycle.EvaluationStatusItem) (bool, error) {
+func checkValue(objective apilifecycle.Objective, item *apilifecycle.EvaluationStatusItem, previousValue *float64) (bool, error) {
 
 	if len(item.Value) == 0 || len(objective.EvaluationTarget) == 0 {
 		return false, fmt.Errorf("no values")
 	}
 
-	eval := objective.EvaluationTarget[1:]
-	sign := objective.EvaluationTarget[:1]
-
 	resultValue, err := strconv.ParseFloat(item.Value, 64)
 	if err != nil || math.IsNaN(resultValue) {
 		return false, err
 	}
 
-	compareValue, err := strconv.ParseFloat(eval, 64)
-	if err != nil || math.IsNaN(compareValue) {
+	target, err := evaluationtarget.Parse(objective.EvaluationTarget)
+	if err != nil {
 		return false, err
 	}
 
-	// choose comparator
-	switch sign {
-	case ">":
-		return resultValue > compareValue, nil
-	case "<":
-		return resultValue < compareValue, nil
-	default:
-		return false, fmt.Errorf("invalid operator")
+	return target.Evaluate(resultValue, previousValue), nil
+}
+
+// isRelativeTarget checks whether the evaluation target of the given objective compares against the previous version
+func isRelativeTarget(objective apilifecycle.Objective) bool {
+	target, err := evaluationtarget.Parse(objective.EvaluationTarget)
+	return err == nil && target.IsRelative()
+}
+
+// isEvaluationOfPreviousVersion checks whether candidate has been created for the same KeptnEvaluationDefinition
+// and phase as evaluation, but for the version of the KeptnApp or KeptnWorkload deployed prior to it
+func isEvaluationOfPreviousVersion(evaluation *apilifecycle.KeptnEvaluation, candidate *apilifecycle.KeptnEvaluation) bool {
+	if candidate.Spec.EvaluationDefinition != evaluation.Spec.EvaluationDefinition ||
+		candidate.Spec.Type != evaluation.Spec.Type ||
+		candidate.Spec.AppName != evaluation.Spec.AppName ||
+		candidate.Spec.Workload != evaluation.Spec.Workload {
+		return false
+	}
+	if evaluation.Spec.Workload != "" {
+		return candidate.Spec.WorkloadVersion == evaluation.Spec.PreviousVersion
 	}
+	return candidate.Spec.AppVersion == evaluation.Spec.PreviousVersion
 }
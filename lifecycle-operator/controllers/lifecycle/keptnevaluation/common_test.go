@@ -8,12 +8,14 @@ import (
 )
 
 func TestCheckValue(t *testing.T) {
+	previous := 100.0
 	tests := []struct {
-		name   string
-		obj    apilifecycle.Objective
-		item   *apilifecycle.EvaluationStatusItem
-		result bool
-		err    bool
+		name     string
+		obj      apilifecycle.Objective
+		item     *apilifecycle.EvaluationStatusItem
+		previous *float64
+		result   bool
+		err      bool
 	}{
 		{
 			name:   "empty values",
@@ -209,11 +211,131 @@ func TestCheckValue(t *testing.T) {
 			result: false,
 			err:    true,
 		},
+		{
+			name: "10<=10",
+			obj: apilifecycle.Objective{
+				KeptnMetricRef: apilifecycle.KeptnMetricReference{
+					Name:      "testytest",
+					Namespace: "default",
+				},
+				EvaluationTarget: "<=10",
+			},
+			item: &apilifecycle.EvaluationStatusItem{
+				Value:   "10",
+				Status:  "all good",
+				Message: "all good",
+			},
+			result: true,
+			err:    false,
+		},
+		{
+			name: "9>=10",
+			obj: apilifecycle.Objective{
+				KeptnMetricRef: apilifecycle.KeptnMetricReference{
+					Name:      "testytest",
+					Namespace: "default",
+				},
+				EvaluationTarget: ">=10",
+			},
+			item: &apilifecycle.EvaluationStatusItem{
+				Value:   "9",
+				Status:  "all good",
+				Message: "all good",
+			},
+			result: false,
+			err:    false,
+		},
+		{
+			name: "range",
+			obj: apilifecycle.Objective{
+				KeptnMetricRef: apilifecycle.KeptnMetricReference{
+					Name:      "testytest",
+					Namespace: "default",
+				},
+				EvaluationTarget: "10..50",
+			},
+			item: &apilifecycle.EvaluationStatusItem{
+				Value:   "20",
+				Status:  "all good",
+				Message: "all good",
+			},
+			result: true,
+			err:    false,
+		},
+		{
+			name: "compound",
+			obj: apilifecycle.Objective{
+				KeptnMetricRef: apilifecycle.KeptnMetricReference{
+					Name:      "testytest",
+					Namespace: "default",
+				},
+				EvaluationTarget: ">10 && <50",
+			},
+			item: &apilifecycle.EvaluationStatusItem{
+				Value:   "50",
+				Status:  "all good",
+				Message: "all good",
+			},
+			result: false,
+			err:    false,
+		},
+		{
+			name: "relative",
+			obj: apilifecycle.Objective{
+				KeptnMetricRef: apilifecycle.KeptnMetricReference{
+					Name:      "testytest",
+					Namespace: "default",
+				},
+				EvaluationTarget: "<=+10%",
+			},
+			item: &apilifecycle.EvaluationStatusItem{
+				Value:   "105",
+				Status:  "all good",
+				Message: "all good",
+			},
+			previous: &previous,
+			result:   true,
+			err:      false,
+		},
+		{
+			name: "relative not met",
+			obj: apilifecycle.Objective{
+				KeptnMetricRef: apilifecycle.KeptnMetricReference{
+					Name:      "testytest",
+					Namespace: "default",
+				},
+				EvaluationTarget: "<=+10%",
+			},
+			item: &apilifecycle.EvaluationStatusItem{
+				Value:   "115",
+				Status:  "all good",
+				Message: "all good",
+			},
+			previous: &previous,
+			result:   false,
+			err:      false,
+		},
+		{
+			name: "relative without previous value",
+			obj: apilifecycle.Objective{
+				KeptnMetricRef: apilifecycle.KeptnMetricReference{
+					Name:      "testytest",
+					Namespace: "default",
+				},
+				EvaluationTarget: "<=+10%",
+			},
+			item: &apilifecycle.EvaluationStatusItem{
+				Value:   "105",
+				Status:  "all good",
+				Message: "all good",
+			},
+			result: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r, e := checkValue(tt.obj, tt.item)
+			r, e := checkValue(tt.obj, tt.item, tt.previous)
 			require.Equal(t, tt.result, r)
 			if tt.err {
 				require.NotNil(t, e)
@@ -19,6 +19,8 @@ package keptnevaluation
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -82,7 +84,7 @@ func (r *KeptnEvaluationReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, nil
 	}
 
-	if !evaluation.Status.OverallStatus.IsSucceeded() {
+	if !evaluation.Status.OverallStatus.IsCompleted() {
 		evaluationDefinition, err := controllercommon.GetEvaluationDefinition(r.Client, r.Log, ctx, evaluation.Spec.EvaluationDefinition, req.NamespacedName.Namespace)
 		if err != nil {
 			if errors.IsNotFound(err) {
@@ -97,7 +99,7 @@ func (r *KeptnEvaluationReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 	}
 
-	if !evaluation.Status.OverallStatus.IsSucceeded() {
+	if !evaluation.Status.OverallStatus.IsCompleted() {
 		if err := r.handleEvaluationIncomplete(ctx, evaluation); err != nil {
 			return ctrl.Result{Requeue: true}, err
 		}
@@ -126,7 +128,10 @@ func (r *KeptnEvaluationReconciler) handleEvaluationIncomplete(ctx context.Conte
 
 func (r *KeptnEvaluationReconciler) handleEvaluationExceededRetries(ctx context.Context, evaluation *apilifecycle.KeptnEvaluation) {
 	r.EventSender.Emit(apicommon.PhaseReconcileEvaluation, "Warning", evaluation, apicommon.PhaseStateReconcileTimeout, "retryCount exceeded", "")
-	evaluation.Status.OverallStatus = apicommon.StateFailed
+	// an evaluation that reached the warning score in its last attempt is not considered as failed
+	if !evaluation.Status.OverallStatus.IsWarning() {
+		evaluation.Status.OverallStatus = apicommon.StateFailed
+	}
 	err := r.updateFinishedEvaluationMetrics(ctx, evaluation)
 	if err != nil {
 		r.Log.Error(err, "failed to update finished evaluation metrics")
@@ -152,7 +157,9 @@ func (r *KeptnEvaluationReconciler) performEvaluation(ctx context.Context, evalu
 
 	evaluation.Status.RetryCount++
 	evaluation.Status.EvaluationStatus = newStatus
-	if apicommon.GetOverallState(statusSummary) == apicommon.StateSucceeded {
+	if evaluationDefinition.Spec.TotalScore != nil {
+		evaluation.Status.OverallStatus = getScoredState(evaluation, evaluationDefinition)
+	} else if apicommon.GetOverallState(statusSummary) == apicommon.StateSucceeded {
 		evaluation.Status.OverallStatus = apicommon.StateSucceeded
 	} else {
 		evaluation.Status.OverallStatus = apicommon.StateProgressing
@@ -161,6 +168,41 @@ func (r *KeptnEvaluationReconciler) performEvaluation(ctx context.Context, evalu
 	return evaluation
 }
 
+// getScoredState computes the score of the evaluation based on the weights of its objectives and returns
+// the resulting state: the evaluation succeeds if the pass percentage is reached, and finishes with a warning
+// if the warning percentage is reached in its last attempt. Missing a key objective prevents both.
+func getScoredState(evaluation *apilifecycle.KeptnEvaluation, evaluationDefinition *apilifecycle.KeptnEvaluationDefinition) apicommon.KeptnState {
+	totalWeight := 0
+	achievedWeight := 0
+	keyObjectiveFailed := false
+	for _, objective := range evaluationDefinition.Spec.Objectives {
+		totalWeight += objective.GetWeight()
+		if evaluation.Status.EvaluationStatus[objective.KeptnMetricRef.Name].Status.IsSucceeded() {
+			achievedWeight += objective.GetWeight()
+		} else if objective.KeyObjective {
+			keyObjectiveFailed = true
+		}
+	}
+
+	score := 100.0
+	if totalWeight > 0 {
+		score = float64(achievedWeight) / float64(totalWeight) * 100
+	}
+	evaluation.Status.Score = strconv.FormatFloat(score, 'f', 2, 64)
+
+	totalScore := evaluationDefinition.Spec.TotalScore
+	switch {
+	case keyObjectiveFailed:
+		return apicommon.StateProgressing
+	case score >= float64(totalScore.PassPercentage):
+		return apicommon.StateSucceeded
+	case score >= float64(totalScore.WarningPercentage) && evaluation.Status.RetryCount >= evaluation.Spec.Retries:
+		return apicommon.StateWarning
+	default:
+		return apicommon.StateProgressing
+	}
+}
+
 func (r *KeptnEvaluationReconciler) evaluateObjective(ctx context.Context, evaluation *apilifecycle.KeptnEvaluation, statusSummary apicommon.StatusSummary, newStatus map[string]apilifecycle.EvaluationStatusItem, objective apilifecycle.Objective, provider *keptnmetric.KeptnMetricProvider) (map[string]apilifecycle.EvaluationStatusItem, apicommon.StatusSummary) {
 	if _, ok := evaluation.Status.EvaluationStatus[objective.KeptnMetricRef.Name]; !ok {
 		evaluation.AddEvaluationStatus(objective)
@@ -183,8 +225,16 @@ func (r *KeptnEvaluationReconciler) evaluateObjective(ctx context.Context, evalu
 	}
 
 	statusItem.Value = value
+
+	previousValue, err := r.getPreviousValue(ctx, evaluation, objective)
+	if err != nil {
+		statusItem.Message = err.Error()
+		r.Log.Error(err, "Could not retrieve value of previous version")
+		return updateStatusSummary(statusSummary, statusItem, newStatus, objective)
+	}
+
 	// Evaluating SLO
-	check, err := checkValue(objective, statusItem)
+	check, err := checkValue(objective, statusItem, previousValue)
 	if err != nil {
 		statusItem.Message = err.Error()
 		r.Log.Error(err, "Could not check objective result")
@@ -197,9 +247,51 @@ func (r *KeptnEvaluationReconciler) evaluateObjective(ctx context.Context, evalu
 	} else {
 		statusItem.Message = fmt.Sprintf("value '%s' did not meet objective '%s'", value, objective.EvaluationTarget)
 	}
+	if previousValue == nil && isRelativeTarget(objective) {
+		statusItem.Message += ", relative conditions were skipped as no value of the previous version is available"
+	}
 	return updateStatusSummary(statusSummary, statusItem, newStatus, objective)
 }
 
+// getPreviousValue returns the value of the objective in the latest evaluation of the previous version.
+// It returns nil if the evaluation target of the objective does not refer to the previous version,
+// or if no such value exists.
+func (r *KeptnEvaluationReconciler) getPreviousValue(ctx context.Context, evaluation *apilifecycle.KeptnEvaluation, objective apilifecycle.Objective) (*float64, error) {
+	// invalid targets are reported when checking the value
+	if !isRelativeTarget(objective) || evaluation.Spec.PreviousVersion == "" {
+		return nil, nil
+	}
+
+	evaluationList := &apilifecycle.KeptnEvaluationList{}
+	if err := r.Client.List(ctx, evaluationList, client.InNamespace(evaluation.Namespace)); err != nil {
+		return nil, err
+	}
+
+	var latest *apilifecycle.KeptnEvaluation
+	for i := range evaluationList.Items {
+		candidate := &evaluationList.Items[i]
+		if !isEvaluationOfPreviousVersion(evaluation, candidate) {
+			continue
+		}
+		if item, ok := candidate.Status.EvaluationStatus[objective.KeptnMetricRef.Name]; !ok || item.Value == "" {
+			continue
+		}
+		if latest == nil || candidate.Status.EndTime.After(latest.Status.EndTime.Time) {
+			latest = candidate
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+
+	previousValue := latest.Status.EvaluationStatus[objective.KeptnMetricRef.Name].Value
+	value, err := strconv.ParseFloat(previousValue, 64)
+	if err != nil || math.IsNaN(value) {
+		return nil, fmt.Errorf("value '%s' of previous version %s is not a valid number", previousValue, evaluation.Spec.PreviousVersion)
+	}
+	return &value, nil
+}
+
 func updateStatusSummary(statusSummary apicommon.StatusSummary, statusItem *apilifecycle.EvaluationStatusItem, newStatus map[string]apilifecycle.EvaluationStatusItem, objective apilifecycle.Objective) (map[string]apilifecycle.EvaluationStatusItem, apicommon.StatusSummary) {
 	statusSummary = apicommon.UpdateStatusSummary(statusItem.Status, statusSummary)
 	newStatus[objective.KeptnMetricRef.Name] = *statusItem
@@ -4,6 +4,7 @@ package keptnevaluation
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
@@ -171,6 +172,75 @@ func TestKeptnEvaluationReconciler_Reconcile_SucceedEvaluation(t *testing.T) {
 	require.Equal(t, "value '10' met objective '<11'", updatedEvaluation.Status.EvaluationStatus[metric.Name].Message)
 }
 
+func TestKeptnEvaluationReconciler_Reconcile_RelativeEvaluationWithoutPreviousVersion(t *testing.T) {
+
+	const namespace = "my-namespace"
+	metric := &metricsapi.KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-metric",
+			Namespace: namespace,
+		},
+		Status: metricsapi.KeptnMetricStatus{
+			Value:    "10",
+			RawValue: []byte("10"),
+		},
+	}
+
+	evaluationDefinition := &apilifecycle.KeptnEvaluationDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-definition",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationDefinitionSpec{
+			Objectives: []apilifecycle.Objective{
+				{
+					KeptnMetricRef: apilifecycle.KeptnMetricReference{
+						Name:      metric.Name,
+						Namespace: namespace,
+					},
+					EvaluationTarget: "<11 && <=+10%",
+				},
+			},
+		},
+	}
+
+	// the first version of the workload has no previous version to compare with
+	evaluation := &apilifecycle.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-evaluation",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationSpec{
+			EvaluationDefinition: evaluationDefinition.Name,
+			Workload:             "my-workload",
+			WorkloadVersion:      "1.0.0",
+			FailureConditions: apilifecycle.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	reconciler, fakeClient := setupReconcilerAndClient(t, metric, evaluationDefinition, evaluation)
+
+	_, err := reconciler.Reconcile(context.TODO(), controllerruntime.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: namespace,
+			Name:      evaluation.Name,
+		},
+	})
+	require.Nil(t, err)
+
+	updatedEvaluation := &apilifecycle.KeptnEvaluation{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: namespace,
+		Name:      evaluation.Name,
+	}, updatedEvaluation)
+	require.Nil(t, err)
+
+	require.Equal(t, apicommon.StateSucceeded, updatedEvaluation.Status.EvaluationStatus[metric.Name].Status)
+	require.Equal(t, "value '10' met objective '<11 && <=+10%', relative conditions were skipped as no value of the previous version is available", updatedEvaluation.Status.EvaluationStatus[metric.Name].Message)
+}
+
 func TestKeptnEvaluationReconciler_Reconcile_SucceedEvaluation_withDefinitionInDefaultKeptnNamespace(t *testing.T) {
 
 	const namespace = "my-namespace"
@@ -245,6 +315,262 @@ func TestKeptnEvaluationReconciler_Reconcile_SucceedEvaluation_withDefinitionInD
 	require.Equal(t, "value '10' met objective '<11'", updatedEvaluation.Status.EvaluationStatus[metric.Name].Message)
 }
 
+func TestKeptnEvaluationReconciler_getPreviousValue(t *testing.T) {
+	const namespace = "my-namespace"
+	objective := apilifecycle.Objective{
+		KeptnMetricRef: apilifecycle.KeptnMetricReference{
+			Name:      "my-metric",
+			Namespace: namespace,
+		},
+		EvaluationTarget: "<=+10%",
+	}
+
+	newEvaluation := func(name string, appVersion string, previousVersion string, value string, endTime time.Time) *apilifecycle.KeptnEvaluation {
+		return &apilifecycle.KeptnEvaluation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: apilifecycle.KeptnEvaluationSpec{
+				AppName:              "my-app",
+				AppVersion:           appVersion,
+				PreviousVersion:      previousVersion,
+				EvaluationDefinition: "my-definition",
+				Type:                 apicommon.PostDeploymentCheckType,
+			},
+			Status: apilifecycle.KeptnEvaluationStatus{
+				EvaluationStatus: map[string]apilifecycle.EvaluationStatusItem{
+					"my-metric": {Value: value},
+				},
+				EndTime: metav1.NewTime(endTime),
+			},
+		}
+	}
+
+	evaluation := newEvaluation("current", "2.0.0", "1.0.0", "", time.Time{})
+	older := newEvaluation("older", "1.0.0", "", "50", time.Now().Add(-time.Hour))
+	latest := newEvaluation("latest", "1.0.0", "", "100", time.Now())
+	otherVersion := newEvaluation("other-version", "0.9.0", "", "10", time.Now())
+
+	reconciler, _ := setupReconcilerAndClient(t, evaluation, older, latest, otherVersion)
+
+	value, err := reconciler.getPreviousValue(context.TODO(), evaluation, objective)
+	require.Nil(t, err)
+	require.NotNil(t, value)
+	require.Equal(t, 100.0, *value)
+
+	// absolute targets do not need the previous value
+	objective.EvaluationTarget = "<=10"
+	value, err = reconciler.getPreviousValue(context.TODO(), evaluation, objective)
+	require.Nil(t, err)
+	require.Nil(t, value)
+
+	// no evaluation of the previous version
+	objective.EvaluationTarget = "<=+10%"
+	evaluation.Spec.PreviousVersion = "1.5.0"
+	value, err = reconciler.getPreviousValue(context.TODO(), evaluation, objective)
+	require.Nil(t, err)
+	require.Nil(t, value)
+}
+
+func TestKeptnEvaluationReconciler_Reconcile_WarningEvaluation(t *testing.T) {
+
+	const namespace = "my-namespace"
+	metric := &metricsapi.KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-metric",
+			Namespace: namespace,
+		},
+		Status: metricsapi.KeptnMetricStatus{
+			Value:    "10",
+			RawValue: []byte("10"),
+		},
+	}
+	otherMetric := &metricsapi.KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-other-metric",
+			Namespace: namespace,
+		},
+		Status: metricsapi.KeptnMetricStatus{
+			Value:    "20",
+			RawValue: []byte("20"),
+		},
+	}
+
+	evaluationDefinition := &apilifecycle.KeptnEvaluationDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-definition",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationDefinitionSpec{
+			Objectives: []apilifecycle.Objective{
+				{
+					KeptnMetricRef: apilifecycle.KeptnMetricReference{
+						Name:      metric.Name,
+						Namespace: namespace,
+					},
+					EvaluationTarget: "<11",
+					Weight:           3,
+				},
+				{
+					KeptnMetricRef: apilifecycle.KeptnMetricReference{
+						Name:      otherMetric.Name,
+						Namespace: namespace,
+					},
+					EvaluationTarget: "<11",
+				},
+			},
+			TotalScore: &apilifecycle.TotalScore{
+				PassPercentage:    90,
+				WarningPercentage: 70,
+			},
+			FailureConditions: apilifecycle.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	evaluation := &apilifecycle.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-evaluation",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationSpec{
+			EvaluationDefinition: evaluationDefinition.Name,
+			FailureConditions: apilifecycle.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	reconciler, fakeClient := setupReconcilerAndClient(t, metric, otherMetric, evaluationDefinition, evaluation)
+
+	request := controllerruntime.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: namespace,
+			Name:      evaluation.Name,
+		},
+	}
+
+	reconcile, err := reconciler.Reconcile(context.TODO(), request)
+
+	require.Nil(t, err)
+	require.False(t, reconcile.Requeue)
+
+	updatedEvaluation := &apilifecycle.KeptnEvaluation{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: namespace,
+		Name:      evaluation.Name,
+	}, updatedEvaluation)
+
+	require.Nil(t, err)
+
+	require.Equal(t, apicommon.StateWarning, updatedEvaluation.Status.OverallStatus)
+	require.Equal(t, "75.00", updatedEvaluation.Status.Score)
+	require.Equal(t, apicommon.StateSucceeded, updatedEvaluation.Status.EvaluationStatus[metric.Name].Status)
+	require.Equal(t, apicommon.StateFailed, updatedEvaluation.Status.EvaluationStatus[otherMetric.Name].Status)
+
+	// reconciling again after the retries are used up must not turn the warning into a failure
+	_, err = reconciler.Reconcile(context.TODO(), request)
+	require.Nil(t, err)
+
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: namespace,
+		Name:      evaluation.Name,
+	}, updatedEvaluation)
+
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateWarning, updatedEvaluation.Status.OverallStatus)
+}
+
+func Test_getScoredState(t *testing.T) {
+	objectives := []apilifecycle.Objective{
+		{KeptnMetricRef: apilifecycle.KeptnMetricReference{Name: "key"}, Weight: 2, KeyObjective: true},
+		{KeptnMetricRef: apilifecycle.KeptnMetricReference{Name: "heavy"}, Weight: 5},
+		{KeptnMetricRef: apilifecycle.KeptnMetricReference{Name: "default"}},
+	}
+
+	tests := []struct {
+		name       string
+		succeeded  []string
+		retryCount int
+		wantState  apicommon.KeptnState
+		wantScore  string
+	}{
+		{
+			name:       "all objectives met",
+			succeeded:  []string{"key", "heavy", "default"},
+			retryCount: 1,
+			wantState:  apicommon.StateSucceeded,
+			wantScore:  "100.00",
+		},
+		{
+			name:       "pass percentage reached",
+			succeeded:  []string{"key", "heavy"},
+			retryCount: 1,
+			wantState:  apicommon.StateSucceeded,
+			wantScore:  "87.50",
+		},
+		{
+			name:       "warning percentage reached with retries left",
+			succeeded:  []string{"key", "default"},
+			retryCount: 1,
+			wantState:  apicommon.StateProgressing,
+			wantScore:  "37.50",
+		},
+		{
+			name:       "warning percentage reached in last attempt",
+			succeeded:  []string{"key", "default"},
+			retryCount: 3,
+			wantState:  apicommon.StateWarning,
+			wantScore:  "37.50",
+		},
+		{
+			name:       "warning percentage not reached",
+			succeeded:  []string{"default"},
+			retryCount: 3,
+			wantState:  apicommon.StateProgressing,
+			wantScore:  "12.50",
+		},
+		{
+			name:       "key objective missed",
+			succeeded:  []string{"heavy", "default"},
+			retryCount: 3,
+			wantState:  apicommon.StateProgressing,
+			wantScore:  "75.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluation := &apilifecycle.KeptnEvaluation{
+				Spec: apilifecycle.KeptnEvaluationSpec{
+					FailureConditions: apilifecycle.FailureConditions{Retries: 3},
+				},
+				Status: apilifecycle.KeptnEvaluationStatus{
+					RetryCount:       tt.retryCount,
+					EvaluationStatus: map[string]apilifecycle.EvaluationStatusItem{},
+				},
+			}
+			for _, objective := range objectives {
+				evaluation.Status.EvaluationStatus[objective.KeptnMetricRef.Name] = apilifecycle.EvaluationStatusItem{Status: apicommon.StateFailed}
+			}
+			for _, name := range tt.succeeded {
+				evaluation.Status.EvaluationStatus[name] = apilifecycle.EvaluationStatusItem{Status: apicommon.StateSucceeded}
+			}
+			evaluationDefinition := &apilifecycle.KeptnEvaluationDefinition{
+				Spec: apilifecycle.KeptnEvaluationDefinitionSpec{
+					Objectives: objectives,
+					TotalScore: &apilifecycle.TotalScore{PassPercentage: 80, WarningPercentage: 30},
+				},
+			}
+
+			require.Equal(t, tt.wantState, getScoredState(evaluation, evaluationDefinition))
+			require.Equal(t, tt.wantScore, evaluation.Status.Score)
+		})
+	}
+}
+
 func setupReconcilerAndClient(t *testing.T, objects ...client.Object) (*KeptnEvaluationReconciler, client.Client) {
 	scheme := runtime.NewScheme()
 
@@ -64,6 +64,10 @@ func (c *ContainerBuilder) CreateVolume(ctx context.Context) (*corev1.Volume, er
 	return c.generateVolume(), nil
 }
 
+func (c *ContainerBuilder) GetMounts(ctx context.Context) ([]apilifecycle.TaskMount, error) {
+	return c.taskSpec.Mounts, nil
+}
+
 func (c *ContainerBuilder) getVolumeSource() *corev1.EmptyDirVolumeSource {
 	quantity, ok := c.containerSpec.Resources.Limits["memory"]
 	if ok {
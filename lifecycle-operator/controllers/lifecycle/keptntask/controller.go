@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,6 +42,10 @@ type KeptnTaskReconciler struct {
 	EventSender eventsender.IEvent
 	Log         logr.Logger
 	Meters      apicommon.KeptnMeters
+	// PodClient is used to read the logs of failed tasks
+	PodClient corev1client.PodsGetter
+
+	admissions taskAdmissions
 }
 
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntasks,verbs=get;list;watch;create;update;patch;delete
@@ -49,6 +54,9 @@ type KeptnTaskReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;get;update;list;watch
 // +kubebuilder:rbac:groups=batch,resources=jobs/status,verbs=get;list
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=create;get;update;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
 
 func (r *KeptnTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	requestInfo := controllercommon.GetRequestInfo(req)
@@ -59,6 +67,7 @@ func (r *KeptnTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		if errors.IsNotFound(err) {
 			// taking down all associated K8s resources is handled by K8s
 			r.Log.Info("KeptnTask resource not found. Ignoring since object must be deleted", "requestInfo", requestInfo)
+			r.admissions.release(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		r.Log.Error(err, "Failed to get the KeptnTask")
@@ -80,18 +89,35 @@ func (r *KeptnTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
 	}
 
-	if job == nil {
+	if job == nil && !task.Status.Status.IsCompleted() {
+		// wait for the delay of the retry policy before starting the next attempt
+		if delay := time.Until(task.Status.NextAttemptTime.Time); delay > 0 {
+			return ctrl.Result{Requeue: true, RequeueAfter: delay}, nil
+		}
+		// wait for a free slot if the maximum number of KeptnTasks executed in parallel is reached
+		queued, err := r.queueTask(ctx, task)
+		if err != nil {
+			r.Log.Error(err, "could not check the number of running KeptnTasks")
+			return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+		}
+		if queued {
+			return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+		}
 		err = r.createJob(ctx, req, task)
 		if err != nil {
 			r.Log.Error(err, "could not create Job")
+			r.admissions.release(req.NamespacedName)
 		} else {
+			if task.Status.Status != apicommon.StateProgressing {
+				r.EventSender.Emit(apicommon.PhaseReconcileTask, "Normal", task, apicommon.PhaseStateStarted, "has started", "")
+			}
 			task.Status.Status = apicommon.StateProgressing
 		}
 		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
 	}
 
 	if !task.Status.Status.IsCompleted() {
-		r.updateTaskStatus(job, task)
+		r.updateTaskStatus(ctx, job, task)
 		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
 	}
 
@@ -99,6 +125,7 @@ func (r *KeptnTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	// Task is completed at this place
 	task.SetEndTime()
+	r.admissions.release(req.NamespacedName)
 
 	attrs := task.GetMetricsAttributes()
 
@@ -15,6 +15,8 @@ type JobRunnerBuilder interface {
 	// CreateContainer returns a job container based on the task definition spec
 	CreateContainer(ctx context.Context) (*corev1.Container, error)
 	CreateVolume(ctx context.Context) (*corev1.Volume, error)
+	// GetMounts returns the Secrets and ConfigMaps that are made available to the job container
+	GetMounts(ctx context.Context) ([]apilifecycle.TaskMount, error)
 }
 
 // BuilderOptions contains everything needed to build the current job
@@ -2,11 +2,17 @@ package keptntask
 
 import (
 	"context"
+	"encoding/json"
+	goerrors "errors"
 	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
 
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
 	controllercommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	taskdefinition "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/taskdefinition"
 	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	batchv1 "k8s.io/api/batch/v1"
@@ -14,10 +20,20 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+const (
+	maxLogLines int64 = 50
+	maxLogBytes int64 = 2048
+
+	checksumMismatchReason      = "ChecksumMismatch"
+	functionCodeNotCachedReason = "FunctionCodeNotCached"
+)
+
 func (r *KeptnTaskReconciler) createJob(ctx context.Context, req ctrl.Request, task *apilifecycle.KeptnTask) error {
 	jobName := ""
 	definition, err := controllercommon.GetTaskDefinition(r.Client, r.Log, ctx, task.Spec.TaskDefinition, req.Namespace)
@@ -44,8 +60,14 @@ func (r *KeptnTaskReconciler) createJob(ctx context.Context, req ctrl.Request, t
 	if taskdefinition.SpecExists(definition) {
 		jobName, err = r.createFunctionJob(ctx, req, task, definition)
 		if err != nil {
+			if goerrors.Is(err, controllererrors.ErrChecksumMismatch) {
+				r.failTask(task, checksumMismatchReason, err)
+			} else if goerrors.Is(err, controllererrors.ErrFunctionCodeNotCached) {
+				r.failTask(task, functionCodeNotCachedReason, err)
+			}
 			return err
 		}
+		task.Status.Attempt++
 	}
 
 	task.Status.JobName = jobName
@@ -54,6 +76,14 @@ func (r *KeptnTaskReconciler) createJob(ctx context.Context, req ctrl.Request, t
 	return nil
 }
 
+// failTask marks the given KeptnTask as failed without executing it
+func (r *KeptnTaskReconciler) failTask(task *apilifecycle.KeptnTask, reason string, err error) {
+	task.Status.Status = apicommon.StateFailed
+	task.Status.Reason = reason
+	task.Status.Message = err.Error()
+	r.EventSender.Emit(apicommon.PhaseCreateTask, "Warning", task, apicommon.PhaseStateFailed, err.Error(), "")
+}
+
 func (r *KeptnTaskReconciler) createFunctionJob(ctx context.Context, req ctrl.Request, task *apilifecycle.KeptnTask, definition *apilifecycle.KeptnTaskDefinition) (string, error) {
 
 	job, err := r.generateJob(ctx, task, definition, req)
@@ -70,18 +100,193 @@ func (r *KeptnTaskReconciler) createFunctionJob(ctx context.Context, req ctrl.Re
 	return job.Name, nil
 }
 
-func (r *KeptnTaskReconciler) updateTaskStatus(job *batchv1.Job, task *apilifecycle.KeptnTask) {
+func (r *KeptnTaskReconciler) updateTaskStatus(ctx context.Context, job *batchv1.Job, task *apilifecycle.KeptnTask) {
 	if len(job.Status.Conditions) > 0 {
 		if hasJobCondition(job.Status.Conditions, batchv1.JobComplete) ||
 			hasJobCondition(job.Status.Conditions, batchv1.JobSuccessCriteriaMet) {
 			task.Status.Status = apicommon.StateSucceeded
+			task.Status.Outputs = r.getJobOutputs(ctx, job)
+			r.EventSender.Emit(apicommon.PhaseReconcileTask, "Normal", task, apicommon.PhaseStateFinished, "has finished", "")
 		} else if hasJobCondition(job.Status.Conditions, batchv1.JobFailed) ||
 			hasJobCondition(job.Status.Conditions, batchv1.JobFailureTarget) {
-			task.Status.Status = apicommon.StateFailed
 			task.Status.Message = job.Status.Conditions[0].Message
 			task.Status.Reason = job.Status.Conditions[0].Reason
+			r.setFailureDetails(ctx, job, task)
+			if scheduleRetry(job, task) {
+				r.Log.Info("Retrying failed KeptnTask", "task", task.Name, "attempt", task.Status.Attempt, "nextAttemptTime", task.Status.NextAttemptTime)
+				// the next attempt waits for a free slot again
+				r.admissions.release(types.NamespacedName{Namespace: task.Namespace, Name: task.Name})
+				return
+			}
+			task.Status.Status = apicommon.StateFailed
+			r.EventSender.Emit(apicommon.PhaseReconcileTask, "Warning", task, apicommon.PhaseStateFailed, task.Status.Message, "")
+		}
+	}
+}
+
+// getJobPods returns the pods created for the given Job
+func (r *KeptnTaskReconciler) getJobPods(ctx context.Context, job *batchv1.Job) []corev1.Pod {
+	if job.Spec.Selector == nil {
+		return nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		r.Log.Error(err, "could not parse selector of Job", "job", job.Name)
+		return nil
+	}
+	pods := &corev1.PodList{}
+	if err := r.Client.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		r.Log.Error(err, "could not list pods of Job", "job", job.Name)
+		return nil
+	}
+	return pods.Items
+}
+
+// getJobOutputs returns the result document that the succeeded pod of the given Job
+// wrote to its termination message file. Values that are not strings are kept JSON encoded.
+func (r *KeptnTaskReconciler) getJobOutputs(ctx context.Context, job *batchv1.Job) map[string]string {
+	for _, pod := range r.getJobPods(ctx, job) {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			terminated := containerStatus.State.Terminated
+			if terminated == nil || terminated.ExitCode != 0 || terminated.Message == "" {
+				continue
+			}
+			outputs, err := parseOutputs(terminated.Message)
+			if err != nil {
+				r.Log.Error(err, "could not parse outputs of Job", "job", job.Name)
+				return nil
+			}
+			return outputs
+		}
+	}
+	return nil
+}
+
+// setFailureDetails stores the termination reason and the tail of the log of the
+// most recently failed container of the given Job in the status of the KeptnTask
+func (r *KeptnTaskReconciler) setFailureDetails(ctx context.Context, job *batchv1.Job, task *apilifecycle.KeptnTask) {
+	var failedPod *corev1.Pod
+	var failedContainer *corev1.ContainerStatus
+	var terminated *corev1.ContainerStateTerminated
+	previous := false
+	pods := r.getJobPods(ctx, job)
+	for i := range pods {
+		for j := range pods[i].Status.ContainerStatuses {
+			containerStatus := &pods[i].Status.ContainerStatuses[j]
+			state, isPrevious := getFailedTermination(containerStatus)
+			if state == nil || (terminated != nil && !state.FinishedAt.After(terminated.FinishedAt.Time)) {
+				continue
+			}
+			failedPod, failedContainer, terminated, previous = &pods[i], containerStatus, state, isPrevious
+		}
+	}
+	if terminated == nil {
+		return
+	}
+
+	task.Status.TerminationReason = terminated.Reason
+	if r.PodClient == nil {
+		return
+	}
+	tailLines := maxLogLines
+	logs, err := r.PodClient.Pods(failedPod.Namespace).GetLogs(failedPod.Name, &corev1.PodLogOptions{
+		Container: failedContainer.Name,
+		Previous:  previous,
+		TailLines: &tailLines,
+	}).DoRaw(ctx)
+	if err != nil {
+		r.Log.Error(err, "could not read logs of failed Job", "job", job.Name, "pod", failedPod.Name)
+		return
+	}
+	task.Status.Logs = tail(string(logs), maxLogBytes)
+}
+
+// getFailedTermination returns the termination state of the given container if it failed.
+// If the container has been restarted after it failed, the previous termination state is returned
+func getFailedTermination(containerStatus *corev1.ContainerStatus) (*corev1.ContainerStateTerminated, bool) {
+	if terminated := containerStatus.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+		return terminated, false
+	}
+	if terminated := containerStatus.LastTerminationState.Terminated; terminated != nil && terminated.ExitCode != 0 {
+		return terminated, true
+	}
+	return nil, false
+}
+
+// tail returns at most the last maxBytes bytes of the given string without splitting a character
+func tail(value string, maxBytes int64) string {
+	if int64(len(value)) <= maxBytes {
+		return value
+	}
+	start := int64(len(value)) - maxBytes
+	for start < int64(len(value)) && !utf8.RuneStart(value[start]) {
+		start++
+	}
+	return value[start:]
+}
+
+func parseOutputs(message string) (map[string]string, error) {
+	document := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(message), &document); err != nil {
+		return nil, err
+	}
+	outputs := make(map[string]string, len(document))
+	for key, value := range document {
+		var str string
+		if err := json.Unmarshal(value, &str); err == nil {
+			outputs[key] = str
+		} else {
+			outputs[key] = string(value)
 		}
 	}
+	return outputs, nil
+}
+
+// scheduleRetry prepares the next attempt of the KeptnTask if its retry policy allows to retry the given failed Job.
+// Jobs that failed because of their pod failure policy, i.e. with an exit code that should not be retried, are not retried.
+func scheduleRetry(job *batchv1.Job, task *apilifecycle.KeptnTask) bool {
+	policy := task.Spec.RetryPolicy
+	if policy == nil || task.Status.Attempt > task.GetRetries() || failedByPodFailurePolicy(job.Status.Conditions) {
+		return false
+	}
+	task.Status.JobName = ""
+	task.Status.NextAttemptTime = metav1.NewTime(time.Now().UTC().Add(policy.GetDelay(task.Status.Attempt)))
+	return true
+}
+
+func failedByPodFailurePolicy(conditions []batchv1.JobCondition) bool {
+	for _, v := range conditions {
+		if (v.Type == batchv1.JobFailed || v.Type == batchv1.JobFailureTarget) && v.Reason == batchv1.JobReasonPodFailurePolicy {
+			return true
+		}
+	}
+	return false
+}
+
+// getPodFailurePolicy returns a pod failure policy that fails the Job
+// if its container terminates with an exit code that should not be retried
+func getPodFailurePolicy(policy *apilifecycle.RetryPolicy) *batchv1.PodFailurePolicy {
+	if len(policy.OnExitCodes) == 0 {
+		return nil
+	}
+	// the exit codes of a pod failure policy must be unique and sorted
+	exitCodes := slices.Clone(policy.OnExitCodes)
+	slices.Sort(exitCodes)
+	exitCodes = slices.Compact(exitCodes)
+	return &batchv1.PodFailurePolicy{
+		Rules: []batchv1.PodFailurePolicyRule{
+			{
+				Action: batchv1.PodFailurePolicyActionFailJob,
+				OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+					Operator: batchv1.PodFailurePolicyOnExitCodesOpNotIn,
+					Values:   exitCodes,
+				},
+			},
+		},
+	}
 }
 
 func hasJobCondition(conditions []batchv1.JobCondition, searched batchv1.JobConditionType) bool {
@@ -103,6 +308,30 @@ func (r *KeptnTaskReconciler) getJob(ctx context.Context, jobName string, namesp
 	return job, nil
 }
 
+// copyFunctionConfigMap copies the ConfigMap containing the function code of the given KeptnTaskDefinition
+// into a ConfigMap owned by the given KeptnTask and returns the name of the copy
+func (r *KeptnTaskReconciler) copyFunctionConfigMap(ctx context.Context, task *apilifecycle.KeptnTask, definition *apilifecycle.KeptnTaskDefinition) (string, error) {
+	source := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: definition.Status.Function.ConfigMap, Namespace: definition.Namespace}, source); err != nil {
+		return "", err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keptnfn-" + apicommon.TruncateString(task.Name, 245),
+			Namespace: task.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Data = source.Data
+		return controllerutil.SetControllerReference(task, cm, r.Scheme)
+	})
+	if err != nil {
+		return "", err
+	}
+	return cm.Name, nil
+}
+
 func (r *KeptnTaskReconciler) generateJob(ctx context.Context, task *apilifecycle.KeptnTask, definition *apilifecycle.KeptnTaskDefinition, request ctrl.Request) (*batchv1.Job, error) {
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
@@ -134,6 +363,21 @@ func (r *KeptnTaskReconciler) generateJob(ctx context.Context, task *apilifecycl
 		r.Log.Error(err, "could not set controller reference:")
 	}
 
+	// the cached code might be outdated or modified since the KeptnTaskDefinition has been reconciled
+	if err := taskdefinition.VerifyCachedCode(ctx, r.Client, definition); err != nil {
+		return nil, err
+	}
+
+	configMap := definition.Status.Function.ConfigMap
+	if configMap != "" && definition.Namespace != task.Namespace && definition.Namespace != config.Instance().GetDefaultNamespace() {
+		// pods can only mount ConfigMaps of their own namespace, therefore the function code
+		// of a KeptnTaskDefinition of a task definition library is copied to the namespace of the task
+		configMap, err = r.copyFunctionConfigMap(ctx, task, definition)
+		if err != nil {
+			return nil, fmt.Errorf(controllererrors.ErrCannotGetFunctionConfigMap, err)
+		}
+	}
+
 	builderOpt := BuilderOptions{
 		Client:        r.Client,
 		req:           request,
@@ -144,7 +388,7 @@ func (r *KeptnTaskReconciler) generateJob(ctx context.Context, task *apilifecycl
 		eventSender:   r.EventSender,
 		Image:         taskdefinition.GetRuntimeImage(definition),
 		MountPath:     taskdefinition.GetRuntimeMountPath(definition),
-		ConfigMap:     definition.Status.Function.ConfigMap,
+		ConfigMap:     configMap,
 	}
 
 	builder := NewJobRunnerBuilder(builderOpt)
@@ -156,6 +400,9 @@ func (r *KeptnTaskReconciler) generateJob(ctx context.Context, task *apilifecycl
 	if err != nil {
 		return nil, fmt.Errorf("could not create container for Job: %w", err)
 	}
+	if container.TerminationMessagePath == "" {
+		container.TerminationMessagePath = OutputFilePath
+	}
 
 	volume, err := builder.CreateVolume(ctx)
 	if err != nil {
@@ -168,5 +415,84 @@ func (r *KeptnTaskReconciler) generateJob(ctx context.Context, task *apilifecycl
 
 	job.Spec.Template.Spec.Containers = []corev1.Container{*container}
 
+	mounts, err := builder.GetMounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get mounts for Job: %w", err)
+	}
+	if err := applyMounts(&job.Spec.Template.Spec, mounts); err != nil {
+		return nil, err
+	}
+
+	if definition.Spec.PodTemplate != nil {
+		template, err := mergePodTemplate(job.Spec.Template, definition.Spec.PodTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("could not merge pod template of KeptnTaskDefinition %s: %w", definition.Name, err)
+		}
+		job.Spec.Template = *template
+	}
+
+	if task.Spec.RetryPolicy != nil {
+		// each attempt is executed in a separate Job, the retries are handled by the KeptnTask controller
+		backoffLimit := int32(0)
+		job.Spec.BackoffLimit = &backoffLimit
+		job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+		job.Spec.PodFailurePolicy = getPodFailurePolicy(task.Spec.RetryPolicy)
+	}
+
 	return job, nil
 }
+
+// mergePodTemplate applies the given pod template override to the generated pod template of a Job
+// using a strategic merge patch, i.e. containers and volumes are merged by their name
+func mergePodTemplate(template corev1.PodTemplateSpec, override *corev1.PodTemplateSpec) (*corev1.PodTemplateSpec, error) {
+	original, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := toPatch(override)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := strategicpatch.StrategicMergePatch(original, patch, corev1.PodTemplateSpec{})
+	if err != nil {
+		return nil, err
+	}
+	result := &corev1.PodTemplateSpec{}
+	if err := json.Unmarshal(merged, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// toPatch encodes the given object as patch document.
+// Null values are dropped, since they are only caused by unset fields
+// and would otherwise delete the corresponding fields of the original object
+func toPatch(obj interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(encoded, &document); err != nil {
+		return nil, err
+	}
+	return json.Marshal(dropNulls(document))
+}
+
+func dropNulls(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if item == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = dropNulls(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = dropNulls(item)
+		}
+	}
+	return value
+}
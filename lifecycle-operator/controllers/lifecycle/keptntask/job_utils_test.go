@@ -2,18 +2,24 @@ package keptntask
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/taskdefinition"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	"github.com/stretchr/testify/require"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -73,7 +79,9 @@ func TestKeptnTaskReconciler_createJob(t *testing.T) {
 	require.Equal(t, namespace, resultingJob.Namespace)
 	require.NotEmpty(t, resultingJob.OwnerReferences)
 	require.Len(t, resultingJob.Spec.Template.Spec.Containers, 1)
-	require.Len(t, resultingJob.Spec.Template.Spec.Containers[0].Env, 5)
+	require.Len(t, resultingJob.Spec.Template.Spec.Containers[0].Env, 6)
+	require.Equal(t, int32(1), task.Status.Attempt)
+	require.Equal(t, OutputFilePath, resultingJob.Spec.Template.Spec.Containers[0].TerminationMessagePath)
 	require.Equal(t, map[string]string{
 		"label1": "label2",
 	}, resultingJob.Labels)
@@ -86,6 +94,115 @@ func TestKeptnTaskReconciler_createJob(t *testing.T) {
 	}, resultingJob.Annotations)
 }
 
+func TestKeptnTaskReconciler_createJobWithChecksum(t *testing.T) {
+	namespace := "default"
+	checksum := taskdefinition.Checksum([]byte("console.log('hello');"))
+	taskDefinition := &apilifecycle.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-task-definition",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnTaskDefinitionSpec{
+			Deno: &apilifecycle.RuntimeSpec{
+				HttpReference: apilifecycle.HttpReference{
+					Url:    "http://functions/hello.ts",
+					Sha256: strings.ToUpper(checksum),
+				},
+			},
+		},
+	}
+	fakeClient := testcommon.NewTestClient(taskDefinition)
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: namespace},
+	}
+
+	// the code is not fetched by the operator, the runtime verifies it before executing it
+	task := makeTask("my-task", namespace, taskDefinition.Name)
+	err := r.createJob(context.TODO(), req, task)
+	require.Nil(t, err)
+	require.NotEmpty(t, task.Status.JobName)
+
+	resultingJob := &batchv1.Job{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: task.Status.JobName}, resultingJob)
+	require.Nil(t, err)
+	require.Contains(t, resultingJob.Spec.Template.Spec.Containers[0].Env, v1.EnvVar{Name: Script, Value: "http://functions/hello.ts"})
+	require.Contains(t, resultingJob.Spec.Template.Spec.Containers[0].Env, v1.EnvVar{Name: ScriptChecksum, Value: checksum})
+}
+
+func TestKeptnTaskReconciler_createJobWithCachedCode(t *testing.T) {
+	namespace := "default"
+	code := "console.log('hello');"
+	taskDefinition := &apilifecycle.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-task-definition",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnTaskDefinitionSpec{
+			Deno: &apilifecycle.RuntimeSpec{
+				HttpReference: apilifecycle.HttpReference{
+					Url:   "http://functions/hello.ts",
+					Cache: true,
+				},
+			},
+		},
+	}
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keptnfn-my-task-definition",
+			Namespace: namespace,
+			Annotations: map[string]string{
+				apicommon.SourceURLAnnotation:    "http://functions/hello.ts",
+				apicommon.SourceDigestAnnotation: "sha256:" + taskdefinition.Checksum([]byte(code)),
+			},
+		},
+		Data: map[string]string{"code": code},
+	}
+	fakeClient := testcommon.NewTestClient(taskDefinition, cm)
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: namespace},
+	}
+
+	// the code could not be fetched yet
+	task := makeTask("my-task", namespace, taskDefinition.Name)
+	err := r.createJob(context.TODO(), req, task)
+	require.ErrorIs(t, err, controllererrors.ErrFunctionCodeNotCached)
+	require.Empty(t, task.Status.JobName)
+	require.Equal(t, apicommon.StateFailed, task.Status.Status)
+	require.Equal(t, "FunctionCodeNotCached", task.Status.Reason)
+
+	taskDefinition.Status.Function.ConfigMap = cm.Name
+	require.Nil(t, fakeClient.Status().Update(context.TODO(), taskDefinition))
+
+	task = makeTask("my-other-task", namespace, taskDefinition.Name)
+	err = r.createJob(context.TODO(), req, task)
+	require.Nil(t, err)
+	require.NotEmpty(t, task.Status.JobName)
+
+	// the cached code has been modified after it has been fetched
+	cm.Data["code"] = "console.log('modified');"
+	require.Nil(t, fakeClient.Update(context.TODO(), cm))
+
+	task = makeTask("my-third-task", namespace, taskDefinition.Name)
+	err = r.createJob(context.TODO(), req, task)
+	require.ErrorIs(t, err, controllererrors.ErrChecksumMismatch)
+	require.Empty(t, task.Status.JobName)
+	require.Equal(t, "ChecksumMismatch", task.Status.Reason)
+}
+
 func TestKeptnTaskReconciler_createJob_withTaskDefInDefaultNamespace(t *testing.T) {
 	namespace := "default"
 	cmName := "my-cmd"
@@ -139,7 +256,7 @@ func TestKeptnTaskReconciler_createJob_withTaskDefInDefaultNamespace(t *testing.
 	require.Equal(t, namespace, resultingJob.Namespace)
 	require.NotEmpty(t, resultingJob.OwnerReferences)
 	require.Len(t, resultingJob.Spec.Template.Spec.Containers, 1)
-	require.Len(t, resultingJob.Spec.Template.Spec.Containers[0].Env, 5)
+	require.Len(t, resultingJob.Spec.Template.Spec.Containers[0].Env, 6)
 	require.Equal(t, map[string]string{
 		"label1": "label2",
 	}, resultingJob.Labels)
@@ -152,6 +269,90 @@ func TestKeptnTaskReconciler_createJob_withTaskDefInDefaultNamespace(t *testing.
 	}, resultingJob.Annotations)
 }
 
+func TestKeptnTaskReconciler_createJob_withTaskDefInLibraryNamespace(t *testing.T) {
+	namespace := "default"
+	libraryNamespace := "platform-tasks"
+	cmName := "my-cmd"
+	taskDefinitionName := "my-task-definition"
+
+	cm := makeConfigMap(cmName, libraryNamespace)
+	taskDefinition := makeTaskDefinitionWithConfigmapRef(taskDefinitionName, libraryNamespace, cmName)
+
+	fakeClient := testcommon.NewTestClient(cm, taskDefinition)
+
+	taskDefinition.Status.Function.ConfigMap = cmName
+	err := fakeClient.Status().Update(context.TODO(), taskDefinition)
+	require.Nil(t, err)
+
+	config.Instance().SetDefaultNamespace(KeptnNamespace)
+	config.Instance().SetTaskDefinitionLibraries(map[string][]string{libraryNamespace: {namespace}})
+	defer config.Instance().SetTaskDefinitionLibraries(nil)
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, libraryNamespace+"/"+taskDefinitionName)
+	err = fakeClient.Create(context.TODO(), task)
+	require.Nil(t, err)
+
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: namespace,
+		},
+	}
+
+	err = r.createJob(context.TODO(), req, task)
+	require.Nil(t, err)
+	require.NotEmpty(t, task.Status.JobName)
+
+	resultingJob := &batchv1.Job{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: task.Status.JobName}, resultingJob)
+	require.Nil(t, err)
+
+	// the function code is copied to a ConfigMap owned by the task
+	require.Len(t, resultingJob.Spec.Template.Spec.Volumes, 1)
+	require.Equal(t, "keptnfn-my-task", resultingJob.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+
+	copiedCm := &v1.ConfigMap{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: "keptnfn-my-task"}, copiedCm)
+	require.Nil(t, err)
+	require.Equal(t, cm.Data, copiedCm.Data)
+	require.Len(t, copiedCm.OwnerReferences, 1)
+	require.Equal(t, task.Name, copiedCm.OwnerReferences[0].Name)
+}
+
+func TestKeptnTaskReconciler_createJob_withTaskDefInLibraryNamespaceNotAllowed(t *testing.T) {
+	namespace := "default"
+	libraryNamespace := "platform-tasks"
+	taskDefinitionName := "my-task-definition"
+
+	taskDefinition := makeTaskDefinitionWithConfigmapRef(taskDefinitionName, libraryNamespace, "my-cmd")
+	fakeClient := testcommon.NewTestClient(taskDefinition)
+
+	config.Instance().SetDefaultNamespace(KeptnNamespace)
+	config.Instance().SetTaskDefinitionLibraries(map[string][]string{libraryNamespace: {"other-namespace"}})
+	defer config.Instance().SetTaskDefinitionLibraries(nil)
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, libraryNamespace+"/"+taskDefinitionName)
+	err := fakeClient.Create(context.TODO(), task)
+	require.Nil(t, err)
+
+	err = r.createJob(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}}, task)
+	require.ErrorIs(t, err, controllererrors.ErrTaskDefinitionAccessDenied)
+	require.Empty(t, task.Status.JobName)
+}
+
 //nolint:dupl
 func TestKeptnTaskReconciler_updateTaskStatus(t *testing.T) {
 	namespace := "default"
@@ -172,9 +373,10 @@ func TestKeptnTaskReconciler_updateTaskStatus(t *testing.T) {
 	err := apilifecycle.AddToScheme(fakeClient.Scheme())
 	require.Nil(t, err)
 
+	recorder := record.NewFakeRecorder(100)
 	r := &KeptnTaskReconciler{
 		Client:      fakeClient,
-		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		EventSender: eventsender.NewK8sSender(recorder),
 		Log:         ctrl.Log.WithName("task-controller"),
 		Scheme:      fakeClient.Scheme(),
 	}
@@ -186,9 +388,10 @@ func TestKeptnTaskReconciler_updateTaskStatus(t *testing.T) {
 
 	task.Status.JobName = job.Name
 
-	r.updateTaskStatus(job, task)
+	r.updateTaskStatus(context.TODO(), job, task)
 
 	require.Equal(t, apicommon.StateFailed, task.Status.Status)
+	require.Contains(t, <-recorder.Events, "ReconcileTaskFailed")
 
 	// now, set the job to succeeded
 	job.Status.Conditions = []batchv1.JobCondition{
@@ -197,9 +400,10 @@ func TestKeptnTaskReconciler_updateTaskStatus(t *testing.T) {
 		},
 	}
 
-	r.updateTaskStatus(job, task)
+	r.updateTaskStatus(context.TODO(), job, task)
 
 	require.Equal(t, apicommon.StateSucceeded, task.Status.Status)
+	require.Contains(t, <-recorder.Events, "ReconcileTaskFinished")
 }
 
 //nolint:dupl
@@ -236,7 +440,7 @@ func TestKeptnTaskReconciler_updateTaskStatusK8s31(t *testing.T) {
 
 	task.Status.JobName = job.Name
 
-	r.updateTaskStatus(job, task)
+	r.updateTaskStatus(context.TODO(), job, task)
 
 	require.Equal(t, apicommon.StateFailed, task.Status.Status)
 
@@ -247,9 +451,154 @@ func TestKeptnTaskReconciler_updateTaskStatusK8s31(t *testing.T) {
 		},
 	}
 
-	r.updateTaskStatus(job, task)
+	r.updateTaskStatus(context.TODO(), job, task)
+
+	require.Equal(t, apicommon.StateSucceeded, task.Status.Status)
+}
+
+func TestKeptnTaskReconciler_updateTaskStatusOutputs(t *testing.T) {
+	namespace := "default"
+	taskDefinitionName := "my-task-definition"
+
+	job := makeJob("my.job", namespace, batchv1.JobStatus{
+		Conditions: []batchv1.JobCondition{
+			{
+				Type: batchv1.JobComplete,
+			},
+		},
+	})
+	job.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"job-name": job.Name}}
+
+	makePod := func(name string, phase v1.PodPhase, exitCode int32, message string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{"job-name": job.Name},
+			},
+			Status: v1.PodStatus{
+				Phase: phase,
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						Name: "keptn-function-runner",
+						State: v1.ContainerState{
+							Terminated: &v1.ContainerStateTerminated{ExitCode: exitCode, Message: message},
+						},
+					},
+				},
+			},
+		}
+	}
+	failedPod := makePod("failed-pod", v1.PodFailed, 1, `{"buildId":"failed"}`)
+	succeededPod := makePod("succeeded-pod", v1.PodSucceeded, 0, `{"buildId":"1234","score":0.95,"passed":true}`)
+	otherPod := makePod("other-pod", v1.PodSucceeded, 0, `{"buildId":"other"}`)
+	otherPod.Labels = map[string]string{"job-name": "other-job"}
+
+	fakeClient := testcommon.NewTestClient(job, failedPod, succeededPod, otherPod)
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+	task.Status.JobName = job.Name
+
+	r.updateTaskStatus(context.TODO(), job, task)
 
 	require.Equal(t, apicommon.StateSucceeded, task.Status.Status)
+	require.Equal(t, map[string]string{
+		"buildId": "1234",
+		"score":   "0.95",
+		"passed":  "true",
+	}, task.Status.Outputs)
+}
+
+func TestKeptnTaskReconciler_updateTaskStatusFailureDetails(t *testing.T) {
+	namespace := "default"
+	taskDefinitionName := "my-task-definition"
+
+	job := makeJob("my.job", namespace, batchv1.JobStatus{
+		Conditions: []batchv1.JobCondition{
+			{
+				Type:    batchv1.JobFailed,
+				Reason:  "BackoffLimitExceeded",
+				Message: "Job has reached the specified backoff limit",
+			},
+		},
+	})
+	job.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"job-name": job.Name}}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pod",
+			Namespace: namespace,
+			Labels:    map[string]string{"job-name": job.Name},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:  "keptn-function-runner",
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"},
+					},
+				},
+			},
+		},
+	}
+
+	fakeClient := testcommon.NewTestClient(job, pod)
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+		PodClient:   k8sfake.NewSimpleClientset(pod).CoreV1(),
+	}
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+	task.Status.JobName = job.Name
+
+	r.updateTaskStatus(context.TODO(), job, task)
+
+	require.Equal(t, apicommon.StateFailed, task.Status.Status)
+	require.Equal(t, "BackoffLimitExceeded", task.Status.Reason)
+	require.Equal(t, "OOMKilled", task.Status.TerminationReason)
+	// the fake clientset always returns the same logs
+	require.Equal(t, "fake logs", task.Status.Logs)
+
+	// without a pod client, only the termination reason is stored
+	r.PodClient = nil
+	task = makeTask("my-task", namespace, taskDefinitionName)
+
+	r.updateTaskStatus(context.TODO(), job, task)
+
+	require.Equal(t, "OOMKilled", task.Status.TerminationReason)
+	require.Empty(t, task.Status.Logs)
+}
+
+func Test_tail(t *testing.T) {
+	require.Equal(t, "short", tail("short", 10))
+	require.Equal(t, "6789", tail("0123456789", 4))
+	// multi-byte characters are not split
+	require.Equal(t, "b", tail("aäb", 2))
+}
+
+func Test_parseOutputs(t *testing.T) {
+	outputs, err := parseOutputs(`{"name":"value","nested":{"key":1}}`)
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"name": "value", "nested": `{"key":1}`}, outputs)
+
+	_, err = parseOutputs("not a json object")
+	require.NotNil(t, err)
+
+	_, err = parseOutputs(`["a","b"]`)
+	require.NotNil(t, err)
 }
 
 func TestKeptnTaskReconciler_generateJob(t *testing.T) {
@@ -318,6 +667,182 @@ func TestKeptnTaskReconciler_generateJob(t *testing.T) {
 	}, resultingJob.Annotations)
 }
 
+func TestKeptnTaskReconciler_generateJobWithRetryPolicy(t *testing.T) {
+	namespace := "default"
+	taskDefinitionName := "my-task-definition"
+	cmName := "my-cm"
+
+	taskDefinition := makeTaskDefinitionWithConfigmapRef(taskDefinitionName, namespace, cmName)
+	taskDefinition.Status.Function.ConfigMap = cmName
+	fakeClient := testcommon.NewTestClient(taskDefinition)
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+	task.Spec.RetryPolicy = &apilifecycle.RetryPolicy{
+		OnExitCodes: []int32{42, 1, 42},
+	}
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	resultingJob, err := r.generateJob(context.TODO(), task, taskDefinition, ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: namespace},
+	})
+	require.Nil(t, err)
+
+	require.Equal(t, int32(0), *resultingJob.Spec.BackoffLimit)
+	require.Equal(t, v1.RestartPolicyNever, resultingJob.Spec.Template.Spec.RestartPolicy)
+	require.Equal(t, &batchv1.PodFailurePolicy{
+		Rules: []batchv1.PodFailurePolicyRule{
+			{
+				Action: batchv1.PodFailurePolicyActionFailJob,
+				OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+					Operator: batchv1.PodFailurePolicyOnExitCodesOpNotIn,
+					Values:   []int32{1, 42},
+				},
+			},
+		},
+	}, resultingJob.Spec.PodFailurePolicy)
+
+	// without exit codes, all failed attempts are retried
+	task.Spec.RetryPolicy.OnExitCodes = nil
+	resultingJob, err = r.generateJob(context.TODO(), task, taskDefinition, ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: namespace},
+	})
+	require.Nil(t, err)
+	require.Nil(t, resultingJob.Spec.PodFailurePolicy)
+}
+
+func TestKeptnTaskReconciler_generateJobWithPodTemplate(t *testing.T) {
+	namespace := "default"
+	taskDefinitionName := "my-task-definition"
+	cmName := "my-cm"
+	runAsNonRoot := true
+
+	taskDefinition := makeTaskDefinitionWithConfigmapRef(taskDefinitionName, namespace, cmName)
+	taskDefinition.Status.Function.ConfigMap = cmName
+	taskDefinition.Spec.PodTemplate = &v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"team": "my-team"},
+		},
+		Spec: v1.PodSpec{
+			NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+			Tolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "tasks", Effect: v1.TaintEffectNoSchedule},
+			},
+			SecurityContext: &v1.PodSecurityContext{RunAsNonRoot: &runAsNonRoot},
+			RestartPolicy:   v1.RestartPolicyOnFailure,
+			Containers: []v1.Container{
+				{
+					Name: "keptn-function-runner",
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("128Mi")},
+					},
+				},
+				{
+					Name:  "sidecar",
+					Image: "busybox",
+				},
+			},
+			Volumes: []v1.Volume{
+				{Name: "extra", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+			},
+		},
+	}
+	fakeClient := testcommon.NewTestClient(taskDefinition)
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+	task.Spec.RetryPolicy = &apilifecycle.RetryPolicy{}
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	resultingJob, err := r.generateJob(context.TODO(), task, taskDefinition, ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: namespace},
+	})
+	require.Nil(t, err)
+
+	podSpec := resultingJob.Spec.Template.Spec
+	require.Equal(t, map[string]string{"label1": "label2", "team": "my-team"}, resultingJob.Spec.Template.Labels)
+	require.Equal(t, map[string]string{"kubernetes.io/os": "linux"}, podSpec.NodeSelector)
+	require.Equal(t, taskDefinition.Spec.PodTemplate.Spec.Tolerations, podSpec.Tolerations)
+	require.Equal(t, &runAsNonRoot, podSpec.SecurityContext.RunAsNonRoot)
+	// the retry policy requires each attempt to be executed in a separate pod
+	require.Equal(t, v1.RestartPolicyNever, podSpec.RestartPolicy)
+
+	require.Len(t, podSpec.Containers, 2)
+	require.Equal(t, "keptn-function-runner", podSpec.Containers[0].Name)
+	require.NotEmpty(t, podSpec.Containers[0].Env)
+	require.Equal(t, resource.MustParse("128Mi"), podSpec.Containers[0].Resources.Limits[v1.ResourceMemory])
+	require.Equal(t, "sidecar", podSpec.Containers[1].Name)
+
+	volumes := []string{}
+	for _, volume := range podSpec.Volumes {
+		volumes = append(volumes, volume.Name)
+	}
+	require.ElementsMatch(t, []string{FunctionMountName, "extra"}, volumes)
+}
+
+func TestKeptnTaskReconciler_updateTaskStatusRetry(t *testing.T) {
+	namespace := "default"
+	retries := int32(1)
+
+	failedJob := func(reason string) *batchv1.Job {
+		return makeJob("my.job", namespace, batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{
+				{
+					Type:   batchv1.JobFailed,
+					Reason: reason,
+				},
+			},
+		})
+	}
+
+	r := &KeptnTaskReconciler{
+		Client:      testcommon.NewTestClient(),
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+	}
+
+	task := makeTask("my-task", namespace, "my-task-definition")
+	task.Spec.Retries = &retries
+	task.Spec.RetryPolicy = &apilifecycle.RetryPolicy{
+		Delay: metav1.Duration{Duration: time.Minute},
+	}
+	task.Status.JobName = "my.job"
+	task.Status.Attempt = 1
+	task.Status.Status = apicommon.StateProgressing
+
+	// the first attempt failed, so the task is retried after the delay
+	r.updateTaskStatus(context.TODO(), failedJob(batchv1.JobReasonBackoffLimitExceeded), task)
+
+	require.Equal(t, apicommon.StateProgressing, task.Status.Status)
+	require.Empty(t, task.Status.JobName)
+	require.WithinDuration(t, time.Now().Add(time.Minute), task.Status.NextAttemptTime.Time, 5*time.Second)
+
+	// the retry failed as well, so the task fails
+	task.Status.JobName = "my.job"
+	task.Status.Attempt = 2
+	r.updateTaskStatus(context.TODO(), failedJob(batchv1.JobReasonBackoffLimitExceeded), task)
+
+	require.Equal(t, apicommon.StateFailed, task.Status.Status)
+
+	// exit codes that should not be retried fail the task immediately
+	task.Status.Status = apicommon.StateProgressing
+	task.Status.Attempt = 1
+	r.updateTaskStatus(context.TODO(), failedJob(batchv1.JobReasonPodFailurePolicy), task)
+
+	require.Equal(t, apicommon.StateFailed, task.Status.Status)
+	require.Equal(t, batchv1.JobReasonPodFailurePolicy, task.Status.Reason)
+}
+
 func makeJob(name, namespace string, status batchv1.JobStatus) *batchv1.Job {
 	return &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
@@ -3,6 +3,7 @@ package keptntask
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"dario.cat/mergo"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
@@ -33,9 +34,11 @@ type RuntimeExecutionParams struct {
 	SecureParameters string
 	CmdParameters    string
 	URL              string
+	Checksum         string
 	Context          apilifecycle.TaskContext
 	Image            string
 	MountPath        string
+	Mounts           []apilifecycle.TaskMount
 }
 
 const (
@@ -44,7 +47,11 @@ const (
 	Data               = "DATA"
 	CmdArgs            = "CMD_ARGS"
 	Script             = "SCRIPT"
+	OutputFile         = "OUTPUT_FILE"
+	ScriptChecksum     = "SCRIPT_SHA256"
 	FunctionMountName  = "function-mount"
+	// OutputFilePath is the file a task can write a JSON object with its outputs to
+	OutputFilePath = "/dev/termination-log"
 )
 
 func (fb *RuntimeBuilder) CreateContainer(ctx context.Context) (*corev1.Container, error) {
@@ -69,6 +76,7 @@ func (fb *RuntimeBuilder) CreateContainer(ctx context.Context) (*corev1.Containe
 	}
 	envVars = append(envVars, corev1.EnvVar{Name: KeptnContextEnvVar, Value: string(jsonParams)})
 	envVars = append(envVars, corev1.EnvVar{Name: CmdArgs, Value: params.CmdParameters})
+	envVars = append(envVars, corev1.EnvVar{Name: OutputFile, Value: OutputFilePath})
 	if params.SecureParameters != "" {
 		envVars = append(envVars, corev1.EnvVar{
 			Name: SecureData,
@@ -102,6 +110,10 @@ func (fb *RuntimeBuilder) CreateContainer(ctx context.Context) (*corev1.Containe
 		}
 	} else {
 		envVars = append(envVars, corev1.EnvVar{Name: Script, Value: params.URL})
+		if params.Checksum != "" {
+			// the runtime verifies the code it downloads before executing it
+			envVars = append(envVars, corev1.EnvVar{Name: ScriptChecksum, Value: strings.ToLower(params.Checksum)})
+		}
 	}
 
 	container.Env = envVars
@@ -133,6 +145,14 @@ func (fb *RuntimeBuilder) CreateVolume(ctx context.Context) (*corev1.Volume, err
 
 }
 
+func (fb *RuntimeBuilder) GetMounts(ctx context.Context) ([]apilifecycle.TaskMount, error) {
+	params, err := fb.getParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return params.Mounts, nil
+}
+
 func (fb *RuntimeBuilder) getParams(ctx context.Context) (*RuntimeExecutionParams, error) {
 	params, hasParent, err := fb.parseRuntimeTaskDefinition(
 		fb.options.funcSpec,
@@ -166,6 +186,8 @@ func (fb *RuntimeBuilder) getParams(ctx context.Context) (*RuntimeExecutionParam
 	if fb.options.task.Spec.SecureParameters.Secret != "" {
 		params.SecureParameters = fb.options.task.Spec.SecureParameters.Secret
 	}
+
+	params.Mounts = mergeMounts(params.Mounts, fb.options.task.Spec.Mounts)
 	return &params, nil
 }
 
@@ -189,6 +211,7 @@ func (fb *RuntimeBuilder) parseRuntimeTaskDefinition(spec *apilifecycle.RuntimeS
 			return params, false, fmt.Errorf(controllererrors.ErrNoConfigMapMsg, namespace, name)
 		}
 		params.URL = spec.HttpReference.Url
+		params.Checksum = spec.HttpReference.Sha256
 	}
 
 	// Check if there are parameters provided
@@ -201,6 +224,11 @@ func (fb *RuntimeBuilder) parseRuntimeTaskDefinition(spec *apilifecycle.RuntimeS
 		params.SecureParameters = spec.SecureParameters.Secret
 	}
 
+	// Check if there are secrets or config maps to be mounted
+	if len(spec.Mounts) > 0 {
+		params.Mounts = spec.Mounts
+	}
+
 	// Check if there is a cmd params provided
 	if spec.CmdParameters != "" {
 		params.CmdParameters = spec.CmdParameters
@@ -215,12 +243,16 @@ func (fb *RuntimeBuilder) handleParent(ctx context.Context, params *RuntimeExecu
 		fb.options.eventSender.Emit(apicommon.PhaseCreateTask, "Warning", fb.options.task, apicommon.PhaseStateNotFound, fmt.Sprintf("could not find KeptnTaskDefinition: %s ", fb.options.task.Spec.TaskDefinition), "")
 		return err
 	}
+	if err := taskdefinition.VerifyCachedCode(ctx, fb.options.Client, parentDefinition); err != nil {
+		return err
+	}
 	parSpec := taskdefinition.GetRuntimeSpec(parentDefinition)
 	// if the parent has also another parent, the data from the grandparent are already copied to the parent and therefore parent can copy it's data to the child
 	parentJobParams, _, err = fb.parseRuntimeTaskDefinition(parSpec, parentDefinition.Name, parentDefinition.Namespace, parentDefinition.Status.Function.ConfigMap)
 	if err != nil {
 		return err
 	}
+	childMounts := params.Mounts
 	// merge parameter to make sure we use child task data for env var and secrets
 	err = mergo.Merge(params, parentJobParams)
 	if err != nil {
@@ -228,8 +260,12 @@ func (fb *RuntimeBuilder) handleParent(ctx context.Context, params *RuntimeExecu
 		return err
 	}
 
+	// mounts of the child replace the mounts of the parent with the same name
+	params.Mounts = mergeMounts(parentJobParams.Mounts, childMounts)
+
 	// make sure we take the task from the parent
 	params.URL = parSpec.HttpReference.Url
+	params.Checksum = parSpec.HttpReference.Sha256
 	params.ConfigMap = parentDefinition.Status.Function.ConfigMap
 
 	// rewrite image and mount based on parent
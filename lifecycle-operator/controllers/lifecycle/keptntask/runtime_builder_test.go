@@ -314,3 +314,60 @@ func TestJSBuilder_getParams(t *testing.T) {
 		})
 	}
 }
+
+func TestJSBuilder_getParamsMounts(t *testing.T) {
+	parent := &apilifecycle.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "parent",
+			Namespace: "default",
+		},
+		Spec: apilifecycle.KeptnTaskDefinitionSpec{
+			Deno: &apilifecycle.RuntimeSpec{
+				HttpReference: apilifecycle.HttpReference{Url: "donothing"},
+				Mounts: []apilifecycle.TaskMount{
+					{Name: "credentials", Secret: "parent-secret"},
+					{Name: "settings", ConfigMap: "parent-settings", MountPath: "/etc/settings"},
+				},
+			},
+		},
+	}
+	child := &apilifecycle.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "child",
+			Namespace: "default",
+		},
+		Spec: apilifecycle.KeptnTaskDefinitionSpec{
+			Deno: &apilifecycle.RuntimeSpec{
+				FunctionReference: apilifecycle.FunctionReference{Name: parent.Name},
+				Mounts: []apilifecycle.TaskMount{
+					{Name: "credentials", Secret: "child-secret"},
+				},
+			},
+		},
+	}
+	task := makeTask("myt", "default", child.Name)
+	task.Spec.Mounts = []apilifecycle.TaskMount{
+		{Name: "token", Secret: "task-secret", Env: map[string]string{"TOKEN": "token"}},
+	}
+
+	js := &RuntimeBuilder{
+		options: BuilderOptions{
+			Client:      testcommon.NewTestClient(parent, child),
+			eventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+			req: ctrl.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default"},
+			},
+			Log:      testr.New(t),
+			funcSpec: taskdefinition.GetRuntimeSpec(child),
+			task:     task,
+		},
+	}
+
+	mounts, err := js.GetMounts(context.TODO())
+	require.Nil(t, err)
+	require.Equal(t, []apilifecycle.TaskMount{
+		{Name: "settings", ConfigMap: "parent-settings", MountPath: "/etc/settings"}, // inherited from the parent
+		{Name: "credentials", Secret: "child-secret"},                                // replaced by the child
+		{Name: "token", Secret: "task-secret", Env: map[string]string{"TOKEN": "token"}},
+	}, mounts)
+}
@@ -0,0 +1,122 @@
+package keptntask
+
+import (
+	"fmt"
+	"sort"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// mergeMounts returns the given mounts, where the mounts of base are replaced by the overrides with the same name
+func mergeMounts(base []apilifecycle.TaskMount, overrides []apilifecycle.TaskMount) []apilifecycle.TaskMount {
+	if len(overrides) == 0 {
+		return base
+	}
+	result := make([]apilifecycle.TaskMount, 0, len(base)+len(overrides))
+	for _, mount := range base {
+		if !containsMount(overrides, mount.Name) {
+			result = append(result, mount)
+		}
+	}
+	return append(result, overrides...)
+}
+
+func containsMount(mounts []apilifecycle.TaskMount, name string) bool {
+	for _, mount := range mounts {
+		if mount.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// applyMounts makes the referenced Secrets and ConfigMaps available to the task container of the given pod,
+// either as environment variables or as files
+func applyMounts(podSpec *corev1.PodSpec, mounts []apilifecycle.TaskMount) error {
+	if len(mounts) == 0 || len(podSpec.Containers) == 0 {
+		return nil
+	}
+	container := &podSpec.Containers[0]
+	for _, mount := range mounts {
+		if (mount.Secret == "") == (mount.ConfigMap == "") {
+			return fmt.Errorf(controllererrors.ErrInvalidTaskMountMsg, mount.Name)
+		}
+		if len(mount.Env) == 0 && mount.MountPath == "" {
+			container.EnvFrom = append(container.EnvFrom, getEnvFromSource(mount))
+			continue
+		}
+		container.Env = append(container.Env, getEnvVars(mount)...)
+		if mount.MountPath != "" {
+			podSpec.Volumes = append(podSpec.Volumes, getMountVolume(mount))
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      mount.Name,
+				ReadOnly:  true,
+				MountPath: mount.MountPath,
+			})
+		}
+	}
+	return nil
+}
+
+func getEnvFromSource(mount apilifecycle.TaskMount) corev1.EnvFromSource {
+	if mount.Secret != "" {
+		return corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: mount.Secret},
+			},
+		}
+	}
+	return corev1.EnvFromSource{
+		ConfigMapRef: &corev1.ConfigMapEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: mount.ConfigMap},
+		},
+	}
+}
+
+func getEnvVars(mount apilifecycle.TaskMount) []corev1.EnvVar {
+	names := make([]string, 0, len(mount.Env))
+	for name := range mount.Env {
+		names = append(names, name)
+	}
+	// sort the variables to generate the same Job for the same mounts
+	sort.Strings(names)
+
+	envVars := make([]corev1.EnvVar, 0, len(names))
+	for _, name := range names {
+		source := &corev1.EnvVarSource{}
+		if mount.Secret != "" {
+			source.SecretKeyRef = &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: mount.Secret},
+				Key:                  mount.Env[name],
+			}
+		} else {
+			source.ConfigMapKeyRef = &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: mount.ConfigMap},
+				Key:                  mount.Env[name],
+			}
+		}
+		envVars = append(envVars, corev1.EnvVar{Name: name, ValueFrom: source})
+	}
+	return envVars
+}
+
+func getMountVolume(mount apilifecycle.TaskMount) corev1.Volume {
+	if mount.Secret != "" {
+		return corev1.Volume{
+			Name: mount.Name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: mount.Secret},
+			},
+		}
+	}
+	return corev1.Volume{
+		Name: mount.Name,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: mount.ConfigMap},
+			},
+		},
+	}
+}
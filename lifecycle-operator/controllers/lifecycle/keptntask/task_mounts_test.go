@@ -0,0 +1,75 @@
+package keptntask
+
+import (
+	"testing"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_mergeMounts(t *testing.T) {
+	base := []apilifecycle.TaskMount{
+		{Name: "a", Secret: "secret-a"},
+		{Name: "b", Secret: "secret-b"},
+	}
+
+	require.Equal(t, base, mergeMounts(base, nil))
+	require.Equal(t, []apilifecycle.TaskMount{
+		{Name: "a", Secret: "secret-a"},
+		{Name: "b", ConfigMap: "configmap-b"},
+		{Name: "c", ConfigMap: "configmap-c"},
+	}, mergeMounts(base, []apilifecycle.TaskMount{
+		{Name: "b", ConfigMap: "configmap-b"},
+		{Name: "c", ConfigMap: "configmap-c"},
+	}))
+}
+
+func Test_applyMounts(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "keptn-function-runner"}},
+	}
+
+	err := applyMounts(podSpec, []apilifecycle.TaskMount{
+		{Name: "all-keys", ConfigMap: "my-configmap"},
+		{Name: "some-keys", Secret: "my-secret", Env: map[string]string{"USER": "username", "PASSWORD": "password"}},
+		{Name: "files", Secret: "my-certs", MountPath: "/etc/certs"},
+	})
+	require.Nil(t, err)
+
+	container := podSpec.Containers[0]
+	require.Equal(t, []corev1.EnvFromSource{
+		{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "my-configmap"}}},
+	}, container.EnvFrom)
+	require.Equal(t, []corev1.EnvVar{
+		{
+			Name: "PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+				Key:                  "password",
+			}},
+		},
+		{
+			Name: "USER",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+				Key:                  "username",
+			}},
+		},
+	}, container.Env)
+	require.Equal(t, []corev1.VolumeMount{
+		{Name: "files", ReadOnly: true, MountPath: "/etc/certs"},
+	}, container.VolumeMounts)
+	require.Equal(t, []corev1.Volume{
+		{Name: "files", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "my-certs"}}},
+	}, podSpec.Volumes)
+}
+
+func Test_applyMountsInvalid(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "keptn-function-runner"}},
+	}
+
+	err := applyMounts(podSpec, []apilifecycle.TaskMount{{Name: "invalid"}})
+	require.ErrorContains(t, err, "mount invalid must reference either a Secret or a ConfigMap")
+}
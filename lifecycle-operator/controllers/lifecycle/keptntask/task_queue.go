@@ -0,0 +1,194 @@
+package keptntask
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	controllercommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// taskAdmissions keeps track of the KeptnTasks that have been admitted to create their Job.
+// Admitted tasks are counted as running until they complete, as the JobName of their status
+// may not be visible in the cache yet when the next KeptnTask is checked.
+type taskAdmissions struct {
+	mtx      sync.Mutex
+	admitted map[types.NamespacedName]bool
+}
+
+func (a *taskAdmissions) admit(task types.NamespacedName) {
+	if a.admitted == nil {
+		a.admitted = map[types.NamespacedName]bool{}
+	}
+	a.admitted[task] = true
+}
+
+func (a *taskAdmissions) isAdmitted(task types.NamespacedName) bool {
+	return a.admitted[task]
+}
+
+// release frees the slot of the given KeptnTask once it has completed or could not be started
+func (a *taskAdmissions) release(task types.NamespacedName) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	delete(a.admitted, task)
+}
+
+// taskLimits holds the number of KeptnTasks that occupy a slot in the cluster, in each namespace and for each
+// KeptnTaskDefinition that limits the number of its KeptnTasks executed in parallel
+type taskLimits struct {
+	maxParallel             int
+	maxParallelPerNamespace int
+	running                 int
+	runningInNamespace      map[string]int
+	runningOfDefinition     map[types.NamespacedName]int
+}
+
+func (l *taskLimits) add(task *apilifecycle.KeptnTask, definition *apilifecycle.KeptnTaskDefinition) {
+	l.running++
+	l.runningInNamespace[task.Namespace]++
+	if definition != nil && definition.Spec.MaxParallel != nil {
+		l.runningOfDefinition[types.NamespacedName{Namespace: definition.Namespace, Name: definition.Name}]++
+	}
+}
+
+// blockedBy returns a message describing the limit that prevents the given KeptnTask from being started,
+// or an empty string if a slot is free
+func (l *taskLimits) blockedBy(task *apilifecycle.KeptnTask, definition *apilifecycle.KeptnTaskDefinition) string {
+	if l.maxParallel > 0 && l.running >= l.maxParallel {
+		return fmt.Sprintf("waiting for one of the %d KeptnTasks running in the cluster to complete", l.running)
+	}
+	if running := l.runningInNamespace[task.Namespace]; l.maxParallelPerNamespace > 0 && running >= l.maxParallelPerNamespace {
+		return fmt.Sprintf("waiting for one of the %d KeptnTasks running in namespace %s to complete", running, task.Namespace)
+	}
+	if definition != nil && definition.Spec.MaxParallel != nil {
+		running := l.runningOfDefinition[types.NamespacedName{Namespace: definition.Namespace, Name: definition.Name}]
+		if running >= int(*definition.Spec.MaxParallel) {
+			return fmt.Sprintf("waiting for one of the %d KeptnTasks of KeptnTaskDefinition %s to complete", running, task.Spec.TaskDefinition)
+		}
+	}
+	return ""
+}
+
+// queueTask checks whether a Job may be created for the given KeptnTask without exceeding the maximum number
+// of KeptnTasks executed in parallel in the cluster, in the namespace of the task or for its KeptnTaskDefinition.
+// Free slots are handed out in the order in which the waiting KeptnTasks have been created.
+// If no slot is free, the KeptnTask is marked as queued and true is returned.
+// Otherwise, the KeptnTask is admitted and occupies a slot until it is released.
+func (r *KeptnTaskReconciler) queueTask(ctx context.Context, task *apilifecycle.KeptnTask) (bool, error) {
+	definition, err := controllercommon.GetTaskDefinition(r.Client, r.Log, ctx, task.Spec.TaskDefinition, task.Namespace)
+	if err != nil {
+		// the missing KeptnTaskDefinition is reported when creating the Job
+		return false, nil
+	}
+
+	limits := &taskLimits{
+		maxParallel:             config.Instance().GetMaxParallelTasks(),
+		maxParallelPerNamespace: config.Instance().GetMaxParallelTasksPerNamespace(),
+		runningInNamespace:      map[string]int{},
+		runningOfDefinition:     map[types.NamespacedName]int{},
+	}
+	if limits.maxParallel == 0 && limits.maxParallelPerNamespace == 0 && definition.Spec.MaxParallel == nil {
+		r.dequeueTask(task)
+		return false, nil
+	}
+
+	// admission decisions are serialized, so that a slot is not handed out twice
+	r.admissions.mtx.Lock()
+	defer r.admissions.mtx.Unlock()
+
+	key := types.NamespacedName{Namespace: task.Namespace, Name: task.Name}
+	if r.admissions.isAdmitted(key) {
+		return false, nil
+	}
+
+	tasks := &apilifecycle.KeptnTaskList{}
+	if err := r.Client.List(ctx, tasks); err != nil {
+		return false, err
+	}
+
+	definitions := map[string]*apilifecycle.KeptnTaskDefinition{}
+	var waiting []*apilifecycle.KeptnTask
+	for i := range tasks.Items {
+		other := &tasks.Items[i]
+		if (other.Namespace == task.Namespace && other.Name == task.Name) || other.Status.Status.IsCompleted() {
+			continue
+		}
+		if other.IsRunning() || r.admissions.isAdmitted(types.NamespacedName{Namespace: other.Namespace, Name: other.Name}) {
+			limits.add(other, r.getCachedTaskDefinition(ctx, definitions, other))
+		} else if other.Status.JobName == "" && isWaitingBefore(other, task) {
+			waiting = append(waiting, other)
+		}
+	}
+
+	// the KeptnTasks waiting for longer get the free slots first
+	sort.Slice(waiting, func(i, j int) bool {
+		return isWaitingBefore(waiting[i], waiting[j])
+	})
+	for _, other := range waiting {
+		otherDefinition := r.getCachedTaskDefinition(ctx, definitions, other)
+		if limits.blockedBy(other, otherDefinition) == "" {
+			limits.add(other, otherDefinition)
+		}
+	}
+
+	message := limits.blockedBy(task, definition)
+	if message == "" {
+		r.admissions.admit(key)
+		r.dequeueTask(task)
+		return false, nil
+	}
+
+	if !task.IsQueued() {
+		r.Log.Info("Queueing KeptnTask", "task", task.Name, "namespace", task.Namespace, "reason", message)
+		r.EventSender.Emit(apicommon.PhaseCreateTask, "Normal", task, apicommon.PhaseStateStatusChanged, "KeptnTask queued: "+message, "")
+	}
+	task.Status.Status = apicommon.StatePending
+	task.Status.Reason = apilifecycle.TaskQueuedReason
+	task.Status.Message = message
+	return true, nil
+}
+
+// dequeueTask removes the queued state of the given KeptnTask before its Job is created
+func (r *KeptnTaskReconciler) dequeueTask(task *apilifecycle.KeptnTask) {
+	if task.IsQueued() {
+		task.Status.Reason = ""
+		task.Status.Message = ""
+	}
+}
+
+// getCachedTaskDefinition returns the KeptnTaskDefinition of the given KeptnTask,
+// looking up each reference only once per check
+func (r *KeptnTaskReconciler) getCachedTaskDefinition(ctx context.Context, definitions map[string]*apilifecycle.KeptnTaskDefinition, task *apilifecycle.KeptnTask) *apilifecycle.KeptnTaskDefinition {
+	ref := task.Namespace + controllercommon.TaskDefinitionRefSeparator + task.Spec.TaskDefinition
+	if definition, ok := definitions[ref]; ok {
+		return definition
+	}
+	definition, err := controllercommon.GetTaskDefinition(r.Client, r.Log, ctx, task.Spec.TaskDefinition, task.Namespace)
+	if err != nil {
+		definition = nil
+	}
+	definitions[ref] = definition
+	return definition
+}
+
+// isWaitingBefore checks whether the KeptnTask a has been waiting for a slot longer than the KeptnTask b.
+// KeptnTasks that wait for the delay of their retry policy are not waiting for a slot.
+func isWaitingBefore(a *apilifecycle.KeptnTask, b *apilifecycle.KeptnTask) bool {
+	if time.Now().Before(a.Status.NextAttemptTime.Time) {
+		return false
+	}
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
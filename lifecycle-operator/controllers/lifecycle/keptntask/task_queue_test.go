@@ -0,0 +1,266 @@
+package keptntask
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestKeptnTaskReconciler_queueTask(t *testing.T) {
+	namespace := "default"
+	maxParallel := int32(1)
+
+	tests := []struct {
+		name                    string
+		maxParallel             *int32
+		maxParallelTasks        int
+		maxParallelPerNamespace int
+		runningTasks            []*apilifecycle.KeptnTask
+		wantQueued              bool
+		wantMessage             string
+	}{
+		{
+			name: "no limits",
+			runningTasks: []*apilifecycle.KeptnTask{
+				makeRunningTask("running-1", namespace, "my-task-definition"),
+			},
+			wantQueued: false,
+		},
+		{
+			name:        "limit of the task definition reached",
+			maxParallel: &maxParallel,
+			runningTasks: []*apilifecycle.KeptnTask{
+				makeRunningTask("running-1", namespace, "my-task-definition"),
+			},
+			wantQueued:  true,
+			wantMessage: "waiting for one of the 1 KeptnTasks of KeptnTaskDefinition my-task-definition to complete",
+		},
+		{
+			name:        "limit of the task definition not reached by tasks of other definitions",
+			maxParallel: &maxParallel,
+			runningTasks: []*apilifecycle.KeptnTask{
+				makeRunningTask("running-1", namespace, "other-task-definition"),
+			},
+			wantQueued: false,
+		},
+		{
+			name:                    "limit of the namespace reached",
+			maxParallelPerNamespace: 2,
+			runningTasks: []*apilifecycle.KeptnTask{
+				makeRunningTask("running-1", namespace, "other-task-definition"),
+				makeRunningTask("running-2", namespace, "other-task-definition"),
+				makeRunningTask("running-3", "other-namespace", "other-task-definition"),
+			},
+			wantQueued:  true,
+			wantMessage: "waiting for one of the 2 KeptnTasks running in namespace default to complete",
+		},
+		{
+			name:                    "limit of the namespace not reached by tasks of other namespaces",
+			maxParallelPerNamespace: 2,
+			runningTasks: []*apilifecycle.KeptnTask{
+				makeRunningTask("running-1", namespace, "other-task-definition"),
+				makeRunningTask("running-2", "other-namespace", "other-task-definition"),
+			},
+			wantQueued: false,
+		},
+		{
+			name:             "limit of the cluster reached",
+			maxParallelTasks: 2,
+			runningTasks: []*apilifecycle.KeptnTask{
+				makeRunningTask("running-1", namespace, "other-task-definition"),
+				makeRunningTask("running-2", "other-namespace", "other-task-definition"),
+			},
+			wantQueued:  true,
+			wantMessage: "waiting for one of the 2 KeptnTasks running in the cluster to complete",
+		},
+		{
+			name:             "completed tasks are not counted",
+			maxParallelTasks: 1,
+			runningTasks: []*apilifecycle.KeptnTask{
+				func() *apilifecycle.KeptnTask {
+					task := makeRunningTask("completed-1", namespace, "my-task-definition")
+					task.Status.Status = apicommon.StateSucceeded
+					return task
+				}(),
+			},
+			wantQueued: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskDefinition := makeTaskDefinitionWithConfigmapRef("my-task-definition", namespace, "my-cm")
+			taskDefinition.Spec.MaxParallel = tt.maxParallel
+
+			objs := []client.Object{taskDefinition}
+			for _, task := range tt.runningTasks {
+				objs = append(objs, task)
+			}
+			fakeClient := testcommon.NewTestClient(objs...)
+			for _, task := range tt.runningTasks {
+				require.Nil(t, fakeClient.Status().Update(context.TODO(), task))
+			}
+
+			config.Instance().SetMaxParallelTasks(tt.maxParallelTasks)
+			config.Instance().SetMaxParallelTasksPerNamespace(tt.maxParallelPerNamespace)
+			defer config.Instance().SetMaxParallelTasks(0)
+			defer config.Instance().SetMaxParallelTasksPerNamespace(0)
+
+			r := &KeptnTaskReconciler{
+				Client:      fakeClient,
+				EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+				Log:         ctrl.Log.WithName("task-controller"),
+				Scheme:      fakeClient.Scheme(),
+			}
+
+			task := makeTask("my-task", namespace, "my-task-definition")
+			require.Nil(t, fakeClient.Create(context.TODO(), task))
+
+			queued, err := r.queueTask(context.TODO(), task)
+			require.Nil(t, err)
+			require.Equal(t, tt.wantQueued, queued)
+			require.Equal(t, tt.wantQueued, task.IsQueued())
+			require.Equal(t, tt.wantMessage, task.Status.Message)
+		})
+	}
+}
+
+func TestKeptnTaskReconciler_queueTaskDequeue(t *testing.T) {
+	namespace := "default"
+	maxParallel := int32(1)
+
+	taskDefinition := makeTaskDefinitionWithConfigmapRef("my-task-definition", namespace, "my-cm")
+	taskDefinition.Spec.MaxParallel = &maxParallel
+	runningTask := makeRunningTask("running-1", namespace, "my-task-definition")
+
+	fakeClient := testcommon.NewTestClient(taskDefinition, runningTask)
+	require.Nil(t, fakeClient.Status().Update(context.TODO(), runningTask))
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, "my-task-definition")
+	require.Nil(t, fakeClient.Create(context.TODO(), task))
+
+	queued, err := r.queueTask(context.TODO(), task)
+	require.Nil(t, err)
+	require.True(t, queued)
+
+	// the running task completes and frees its slot
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: runningTask.Name, Namespace: namespace}, runningTask)
+	require.Nil(t, err)
+	runningTask.Status.Status = apicommon.StateSucceeded
+	require.Nil(t, fakeClient.Status().Update(context.TODO(), runningTask))
+
+	queued, err = r.queueTask(context.TODO(), task)
+	require.Nil(t, err)
+	require.False(t, queued)
+	require.False(t, task.IsQueued())
+	require.Empty(t, task.Status.Reason)
+	require.Empty(t, task.Status.Message)
+}
+
+func TestKeptnTaskReconciler_queueTaskAdmission(t *testing.T) {
+	namespace := "default"
+	maxParallel := int32(1)
+
+	taskDefinition := makeTaskDefinitionWithConfigmapRef("my-task-definition", namespace, "my-cm")
+	taskDefinition.Spec.MaxParallel = &maxParallel
+	firstTask := makeTask("first-task", namespace, "my-task-definition")
+	secondTask := makeTask("second-task", namespace, "my-task-definition")
+
+	fakeClient := testcommon.NewTestClient(taskDefinition, firstTask, secondTask)
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	queued, err := r.queueTask(context.TODO(), firstTask)
+	require.Nil(t, err)
+	require.False(t, queued)
+
+	// the Job of the first task is not yet visible in its status, but the task occupies the slot
+	queued, err = r.queueTask(context.TODO(), secondTask)
+	require.Nil(t, err)
+	require.True(t, queued)
+	require.Equal(t, "waiting for one of the 1 KeptnTasks of KeptnTaskDefinition my-task-definition to complete", secondTask.Status.Message)
+
+	// checking an admitted task again does not queue it
+	queued, err = r.queueTask(context.TODO(), firstTask)
+	require.Nil(t, err)
+	require.False(t, queued)
+
+	// the first task completes and frees its slot
+	r.admissions.release(types.NamespacedName{Namespace: namespace, Name: firstTask.Name})
+	firstTask.Status.Status = apicommon.StateSucceeded
+	require.Nil(t, fakeClient.Status().Update(context.TODO(), firstTask))
+
+	queued, err = r.queueTask(context.TODO(), secondTask)
+	require.Nil(t, err)
+	require.False(t, queued)
+}
+
+func TestKeptnTaskReconciler_queueTaskInOrder(t *testing.T) {
+	namespace := "default"
+
+	olderTask := makeTask("b-older-task", namespace, "my-task-definition")
+	olderTask.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Minute))
+	newerTask := makeTask("a-newer-task", namespace, "other-task-definition")
+	newerTask.CreationTimestamp = metav1.NewTime(time.Now())
+
+	fakeClient := testcommon.NewTestClient(
+		makeTaskDefinitionWithConfigmapRef("my-task-definition", namespace, "my-cm"),
+		makeTaskDefinitionWithConfigmapRef("other-task-definition", namespace, "my-cm"),
+		olderTask,
+		newerTask,
+	)
+
+	config.Instance().SetMaxParallelTasksPerNamespace(1)
+	defer config.Instance().SetMaxParallelTasksPerNamespace(0)
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	// the free slot is reserved for the task that has been waiting longer
+	queued, err := r.queueTask(context.TODO(), newerTask)
+	require.Nil(t, err)
+	require.True(t, queued)
+
+	queued, err = r.queueTask(context.TODO(), olderTask)
+	require.Nil(t, err)
+	require.False(t, queued)
+
+	queued, err = r.queueTask(context.TODO(), newerTask)
+	require.Nil(t, err)
+	require.True(t, queued)
+}
+
+func makeRunningTask(name, namespace, taskDefinitionName string) *apilifecycle.KeptnTask {
+	task := makeTask(name, namespace, taskDefinitionName)
+	task.Status.JobName = name + "-job"
+	task.Status.Status = apicommon.StateProgressing
+	return task
+}
@@ -22,7 +22,9 @@ import (
 
 	"github.com/go-logr/logr"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
 	controllercommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/taskdefinition"
 	corev1 "k8s.io/api/core/v1"
@@ -38,6 +40,7 @@ type KeptnTaskDefinitionReconciler struct {
 	Scheme      *runtime.Scheme
 	Log         logr.Logger
 	EventSender eventsender.IEvent
+	Config      config.IConfig
 }
 
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntaskdefinitions,verbs=get;list;watch;create;update;patch;delete
@@ -60,6 +63,7 @@ func (r *KeptnTaskDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.
 		r.Log.Error(err, "Failed to get the KeptnTaskDefinition")
 		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
 	}
+	result := ctrl.Result{}
 	defSpec := taskdefinition.GetRuntimeSpec(definition)
 	if definition.Spec.Container == nil && defSpec != nil { // if the spec is well-defined
 
@@ -77,6 +81,22 @@ func (r *KeptnTaskDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.
 		functionCm := cm
 		if taskdefinition.IsInline(defSpec) {
 			functionCm = r.generateConfigMap(defSpec, cmName, definition.Namespace)
+		} else if taskdefinition.IsCached(defSpec) {
+			cachedCm, refreshAfter, err := r.generateCachedConfigMap(ctx, definition, defSpec, cmName, cm)
+			if err != nil {
+				r.Log.Error(err, "could not fetch function code", "url", defSpec.HttpReference.Url)
+				r.EventSender.Emit(apicommon.PhaseReconcileTask, "Warning", definition, apicommon.PhaseStateFailed, "could not fetch function code: "+err.Error(), "")
+				result = ctrl.Result{Requeue: true, RequeueAfter: time.Minute}
+				// keep using the previously fetched code until the code can be fetched again,
+				// unless it has been fetched for a different URL or checksum
+				if cm == nil || !taskdefinition.IsCacheValid(cm, defSpec.HttpReference) {
+					functionCm = nil
+					definition.Status.Function.ConfigMap = ""
+				}
+			} else {
+				functionCm = cachedCm
+				result = ctrl.Result{RequeueAfter: refreshAfter}
+			}
 		}
 		// compare and handle updated and existing
 		r.reconcileConfigMap(ctx, functionCm, cm)
@@ -93,7 +113,7 @@ func (r *KeptnTaskDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.
 	}
 
 	r.Log.Info("Finished Reconciling KeptnTaskDefinition", "requestInfo", requestInfo)
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
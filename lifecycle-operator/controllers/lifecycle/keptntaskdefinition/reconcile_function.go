@@ -3,9 +3,11 @@ package keptntaskdefinition
 import (
 	"context"
 	"reflect"
+	"time"
 
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/taskdefinition"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -26,6 +28,52 @@ func (r *KeptnTaskDefinitionReconciler) generateConfigMap(spec *apilifecycle.Run
 	return functionCm
 }
 
+// generateCachedConfigMap fetches the code referenced by the HTTP reference of the given KeptnTaskDefinition
+// and returns a ConfigMap owned by the KeptnTaskDefinition containing it.
+// If the code stored in the existing ConfigMap is up-to-date, the existing ConfigMap is returned together with
+// the time until the code needs to be refreshed.
+func (r *KeptnTaskDefinitionReconciler) generateCachedConfigMap(ctx context.Context, definition *apilifecycle.KeptnTaskDefinition, spec *apilifecycle.RuntimeSpec, name string, cm *corev1.ConfigMap) (*corev1.ConfigMap, time.Duration, error) {
+	ref := spec.HttpReference
+	if cm != nil && taskdefinition.IsCacheValid(cm, ref) {
+		if ref.RefreshInterval.Duration <= 0 {
+			return cm, 0, nil
+		}
+		fetchedAt, err := time.Parse(time.RFC3339, cm.Annotations[apicommon.FetchedAtAnnotation])
+		if refreshAfter := time.Until(fetchedAt.Add(ref.RefreshInterval.Duration)); err == nil && refreshAfter > 0 {
+			return cm, refreshAfter, nil
+		}
+	}
+
+	code, err := taskdefinition.FetchCode(ctx, ref.Url, r.Config.GetFunctionCodeSources())
+	if err != nil {
+		return nil, 0, err
+	}
+	if ref.Sha256 != "" {
+		if err := taskdefinition.VerifyChecksum(code, ref.Sha256); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	functionCm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: definition.Namespace,
+			Annotations: map[string]string{
+				apicommon.SourceURLAnnotation:    ref.Url,
+				apicommon.SourceDigestAnnotation: "sha256:" + taskdefinition.Checksum(code),
+				apicommon.FetchedAtAnnotation:    time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+		Data: map[string]string{
+			"code": string(code),
+		},
+	}
+	if err := controllerutil.SetControllerReference(definition, functionCm, r.Scheme); err != nil {
+		return nil, 0, err
+	}
+	return functionCm, ref.RefreshInterval.Duration, nil
+}
+
 func (r *KeptnTaskDefinitionReconciler) reconcileConfigMap(ctx context.Context, functionCm *corev1.ConfigMap, cm *corev1.ConfigMap) {
 
 	if (cm == nil || reflect.DeepEqual(cm, &corev1.ConfigMap{})) && functionCm != nil { // cm does not exist or new taskdef with inline func
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
@@ -27,6 +28,8 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -48,6 +51,7 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_FailedReplicaSet(t *
 
 	r := &KeptnWorkloadVersionReconciler{
 		Client: fakeClient,
+		Config: config.Instance(),
 	}
 
 	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
@@ -67,6 +71,7 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_UnavailableReplicaSe
 
 	r := &KeptnWorkloadVersionReconciler{
 		Client: fakeClient,
+		Config: config.Instance(),
 	}
 
 	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
@@ -100,10 +105,15 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_WorkloadDeploymentTi
 	require.Equal(t, apicommon.StateProgressing, keptnState)
 	require.False(t, workloadVersion.Status.DeploymentStartTime.IsZero())
 
+	require.Equal(t, workloadVersion.Status.DeploymentStartTime.Add(5*time.Second), workloadVersion.Status.DeploymentDeadline.Time)
+
 	//revert the start time parameter backwards to check the timer
 	workloadVersion.Status.DeploymentStartTime = metav1.Time{
 		Time: workloadVersion.Status.DeploymentStartTime.Add(-10 * time.Second),
 	}
+	workloadVersion.Status.DeploymentDeadline = metav1.Time{
+		Time: workloadVersion.Status.DeploymentDeadline.Add(-10 * time.Second),
+	}
 
 	err = r.Client.Status().Update(context.TODO(), workloadVersion)
 	require.Nil(t, err)
@@ -119,6 +129,76 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_WorkloadDeploymentTi
 	require.Equal(t, strings.Contains(event, "has reached timeout"), true, "wrong message")
 }
 
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_ObservabilityTimeout(t *testing.T) {
+
+	rep := int32(1)
+
+	tests := []struct {
+		name                string
+		workloadTimeout     *metav1.Duration
+		appContextTimeout   *metav1.Duration
+		wantDeploymentDelay time.Duration
+	}{
+		{
+			name:                "timeout of KeptnConfig",
+			wantDeploymentDelay: 5 * time.Minute,
+		},
+		{
+			name:                "timeout of KeptnAppContext",
+			appContextTimeout:   &metav1.Duration{Duration: 10 * time.Minute},
+			wantDeploymentDelay: 10 * time.Minute,
+		},
+		{
+			name:                "timeout of KeptnWorkload takes precedence",
+			workloadTimeout:     &metav1.Duration{Duration: 1 * time.Minute},
+			appContextTimeout:   &metav1.Duration{Duration: 10 * time.Minute},
+			wantDeploymentDelay: 1 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replicaset := makeReplicaSet("myrep", "default", &rep, 0)
+			workloadVersion := makeWorkloadVersionWithRef(replicaset.ObjectMeta, "ReplicaSet")
+			workloadVersion.Spec.AppName = "my-app"
+			workloadVersion.Spec.WorkloadName = "my-app-my-workload"
+			workloadVersion.Spec.ObservabilityTimeout = tt.workloadTimeout
+
+			appVersion := &apilifecycle.KeptnAppVersion{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-app-1.0.0",
+					Namespace: "default",
+				},
+				Spec: apilifecycle.KeptnAppVersionSpec{
+					AppName: "my-app",
+					KeptnAppSpec: apilifecycle.KeptnAppSpec{
+						Version: "1.0.0",
+						Workloads: []apilifecycle.KeptnWorkloadRef{
+							{Name: "my-workload"},
+						},
+					},
+					KeptnAppContextSpec: apilifecycle.KeptnAppContextSpec{
+						ObservabilityTimeout: tt.appContextTimeout,
+					},
+				},
+			}
+
+			fakeClient := testcommon.NewTestClient(replicaset, workloadVersion, appVersion)
+
+			r := &KeptnWorkloadVersionReconciler{
+				Client: fakeClient,
+				Config: config.Instance(),
+			}
+			r.Config.SetObservabilityTimeout(metav1.Duration{Duration: 5 * time.Minute})
+
+			keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+			require.Nil(t, err)
+			require.Equal(t, apicommon.StateProgressing, keptnState)
+			require.Equal(t, workloadVersion.Status.DeploymentStartTime.Add(tt.wantDeploymentDelay), workloadVersion.Status.DeploymentDeadline.Time)
+		})
+	}
+}
+
 func TestKeptnWorkloadVersionReconciler_reconcileDeployment_FailedStatefulSet(t *testing.T) {
 
 	rep := int32(1)
@@ -128,6 +208,7 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_FailedStatefulSet(t
 	fakeClient := testcommon.NewTestClient(statefulsetFail, workloadVersion)
 	r := &KeptnWorkloadVersionReconciler{
 		Client: fakeClient,
+		Config: config.Instance(),
 	}
 
 	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
@@ -147,6 +228,7 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_UnavailableStatefulS
 
 	r := &KeptnWorkloadVersionReconciler{
 		Client: fakeClient,
+		Config: config.Instance(),
 	}
 
 	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
@@ -164,6 +246,7 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_FailedDaemonSet(t *t
 
 	r := &KeptnWorkloadVersionReconciler{
 		Client: fakeClient,
+		Config: config.Instance(),
 	}
 
 	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
@@ -181,6 +264,7 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_UnavailableDaemonSet
 
 	r := &KeptnWorkloadVersionReconciler{
 		Client: fakeClient,
+		Config: config.Instance(),
 	}
 
 	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
@@ -199,30 +283,36 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_ReadyReplicaSet(t *t
 
 	r := &KeptnWorkloadVersionReconciler{
 		Client: fakeClient,
+		Config: config.Instance(),
 	}
 
 	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
 	require.Nil(t, err)
 	require.Equal(t, apicommon.StateSucceeded, keptnState)
 	require.False(t, workloadVersion.Status.DeploymentStartTime.IsZero())
+	require.False(t, workloadVersion.Status.DeploymentEndTime.IsZero())
 }
 
 func TestKeptnWorkloadVersionReconciler_reconcileDeployment_ReadyStatefulSet(t *testing.T) {
 
 	rep := int32(1)
 	statefulSet := makeStatefulSet("mystat", "default", &rep, 1)
+	statefulSet.Status.UpdateRevision = "mystat-2"
 	workloadVersion := makeWorkloadVersionWithRef(statefulSet.ObjectMeta, "StatefulSet")
 
 	fakeClient := testcommon.NewTestClient(statefulSet, workloadVersion)
 
 	r := &KeptnWorkloadVersionReconciler{
 		Client: fakeClient,
+		Config: config.Instance(),
 	}
 
 	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
 	require.Nil(t, err)
 	require.Equal(t, apicommon.StateSucceeded, keptnState)
 	require.False(t, workloadVersion.Status.DeploymentStartTime.IsZero())
+	require.False(t, workloadVersion.Status.DeploymentEndTime.IsZero())
+	require.Equal(t, "mystat-2", workloadVersion.Status.DeployedRevision)
 }
 
 func TestKeptnWorkloadVersionReconciler_reconcileDeployment_ReadyDaemonSet(t *testing.T) {
@@ -230,16 +320,33 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_ReadyDaemonSet(t *te
 	daemonSet := makeDaemonSet("mystat", "default", 1, 1)
 	workloadVersion := makeWorkloadVersionWithRef(daemonSet.ObjectMeta, "DaemonSet")
 
-	fakeClient := testcommon.NewTestClient(daemonSet, workloadVersion)
+	isController := true
+	makeRevision := func(name string, revision int64) *appsv1.ControllerRevision {
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "apps/v1", Kind: "DaemonSet", Name: daemonSet.Name, UID: daemonSet.UID, Controller: &isController},
+				},
+			},
+			Revision: revision,
+		}
+	}
+
+	fakeClient := testcommon.NewTestClient(daemonSet, workloadVersion, makeRevision("mystat-1", 1), makeRevision("mystat-2", 2))
 
 	r := &KeptnWorkloadVersionReconciler{
 		Client: fakeClient,
+		Config: config.Instance(),
 	}
 
 	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
 	require.Nil(t, err)
 	require.Equal(t, apicommon.StateSucceeded, keptnState)
 	require.False(t, workloadVersion.Status.DeploymentStartTime.IsZero())
+	require.False(t, workloadVersion.Status.DeploymentEndTime.IsZero())
+	require.Equal(t, "mystat-2", workloadVersion.Status.DeployedRevision)
 }
 
 func TestKeptnWorkloadVersionReconciler_reconcileDeployment_UnsupportedReferenceKind(t *testing.T) {
@@ -248,6 +355,7 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_UnsupportedReference
 	fakeClient := testcommon.NewTestClient(workloadVersion)
 	r := &KeptnWorkloadVersionReconciler{
 		Client: fakeClient,
+		Config: config.Instance(),
 	}
 
 	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
@@ -256,6 +364,275 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_UnsupportedReference
 	require.True(t, workloadVersion.Status.DeploymentStartTime.IsZero())
 }
 
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_Deployment(t *testing.T) {
+
+	rep := int32(2)
+	tests := []struct {
+		name      string
+		updated   int32
+		available int32
+		want      apicommon.KeptnState
+	}{
+		{
+			name:      "ready",
+			updated:   2,
+			available: 2,
+			want:      apicommon.StateSucceeded,
+		},
+		{
+			name:      "not all replicas updated",
+			updated:   1,
+			available: 2,
+			want:      apicommon.StateProgressing,
+		},
+		{
+			name:      "not all replicas available",
+			updated:   2,
+			available: 1,
+			want:      apicommon.StateProgressing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := makeDeployment("mydep", "default", &rep, tt.updated, tt.available)
+			workloadVersion := makeWorkloadVersionWithRef(deployment.ObjectMeta, "Deployment")
+
+			fakeClient := testcommon.NewTestClient(deployment, workloadVersion)
+
+			r := &KeptnWorkloadVersionReconciler{
+				Client: fakeClient,
+				Config: config.Instance(),
+			}
+
+			keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, keptnState)
+			require.False(t, workloadVersion.Status.DeploymentStartTime.IsZero())
+		})
+	}
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_Job(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		conditions []batchv1.JobCondition
+		want       apicommon.KeptnState
+		wantEvent  bool
+	}{
+		{
+			name: "running",
+			want: apicommon.StateProgressing,
+		},
+		{
+			name: "completed",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			},
+			want: apicommon.StateSucceeded,
+		},
+		{
+			name: "failed",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+			},
+			want:      apicommon.StateFailed,
+			wantEvent: true,
+		},
+		{
+			name: "condition not true",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionFalse},
+			},
+			want: apicommon.StateProgressing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := makeJob("myjob", "default", tt.conditions)
+			workloadVersion := makeWorkloadVersionWithRef(job.ObjectMeta, "Job")
+
+			fakeClient := testcommon.NewTestClient(job, workloadVersion)
+			fakeRecorder := record.NewFakeRecorder(100)
+
+			r := &KeptnWorkloadVersionReconciler{
+				Client:      fakeClient,
+				Config:      config.Instance(),
+				EventSender: eventsender.NewK8sSender(fakeRecorder),
+			}
+
+			keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, keptnState)
+
+			if tt.wantEvent {
+				event := <-fakeRecorder.Events
+				require.Contains(t, event, "WorkloadDeployFailed")
+			} else {
+				require.Empty(t, fakeRecorder.Events)
+			}
+		})
+	}
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_ReadinessCheck(t *testing.T) {
+
+	rep := int32(1)
+	replicaSet := makeReplicaSet("myrep", "default", &rep, 1)
+	replicaSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pod",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "my-app"},
+		},
+		Status: corev1.PodStatus{
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "app", RestartCount: 5}},
+		},
+	}
+	workloadVersion := makeWorkloadVersionWithRef(replicaSet.ObjectMeta, "ReplicaSet")
+
+	fakeClient := testcommon.NewTestClient(replicaSet, pod, workloadVersion)
+	fakeRecorder := record.NewFakeRecorder(100)
+
+	r := &KeptnWorkloadVersionReconciler{
+		Client:      fakeClient,
+		Config:      config.Instance(),
+		EventSender: eventsender.NewK8sSender(fakeRecorder),
+	}
+	r.Config.SetObservabilityTimeout(metav1.Duration{Duration: 5 * time.Minute})
+
+	// with the default strategy, the available replica is sufficient
+	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateSucceeded, keptnState)
+
+	// the podReadiness strategy detects the crash-looping pod
+	workloadVersion.Status.DeploymentStatus = apicommon.StatePending
+	maxRestarts := int32(3)
+	workloadVersion.Spec.ReadinessCheck = &apilifecycle.ReadinessCheck{
+		Strategy:    apilifecycle.ReadinessStrategyPodReadiness,
+		MaxRestarts: &maxRestarts,
+	}
+	keptnState, err = r.reconcileDeployment(context.TODO(), workloadVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateFailed, keptnState)
+
+	event := <-fakeRecorder.Events
+	require.Contains(t, event, "WorkloadDeployFailed")
+
+	// unknown strategies are reported as error
+	workloadVersion.Spec.ReadinessCheck = &apilifecycle.ReadinessCheck{Strategy: "unknown"}
+	keptnState, err = r.reconcileDeployment(context.TODO(), workloadVersion)
+	require.ErrorIs(t, err, controllererrors.ErrUnsupportedReadinessStrategy)
+	require.Equal(t, apicommon.StateUnknown, keptnState)
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_Rollout(t *testing.T) {
+
+	rep := int32(1)
+	tests := []struct {
+		name     string
+		phase    argov1alpha1.RolloutPhase
+		steps    []argov1alpha1.CanaryStep
+		podHash  string
+		replicas int32
+		updated  int32
+		want     apicommon.KeptnState
+	}{
+		{
+			name:     "healthy",
+			phase:    argov1alpha1.RolloutPhaseHealthy,
+			podHash:  "abc",
+			replicas: 1,
+			updated:  1,
+			want:     apicommon.StateSucceeded,
+		},
+		{
+			name:     "progressing",
+			phase:    argov1alpha1.RolloutPhaseProgressing,
+			steps:    []argov1alpha1.CanaryStep{{Pause: &argov1alpha1.RolloutPause{}}},
+			podHash:  "abc",
+			replicas: 2,
+			updated:  1,
+			want:     apicommon.StateProgressing,
+		},
+		{
+			name:     "paused at canary step",
+			phase:    argov1alpha1.RolloutPhasePaused,
+			steps:    []argov1alpha1.CanaryStep{{Pause: &argov1alpha1.RolloutPause{}}},
+			podHash:  "abc",
+			replicas: 2,
+			updated:  1,
+			want:     apicommon.StateSucceeded,
+		},
+		{
+			name:     "paused at canary step of another ReplicaSet",
+			phase:    argov1alpha1.RolloutPhasePaused,
+			steps:    []argov1alpha1.CanaryStep{{Pause: &argov1alpha1.RolloutPause{}}},
+			podHash:  "def",
+			replicas: 2,
+			updated:  1,
+			want:     apicommon.StateProgressing,
+		},
+		{
+			name:     "paused without canary steps",
+			phase:    argov1alpha1.RolloutPhasePaused,
+			podHash:  "abc",
+			replicas: 2,
+			updated:  1,
+			want:     apicommon.StateProgressing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rollout := &argov1alpha1.Rollout{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "myrollout",
+					Namespace: "default",
+					UID:       "myrollout",
+				},
+				Spec: argov1alpha1.RolloutSpec{
+					Strategy: argov1alpha1.RolloutStrategy{
+						Canary: &argov1alpha1.CanaryStrategy{
+							Steps: tt.steps,
+						},
+					},
+				},
+				Status: argov1alpha1.RolloutStatus{
+					Phase:           tt.phase,
+					CurrentPodHash:  tt.podHash,
+					Replicas:        tt.replicas,
+					UpdatedReplicas: tt.updated,
+				},
+			}
+			replicaSet := makeReplicaSet("myrep", "default", &rep, 1)
+			replicaSet.Labels = map[string]string{
+				argov1alpha1.DefaultRolloutUniqueLabelKey: "abc",
+			}
+			replicaSet.OwnerReferences = []metav1.OwnerReference{
+				{Kind: "Rollout", Name: rollout.Name, UID: rollout.UID},
+			}
+			workloadVersion := makeWorkloadVersionWithRef(replicaSet.ObjectMeta, "ReplicaSet")
+
+			fakeClient := testcommon.NewTestClient(rollout, replicaSet, workloadVersion)
+
+			r := &KeptnWorkloadVersionReconciler{
+				Client: fakeClient,
+				Config: config.Instance(),
+			}
+
+			keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, keptnState)
+		})
+	}
+}
+
 func makeReplicaSet(name string, namespace string, wanted *int32, available int32) *appsv1.ReplicaSet {
 
 	return &appsv1.ReplicaSet{
@@ -318,6 +695,40 @@ func makeDaemonSet(name string, namespace string, wanted int32, available int32)
 
 }
 
+func makeDeployment(name string, namespace string, wanted *int32, updated int32, available int32) *appsv1.Deployment {
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       types.UID(name),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: wanted,
+		},
+		Status: appsv1.DeploymentStatus{
+			UpdatedReplicas:   updated,
+			AvailableReplicas: available,
+		},
+	}
+
+}
+
+func makeJob(name string, namespace string, conditions []batchv1.JobCondition) *batchv1.Job {
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       types.UID(name),
+		},
+		Status: batchv1.JobStatus{
+			Conditions: conditions,
+		},
+	}
+
+}
+
 func Test_getAppVersionForWorkloadVersion(t *testing.T) {
 	now := time.Now()
 	tests := []struct {
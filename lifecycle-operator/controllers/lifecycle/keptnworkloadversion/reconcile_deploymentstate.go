@@ -4,16 +4,19 @@ import (
 	"context"
 	"time"
 
-	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/readiness"
 	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (r *KeptnWorkloadVersionReconciler) reconcileDeployment(ctx context.Context, workloadVersion *apilifecycle.KeptnWorkloadVersion) (apicommon.KeptnState, error) {
-	var isRunning bool
 	var err error
 
 	if r.isDeploymentTimedOut(workloadVersion) {
@@ -26,28 +29,38 @@ func (r *KeptnWorkloadVersionReconciler) reconcileDeployment(ctx context.Context
 		return workloadVersion.Status.DeploymentStatus, nil
 	}
 
-	switch workloadVersion.Spec.ResourceReference.Kind {
-	case "ReplicaSet":
-		isRunning, err = r.isReplicaSetRunning(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
-	case "StatefulSet":
-		isRunning, err = r.isStatefulSetRunning(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
-	case "DaemonSet":
-		isRunning, err = r.isDaemonSetRunning(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
-	default:
-		isRunning, err = false, controllererrors.ErrUnsupportedWorkloadVersionResourceReference
+	var state apicommon.KeptnState
+	if workloadVersion.Spec.ResourceReference.Kind == "Job" {
+		state, err = r.getJobState(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
+	} else {
+		state, err = r.checkReadiness(ctx, workloadVersion)
 	}
-
 	if err != nil {
 		return apicommon.StateUnknown, err
 	}
 
+	if state.IsFailed() {
+		workloadVersion.Status.DeploymentStatus = apicommon.StateFailed
+		err = r.Client.Status().Update(ctx, workloadVersion)
+		if err != nil {
+			return apicommon.StateUnknown, err
+		}
+		r.EventSender.Emit(apicommon.PhaseWorkloadDeployment, "Warning", workloadVersion, apicommon.PhaseStateFailed, "has failed", workloadVersion.GetVersion())
+		return workloadVersion.Status.DeploymentStatus, nil
+	}
+
 	if !workloadVersion.IsDeploymentStartTimeSet() {
 		workloadVersion.SetDeploymentStartTime()
+		workloadVersion.Status.DeploymentDeadline = metav1.NewTime(workloadVersion.Status.DeploymentStartTime.Add(r.getObservabilityTimeout(ctx, workloadVersion)))
 		workloadVersion.Status.DeploymentStatus = apicommon.StateProgressing
 	}
 
-	if isRunning {
+	if state.IsSucceeded() {
 		workloadVersion.Status.DeploymentStatus = apicommon.StateSucceeded
+		workloadVersion.SetDeploymentEndTime()
+		if workloadVersion.Status.DeployedRevision == "" {
+			workloadVersion.Status.DeployedRevision = r.getDeployedRevision(ctx, workloadVersion)
+		}
 	}
 
 	err = r.Client.Status().Update(ctx, workloadVersion)
@@ -62,50 +75,114 @@ func (r *KeptnWorkloadVersionReconciler) isDeploymentTimedOut(workloadVersion *a
 		return false
 	}
 
-	deploymentDeadline := workloadVersion.Status.DeploymentStartTime.Add(r.Config.GetObservabilityTimeout().Duration)
+	deploymentDeadline := workloadVersion.Status.DeploymentDeadline.Time
+	if deploymentDeadline.IsZero() {
+		deploymentDeadline = workloadVersion.Status.DeploymentStartTime.Add(r.Config.GetObservabilityTimeout().Duration)
+	}
 	currentTime := time.Now().UTC()
 	return currentTime.After(deploymentDeadline)
 }
 
-func (r *KeptnWorkloadVersionReconciler) isReplicaSetRunning(ctx context.Context, resource apilifecycle.ResourceReference, namespace string) (bool, error) {
-	rep := appsv1.ReplicaSet{}
-	err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, &rep)
+// getObservabilityTimeout returns the ObservabilityTimeout of the KeptnWorkloadVersion if set,
+// otherwise the one of the KeptnAppContext of the related KeptnAppVersion, and finally the one of the KeptnConfig
+func (r *KeptnWorkloadVersionReconciler) getObservabilityTimeout(ctx context.Context, workloadVersion *apilifecycle.KeptnWorkloadVersion) time.Duration {
+	if workloadVersion.Spec.ObservabilityTimeout != nil {
+		return workloadVersion.Spec.ObservabilityTimeout.Duration
+	}
+	found, appVersion, err := r.getAppVersionForWorkloadVersion(ctx, workloadVersion)
 	if err != nil {
-		return false, err
+		r.Log.Error(err, "could not retrieve KeptnAppVersion to determine the observability timeout")
+	} else if found && appVersion.Spec.ObservabilityTimeout != nil {
+		return appVersion.Spec.ObservabilityTimeout.Duration
 	}
+	return r.Config.GetObservabilityTimeout().Duration
+}
 
-	for _, ownerRef := range rep.OwnerReferences {
-		if ownerRef.Kind == "Rollout" {
-			return r.isRolloutRunning(ctx, apilifecycle.ResourceReference{Name: ownerRef.Name, UID: ownerRef.UID}, namespace)
+// getDeployedRevision returns the name of the current ControllerRevision of the StatefulSet or DaemonSet
+// referenced by the KeptnWorkloadVersion, which is restored if a later version of the KeptnApp is rolled back
+func (r *KeptnWorkloadVersionReconciler) getDeployedRevision(ctx context.Context, workloadVersion *apilifecycle.KeptnWorkloadVersion) string {
+	ref := workloadVersion.Spec.ResourceReference
+	key := types.NamespacedName{Name: ref.Name, Namespace: workloadVersion.Namespace}
+	switch ref.Kind {
+	case "StatefulSet":
+		sts := &appsv1.StatefulSet{}
+		if err := r.Client.Get(ctx, key, sts); err != nil {
+			r.Log.Error(err, "could not retrieve the deployed revision", "workloadVersion", workloadVersion.Name)
+			return ""
+		}
+		return sts.Status.UpdateRevision
+	case "DaemonSet":
+		ds := &appsv1.DaemonSet{}
+		if err := r.Client.Get(ctx, key, ds); err != nil {
+			r.Log.Error(err, "could not retrieve the deployed revision", "workloadVersion", workloadVersion.Name)
+			return ""
+		}
+		revisions := &appsv1.ControllerRevisionList{}
+		if err := r.Client.List(ctx, revisions, client.InNamespace(ds.Namespace)); err != nil {
+			r.Log.Error(err, "could not retrieve the deployed revision", "workloadVersion", workloadVersion.Name)
+			return ""
+		}
+		// the revision with the highest number describes the current template of the DaemonSet
+		var current *appsv1.ControllerRevision
+		for i := range revisions.Items {
+			rev := &revisions.Items[i]
+			if metav1.IsControlledBy(rev, ds) && (current == nil || rev.Revision > current.Revision) {
+				current = rev
+			}
+		}
+		if current != nil {
+			return current.Name
 		}
 	}
-
-	return *rep.Spec.Replicas == rep.Status.AvailableReplicas, nil
+	return ""
 }
 
-func (r *KeptnWorkloadVersionReconciler) isDaemonSetRunning(ctx context.Context, resource apilifecycle.ResourceReference, namespace string) (bool, error) {
-	daemonSet := &appsv1.DaemonSet{}
-	err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, daemonSet)
+// checkReadiness checks whether the resource referenced by the KeptnWorkloadVersion has been deployed,
+// using the readiness strategy configured for the workload
+func (r *KeptnWorkloadVersionReconciler) checkReadiness(ctx context.Context, workloadVersion *apilifecycle.KeptnWorkloadVersion) (apicommon.KeptnState, error) {
+	var workload client.Object
+	switch workloadVersion.Spec.ResourceReference.Kind {
+	case "Deployment":
+		workload = &appsv1.Deployment{}
+	case "ReplicaSet":
+		workload = &appsv1.ReplicaSet{}
+	case "StatefulSet":
+		workload = &appsv1.StatefulSet{}
+	case "DaemonSet":
+		workload = &appsv1.DaemonSet{}
+	default:
+		return apicommon.StateUnknown, controllererrors.ErrUnsupportedWorkloadVersionResourceReference
+	}
+
+	checker, err := readiness.NewChecker(r.Client, workloadVersion.Spec.ReadinessCheck)
 	if err != nil {
-		return false, err
+		return apicommon.StateUnknown, err
 	}
-	return daemonSet.Status.DesiredNumberScheduled == daemonSet.Status.NumberReady, nil
-}
 
-func (r *KeptnWorkloadVersionReconciler) isStatefulSetRunning(ctx context.Context, resource apilifecycle.ResourceReference, namespace string) (bool, error) {
-	sts := appsv1.StatefulSet{}
-	err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, &sts)
+	err = r.Client.Get(ctx, types.NamespacedName{Name: workloadVersion.Spec.ResourceReference.Name, Namespace: workloadVersion.Namespace}, workload)
 	if err != nil {
-		return false, err
+		return apicommon.StateUnknown, err
 	}
-	return *sts.Spec.Replicas == sts.Status.AvailableReplicas, nil
+	return checker.Check(ctx, workload)
 }
 
-func (r *KeptnWorkloadVersionReconciler) isRolloutRunning(ctx context.Context, resource apilifecycle.ResourceReference, namespace string) (bool, error) {
-	rollout := argov1alpha1.Rollout{}
-	err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, &rollout)
+// getJobState returns StateSucceeded if the Job has completed successfully and StateFailed if it has failed
+func (r *KeptnWorkloadVersionReconciler) getJobState(ctx context.Context, resource apilifecycle.ResourceReference, namespace string) (apicommon.KeptnState, error) {
+	job := batchv1.Job{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, &job)
 	if err != nil {
-		return false, err
+		return apicommon.StateUnknown, err
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return apicommon.StateSucceeded, nil
+		case batchv1.JobFailed:
+			return apicommon.StateFailed, nil
+		}
 	}
-	return rollout.Status.Replicas == rollout.Status.UpdatedReplicas && rollout.Status.Phase == argov1alpha1.RolloutPhaseHealthy, nil
+	return apicommon.StateProgressing, nil
 }
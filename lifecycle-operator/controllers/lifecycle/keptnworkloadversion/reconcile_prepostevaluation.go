@@ -7,6 +7,7 @@ import (
 
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/analysis"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/evaluation"
 )
 
@@ -30,15 +31,29 @@ func (r *KeptnWorkloadVersionReconciler) reconcilePrePostEvaluation(ctx context.
 		return apicommon.StateUnknown, err
 	}
 
-	overallState := apicommon.GetOverallStateBlockedDeployment(state, r.Config.GetBlockDeployment())
+	analysisHandler := analysis.NewHandler(
+		r.Client,
+		r.EventSender,
+		r.Log,
+		r.Client.Scheme(),
+	)
+
+	newAnalysisStatus, analysisState, err := analysisHandler.ReconcileAnalyses(ctx, workloadVersion, checkType)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+
+	overallState := apicommon.GetOverallEvaluationState(apicommon.MergeStatusSummaries(state, analysisState), r.Config.GetBlockDeployment())
 
 	switch checkType {
 	case apicommon.PreDeploymentEvaluationCheckType:
 		workloadVersion.Status.PreDeploymentEvaluationStatus = overallState
 		workloadVersion.Status.PreDeploymentEvaluationTaskStatus = newStatus
+		workloadVersion.Status.PreDeploymentAnalysisStatus = newAnalysisStatus
 	case apicommon.PostDeploymentEvaluationCheckType:
 		workloadVersion.Status.PostDeploymentEvaluationStatus = overallState
 		workloadVersion.Status.PostDeploymentEvaluationTaskStatus = newStatus
+		workloadVersion.Status.PostDeploymentAnalysisStatus = newAnalysisStatus
 	}
 
 	// Write Status Field
@@ -28,6 +28,7 @@ import (
 	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -76,9 +77,15 @@ func (r *KeptnConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	// reconcile config values
 	r.config.SetCreationRequestTimeout(time.Duration(cfg.Spec.KeptnAppCreationRequestTimeoutSeconds) * time.Second)
 	r.config.SetCloudEventsEndpoint(cfg.Spec.CloudEventsEndpoint)
+	r.config.SetCloudEventsDelivery(getCloudEventsDelivery(cfg))
+	r.config.SetCloudEventsEndpoints(getCloudEventsEndpoints(cfg))
 	r.config.SetBlockDeployment(cfg.Spec.BlockDeployment)
 	r.config.SetObservabilityTimeout(cfg.Spec.ObservabilityTimeout)
 	r.config.SetRestApiEnabled(cfg.Spec.RestApiEnabled)
+	r.config.SetTaskDefinitionLibraries(getTaskDefinitionLibraries(cfg.Spec.TaskDefinitionLibraries))
+	r.config.SetMaxParallelTasks(cfg.Spec.MaxParallelTasks)
+	r.config.SetMaxParallelTasksPerNamespace(cfg.Spec.MaxParallelTasksPerNamespace)
+	r.config.SetFunctionCodeSources(getFunctionCodeSources(cfg.Spec.FunctionCodeSources))
 	result, err := r.reconcileOtelCollectorUrl(cfg)
 	if err != nil {
 		return result, err
@@ -99,6 +106,91 @@ func (r *KeptnConfigReconciler) reconcileOtelCollectorUrl(config *optionsv1alpha
 	return ctrl.Result{}, nil
 }
 
+// getTaskDefinitionLibraries maps the namespaces of the given task definition libraries
+// to the namespaces that are allowed to use them
+func getTaskDefinitionLibraries(libraries []optionsv1alpha1.TaskDefinitionLibrary) map[string][]string {
+	result := make(map[string][]string, len(libraries))
+	for _, library := range libraries {
+		result[library.Namespace] = append(result[library.Namespace], library.AllowedNamespaces...)
+	}
+	return result
+}
+
+// getCloudEventsDelivery returns the delivery options of the given KeptnConfig,
+// falling back to the defaults for the options that are not set
+func getCloudEventsDelivery(cfg *optionsv1alpha1.KeptnConfig) config.CloudEventsDelivery {
+	delivery := cfg.Spec.CloudEventsDelivery
+	result := config.CloudEventsDelivery{
+		QueueSize:        delivery.QueueSize,
+		MaxRetries:       config.DefaultCloudEventsMaxRetries,
+		RetryBackoff:     delivery.RetryBackoff.Duration,
+		BatchSize:        delivery.BatchSize,
+		DeadLetterSize:   delivery.DeadLetterSize,
+		DeadLetterMaxAge: delivery.DeadLetterMaxAge.Duration,
+	}
+	if result.QueueSize <= 0 {
+		result.QueueSize = config.DefaultCloudEventsQueueSize
+	}
+	if delivery.MaxRetries != nil {
+		result.MaxRetries = *delivery.MaxRetries
+	}
+	if result.RetryBackoff <= 0 {
+		result.RetryBackoff = config.DefaultCloudEventsRetryBackoff
+	}
+	if result.BatchSize <= 0 {
+		result.BatchSize = config.DefaultCloudEventsBatchSize
+	}
+	if result.DeadLetterSize <= 0 {
+		result.DeadLetterSize = config.DefaultCloudEventsDeadLetterSize
+	}
+	if result.DeadLetterMaxAge <= 0 {
+		result.DeadLetterMaxAge = config.DefaultCloudEventsDeadLetterMaxAge
+	}
+	if delivery.HeadersSecretName != "" {
+		result.HeadersSecret = types.NamespacedName{Namespace: cfg.Namespace, Name: delivery.HeadersSecretName}
+	}
+	return result
+}
+
+// getCloudEventsEndpoints returns the filtered endpoints receiving Cloud Events of the given KeptnConfig
+func getCloudEventsEndpoints(cfg *optionsv1alpha1.KeptnConfig) []config.CloudEventsEndpoint {
+	result := make([]config.CloudEventsEndpoint, 0, len(cfg.Spec.CloudEventsEndpoints))
+	for _, endpoint := range cfg.Spec.CloudEventsEndpoints {
+		if endpoint.URL == "" {
+			continue
+		}
+		ceEndpoint := config.CloudEventsEndpoint{
+			URL: endpoint.URL,
+			Filter: config.CloudEventsFilter{
+				Namespaces: endpoint.Filter.Namespaces,
+				Phases:     endpoint.Filter.Phases,
+				Statuses:   endpoint.Filter.Statuses,
+				Types:      endpoint.Filter.Types,
+			},
+			Format: endpoint.Format,
+		}
+		if endpoint.HeadersSecretName != "" {
+			ceEndpoint.HeadersSecret = types.NamespacedName{Namespace: cfg.Namespace, Name: endpoint.HeadersSecretName}
+		}
+		result = append(result, ceEndpoint)
+	}
+	return result
+}
+
+// getFunctionCodeSources returns the allowed sources of function code,
+// falling back to the default scheme if no schemes are set
+func getFunctionCodeSources(sources optionsv1alpha1.FunctionCodeSources) config.FunctionCodeSources {
+	result := config.FunctionCodeSources{
+		Schemes:               sources.Schemes,
+		Hosts:                 sources.Hosts,
+		AllowPrivateAddresses: sources.AllowPrivateAddresses,
+	}
+	if len(result.Schemes) == 0 {
+		result.Schemes = []string{config.DefaultFunctionCodeScheme}
+	}
+	return result
+}
+
 func (r *KeptnConfigReconciler) initConfig() {
 	r.LastAppliedSpec = &optionsv1alpha1.KeptnConfigSpec{}
 }
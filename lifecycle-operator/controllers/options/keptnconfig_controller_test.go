@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-logr/logr"
 	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	fakeconfig "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config/fake"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
 	"github.com/stretchr/testify/require"
@@ -370,6 +371,186 @@ func TestKeptnConfigReconciler_reconcileOtelCollectorUrl(t *testing.T) {
 	}
 }
 
+func TestKeptnConfigReconciler_ReconcileTaskDefinitionLibraries(t *testing.T) {
+	cfg := &optionsv1alpha1.KeptnConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "library-config",
+			Namespace: "keptn-system",
+		},
+		Spec: optionsv1alpha1.KeptnConfigSpec{
+			TaskDefinitionLibraries: []optionsv1alpha1.TaskDefinitionLibrary{
+				{Namespace: "platform-tasks", AllowedNamespaces: []string{"app-a", "app-b"}},
+				{Namespace: "shared-tasks", AllowedNamespaces: []string{"*"}},
+			},
+		},
+	}
+	r := setupReconciler(cfg)
+
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: "keptn-system", Name: "library-config"},
+	})
+	require.Nil(t, err)
+
+	mockConfig := r.config.(*fakeconfig.MockConfig)
+	require.Len(t, mockConfig.SetTaskDefinitionLibrariesCalls(), 1)
+	require.Equal(t, map[string][]string{
+		"platform-tasks": {"app-a", "app-b"},
+		"shared-tasks":   {"*"},
+	}, mockConfig.SetTaskDefinitionLibrariesCalls()[0].Libraries)
+}
+
+func TestKeptnConfigReconciler_ReconcileMaxParallelTasks(t *testing.T) {
+	cfg := &optionsv1alpha1.KeptnConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "quota-config",
+			Namespace: "keptn-system",
+		},
+		Spec: optionsv1alpha1.KeptnConfigSpec{
+			MaxParallelTasks:             20,
+			MaxParallelTasksPerNamespace: 5,
+		},
+	}
+	r := setupReconciler(cfg)
+
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: "keptn-system", Name: "quota-config"},
+	})
+	require.Nil(t, err)
+
+	mockConfig := r.config.(*fakeconfig.MockConfig)
+	require.Len(t, mockConfig.SetMaxParallelTasksCalls(), 1)
+	require.Equal(t, 20, mockConfig.SetMaxParallelTasksCalls()[0].Value)
+	require.Len(t, mockConfig.SetMaxParallelTasksPerNamespaceCalls(), 1)
+	require.Equal(t, 5, mockConfig.SetMaxParallelTasksPerNamespaceCalls()[0].Value)
+}
+
+func TestKeptnConfigReconciler_ReconcileCloudEventsDelivery(t *testing.T) {
+	cfg := &optionsv1alpha1.KeptnConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "delivery-config",
+			Namespace: "keptn-system",
+		},
+		Spec: optionsv1alpha1.KeptnConfigSpec{
+			CloudEventsDelivery: optionsv1alpha1.CloudEventsDelivery{
+				QueueSize:         50,
+				RetryBackoff:      metav1.Duration{Duration: 2 * time.Second},
+				BatchSize:         10,
+				HeadersSecretName: "cloudevents-headers",
+			},
+		},
+	}
+	r := setupReconciler(cfg)
+
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: "keptn-system", Name: "delivery-config"},
+	})
+	require.Nil(t, err)
+
+	mockConfig := r.config.(*fakeconfig.MockConfig)
+	require.Len(t, mockConfig.SetCloudEventsDeliveryCalls(), 1)
+	require.Equal(t, config.CloudEventsDelivery{
+		QueueSize:        50,
+		MaxRetries:       config.DefaultCloudEventsMaxRetries,
+		RetryBackoff:     2 * time.Second,
+		BatchSize:        10,
+		DeadLetterSize:   config.DefaultCloudEventsDeadLetterSize,
+		DeadLetterMaxAge: config.DefaultCloudEventsDeadLetterMaxAge,
+		HeadersSecret:    types.NamespacedName{Namespace: "keptn-system", Name: "cloudevents-headers"},
+	}, mockConfig.SetCloudEventsDeliveryCalls()[0].Delivery)
+}
+
+func Test_getCloudEventsDelivery_NoRetries(t *testing.T) {
+	maxRetries := 0
+	cfg := &optionsv1alpha1.KeptnConfig{
+		Spec: optionsv1alpha1.KeptnConfigSpec{
+			CloudEventsDelivery: optionsv1alpha1.CloudEventsDelivery{
+				MaxRetries: &maxRetries,
+			},
+		},
+	}
+
+	// an explicit zero disables retries instead of falling back to the default
+	require.Equal(t, 0, getCloudEventsDelivery(cfg).MaxRetries)
+}
+
+func TestKeptnConfigReconciler_ReconcileCloudEventsEndpoints(t *testing.T) {
+	cfg := &optionsv1alpha1.KeptnConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "endpoints-config",
+			Namespace: "keptn-system",
+		},
+		Spec: optionsv1alpha1.KeptnConfigSpec{
+			CloudEventsEndpoints: []optionsv1alpha1.CloudEventsEndpoint{
+				{
+					URL:               "http://paging",
+					HeadersSecretName: "paging-headers",
+					Filter: optionsv1alpha1.CloudEventsFilter{
+						Namespaces: []string{"production"},
+						Statuses:   []string{"Failed"},
+					},
+				},
+				{
+					URL: "http://audit",
+				},
+				{
+					URL: "",
+				},
+			},
+		},
+	}
+	r := setupReconciler(cfg)
+
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: "keptn-system", Name: "endpoints-config"},
+	})
+	require.Nil(t, err)
+
+	mockConfig := r.config.(*fakeconfig.MockConfig)
+	require.Len(t, mockConfig.SetCloudEventsEndpointsCalls(), 1)
+	require.Equal(t, []config.CloudEventsEndpoint{
+		{
+			URL:           "http://paging",
+			HeadersSecret: types.NamespacedName{Namespace: "keptn-system", Name: "paging-headers"},
+			Filter: config.CloudEventsFilter{
+				Namespaces: []string{"production"},
+				Statuses:   []string{"Failed"},
+			},
+		},
+		{
+			URL: "http://audit",
+		},
+	}, mockConfig.SetCloudEventsEndpointsCalls()[0].Endpoints)
+}
+
+func TestKeptnConfigReconciler_ReconcileFunctionCodeSources(t *testing.T) {
+	cfg := &optionsv1alpha1.KeptnConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sources-config",
+			Namespace: "keptn-system",
+		},
+		Spec: optionsv1alpha1.KeptnConfigSpec{
+			FunctionCodeSources: optionsv1alpha1.FunctionCodeSources{
+				Hosts:                 []string{"*.example.com"},
+				AllowPrivateAddresses: true,
+			},
+		},
+	}
+	r := setupReconciler(cfg)
+
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: "keptn-system", Name: "sources-config"},
+	})
+	require.Nil(t, err)
+
+	mockConfig := r.config.(*fakeconfig.MockConfig)
+	require.Len(t, mockConfig.SetFunctionCodeSourcesCalls(), 1)
+	require.Equal(t, config.FunctionCodeSources{
+		Schemes:               []string{config.DefaultFunctionCodeScheme},
+		Hosts:                 []string{"*.example.com"},
+		AllowPrivateAddresses: true,
+	}, mockConfig.SetFunctionCodeSourcesCalls()[0].Sources)
+}
+
 func setupReconciler(withConfig *optionsv1alpha1.KeptnConfig) *KeptnConfigReconciler {
 	// setup logger
 	opts := zap.Options{
@@ -385,11 +566,17 @@ func setupReconciler(withConfig *optionsv1alpha1.KeptnConfig) *KeptnConfigReconc
 		ctrl.Log.WithName("test-keptnconfig-controller"),
 	)
 	r.config = &fakeconfig.MockConfig{
-		SetCloudEventsEndpointFunc:    func(endpoint string) {},
-		SetCreationRequestTimeoutFunc: func(value time.Duration) {},
-		SetBlockDeploymentFunc:        func(value bool) {},
-		SetObservabilityTimeoutFunc:   func(timeout metav1.Duration) {},
-		SetRestApiEnabledFunc:         func(value bool) {},
+		SetCloudEventsEndpointFunc:          func(endpoint string) {},
+		SetCreationRequestTimeoutFunc:       func(value time.Duration) {},
+		SetBlockDeploymentFunc:              func(value bool) {},
+		SetObservabilityTimeoutFunc:         func(timeout metav1.Duration) {},
+		SetRestApiEnabledFunc:               func(value bool) {},
+		SetTaskDefinitionLibrariesFunc:      func(libraries map[string][]string) {},
+		SetMaxParallelTasksFunc:             func(value int) {},
+		SetMaxParallelTasksPerNamespaceFunc: func(value int) {},
+		SetCloudEventsDeliveryFunc:          func(delivery config.CloudEventsDelivery) {},
+		SetCloudEventsEndpointsFunc:         func(endpoints []config.CloudEventsEndpoint) {},
+		SetFunctionCodeSourcesFunc:          func(sources config.FunctionCodeSources) {},
 	}
 	return r
 }
@@ -0,0 +1,349 @@
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/auth"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnapps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnappversions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnworkloadversions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntasks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnevaluations,verbs=get;list;watch
+
+// Resource is the representation of a Keptn resource returned by the REST API
+type Resource struct {
+	Name              string      `json:"name"`
+	Namespace         string      `json:"namespace"`
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+	Spec              interface{} `json:"spec"`
+	Status            interface{} `json:"status,omitempty"`
+}
+
+// ListResponse is the response of the endpoints listing Keptn resources
+type ListResponse struct {
+	Items []Resource `json:"items"`
+}
+
+// HistoryEntry is a single deployment of a KeptnApp, consisting of the KeptnAppVersion
+// and the KeptnWorkloadVersions deployed with it
+type HistoryEntry struct {
+	AppVersion       Resource   `json:"appVersion"`
+	WorkloadVersions []Resource `json:"workloadVersions"`
+}
+
+// HistoryResponse is the response of the deployment history endpoint, starting with the latest deployment
+type HistoryResponse struct {
+	Items []HistoryEntry `json:"items"`
+}
+
+// Server exposes a read-only HTTP API for the Keptn resources managed by the lifecycle-operator.
+// The resources are read from the cache of the manager.
+// The API only responds if it is enabled in the KeptnConfig, so that it can be toggled at runtime.
+// Callers authenticate with a bearer token and only receive the resources of the namespaces
+// in which they are allowed to list them. The API is served via HTTPS with the certificate found in certDir.
+type Server struct {
+	client  client.Client
+	log     logr.Logger
+	config  config.IConfig
+	port    int
+	certDir string
+	auth    *auth.Authenticator
+}
+
+func NewServer(client client.Client, log logr.Logger, config config.IConfig, port int, certDir string) *Server {
+	return &Server{
+		client:  client,
+		log:     log,
+		config:  config,
+		port:    port,
+		certDir: certDir,
+		auth:    auth.NewAuthenticator(client, log),
+	}
+}
+
+// Start runs the HTTPS server until the given context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", s.port),
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: shutdownTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.log.Error(err, "could not shut down REST API server")
+		}
+	}()
+
+	s.log.Info("serving REST API", "port", s.port)
+	if err := auth.ListenAndServeTLS(ctx, s.log, server, s.certDir); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection implements LeaderElectionRunnable, the API is served by all replicas
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the http.Handler serving the REST API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/apps", s.listApps)
+	mux.HandleFunc("GET /api/v1/appversions", s.listAppVersions)
+	mux.HandleFunc("GET /api/v1/workloadversions", s.listWorkloadVersions)
+	mux.HandleFunc("GET /api/v1/tasks", s.listTasks)
+	mux.HandleFunc("GET /api/v1/evaluations", s.listEvaluations)
+	mux.HandleFunc("GET /api/v1/namespaces/{namespace}/apps/{app}/history", s.getHistory)
+	return s.middleware(s.auth.Middleware(mux))
+}
+
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.config.GetRestApiEnabled() {
+			http.Error(w, "the REST API is disabled", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) listApps(w http.ResponseWriter, r *http.Request) {
+	apps := &apilifecycle.KeptnAppList{}
+	canRead, ok := s.list(w, r, apps, "keptnapps")
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	items := []Resource{}
+	for _, app := range apps.Items {
+		if canRead(app.Namespace) && matches(query.Get("app"), app.Name) {
+			items = append(items, newResource(app.ObjectMeta, app.Spec, app.Status))
+		}
+	}
+	s.respond(w, ListResponse{Items: items})
+}
+
+func (s *Server) listAppVersions(w http.ResponseWriter, r *http.Request) {
+	appVersions := &apilifecycle.KeptnAppVersionList{}
+	canRead, ok := s.list(w, r, appVersions, "keptnappversions")
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	items := []Resource{}
+	for _, appVersion := range appVersions.Items {
+		if canRead(appVersion.Namespace) && matches(query.Get("app"), appVersion.Spec.AppName) {
+			items = append(items, newResource(appVersion.ObjectMeta, appVersion.Spec, appVersion.Status))
+		}
+	}
+	s.respond(w, ListResponse{Items: items})
+}
+
+func (s *Server) listWorkloadVersions(w http.ResponseWriter, r *http.Request) {
+	workloadVersions := &apilifecycle.KeptnWorkloadVersionList{}
+	canRead, ok := s.list(w, r, workloadVersions, "keptnworkloadversions")
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	items := []Resource{}
+	for _, workloadVersion := range workloadVersions.Items {
+		if canRead(workloadVersion.Namespace) && matches(query.Get("app"), workloadVersion.Spec.AppName) && matches(query.Get("workload"), workloadVersion.Spec.WorkloadName) {
+			items = append(items, newResource(workloadVersion.ObjectMeta, workloadVersion.Spec, workloadVersion.Status))
+		}
+	}
+	s.respond(w, ListResponse{Items: items})
+}
+
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	tasks := &apilifecycle.KeptnTaskList{}
+	canRead, ok := s.list(w, r, tasks, "keptntasks")
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	items := []Resource{}
+	for _, task := range tasks.Items {
+		if canRead(task.Namespace) && matches(query.Get("app"), task.Spec.Context.AppName) && matches(query.Get("workload"), task.Spec.Context.WorkloadName) {
+			items = append(items, newResource(task.ObjectMeta, task.Spec, task.Status))
+		}
+	}
+	s.respond(w, ListResponse{Items: items})
+}
+
+func (s *Server) listEvaluations(w http.ResponseWriter, r *http.Request) {
+	evaluations := &apilifecycle.KeptnEvaluationList{}
+	canRead, ok := s.list(w, r, evaluations, "keptnevaluations")
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	items := []Resource{}
+	for _, evaluation := range evaluations.Items {
+		if canRead(evaluation.Namespace) && matches(query.Get("app"), evaluation.Spec.AppName) && matches(query.Get("workload"), evaluation.Spec.Workload) {
+			items = append(items, newResource(evaluation.ObjectMeta, evaluation.Spec, evaluation.Status))
+		}
+	}
+	s.respond(w, ListResponse{Items: items})
+}
+
+func (s *Server) getHistory(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	app := r.PathValue("app")
+	for _, resource := range []string{"keptnappversions", "keptnworkloadversions"} {
+		if !s.authorize(w, r, resource, namespace) {
+			return
+		}
+	}
+
+	appVersions := &apilifecycle.KeptnAppVersionList{}
+	if err := s.client.List(r.Context(), appVersions, client.InNamespace(namespace)); err != nil {
+		s.internalError(w, err)
+		return
+	}
+	workloadVersions := &apilifecycle.KeptnWorkloadVersionList{}
+	if err := s.client.List(r.Context(), workloadVersions, client.InNamespace(namespace)); err != nil {
+		s.internalError(w, err)
+		return
+	}
+
+	history := []HistoryEntry{}
+	for _, appVersion := range appVersions.Items {
+		if appVersion.Spec.AppName != app {
+			continue
+		}
+		entry := HistoryEntry{
+			AppVersion:       newResource(appVersion.ObjectMeta, appVersion.Spec, appVersion.Status),
+			WorkloadVersions: []Resource{},
+		}
+		for _, workload := range appVersion.Spec.Workloads {
+			for _, workloadVersion := range workloadVersions.Items {
+				if workloadVersion.Spec.AppName == app &&
+					workloadVersion.Spec.WorkloadName == appVersion.GetWorkloadNameOfApp(workload.Name) &&
+					workloadVersion.Spec.Version == workload.Version {
+					entry.WorkloadVersions = append(entry.WorkloadVersions, newResource(workloadVersion.ObjectMeta, workloadVersion.Spec, workloadVersion.Status))
+				}
+			}
+		}
+		history = append(history, entry)
+	}
+	if len(history) == 0 {
+		http.Error(w, fmt.Sprintf("no deployments of KeptnApp %s found in namespace %s", app, namespace), http.StatusNotFound)
+		return
+	}
+
+	// the latest deployment comes first
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[j].AppVersion.CreationTimestamp.Before(&history[i].AppVersion.CreationTimestamp)
+	})
+	s.respond(w, HistoryResponse{Items: history})
+}
+
+// list retrieves the resources of the namespace given by the namespace query parameter, or of all namespaces.
+// The returned function checks whether the caller may read the resources of a namespace.
+func (s *Server) list(w http.ResponseWriter, r *http.Request, list client.ObjectList, resource string) (func(namespace string) bool, bool) {
+	namespace := r.URL.Query().Get("namespace")
+	if !s.authorize(w, r, resource, namespace) {
+		if namespace != "" {
+			return nil, false
+		}
+		// the resources of all namespaces are filtered by the namespaces the caller may read
+		return s.listNamespaced(w, r, list, resource)
+	}
+
+	var opts []client.ListOption
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err := s.client.List(r.Context(), list, opts...); err != nil {
+		s.internalError(w, err)
+		return nil, false
+	}
+	return func(string) bool { return true }, true
+}
+
+func (s *Server) listNamespaced(w http.ResponseWriter, r *http.Request, list client.ObjectList, resource string) (func(namespace string) bool, bool) {
+	if err := s.client.List(r.Context(), list); err != nil {
+		s.internalError(w, err)
+		return nil, false
+	}
+
+	user := auth.UserFrom(r.Context())
+	allowed := map[string]bool{}
+	return func(namespace string) bool {
+		if result, ok := allowed[namespace]; ok {
+			return result
+		}
+		result, err := s.auth.Authorize(r.Context(), user, "list", resource, "", namespace)
+		if err != nil {
+			s.log.Error(err, "could not review access to namespace", "user", user.Username, "namespace", namespace)
+		}
+		allowed[namespace] = result
+		return result
+	}, true
+}
+
+// authorize checks whether the caller may list the resource in the given namespace, or in all namespaces if it is empty.
+// If a namespace is given and the caller has no access, the request is answered with 403.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, resource string, namespace string) bool {
+	user := auth.UserFrom(r.Context())
+	allowed, err := s.auth.Authorize(r.Context(), user, "list", resource, "", namespace)
+	if err != nil {
+		s.log.Error(err, "could not review access for REST API", "user", user.Username)
+		if namespace != "" {
+			http.Error(w, "could not review access", http.StatusInternalServerError)
+		}
+		return false
+	}
+	if !allowed && namespace != "" {
+		http.Error(w, fmt.Sprintf("%s is not allowed to list %s in namespace %s", user.Username, resource, namespace), http.StatusForbidden)
+	}
+	return allowed
+}
+
+func (s *Server) respond(w http.ResponseWriter, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.log.Error(err, "could not encode REST API response")
+	}
+}
+
+func (s *Server) internalError(w http.ResponseWriter, err error) {
+	s.log.Error(err, "could not retrieve resources for REST API")
+	http.Error(w, "could not retrieve resources", http.StatusInternalServerError)
+}
+
+func newResource(meta metav1.ObjectMeta, spec interface{}, status interface{}) Resource {
+	return Resource{
+		Name:              meta.Name,
+		Namespace:         meta.Namespace,
+		CreationTimestamp: meta.CreationTimestamp,
+		Spec:              spec,
+		Status:            status,
+	}
+}
+
+// matches checks whether the given value matches the filter, an empty filter matches all values
+func matches(filter string, value string) bool {
+	return filter == "" || filter == value
+}
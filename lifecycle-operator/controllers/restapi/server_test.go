@@ -0,0 +1,263 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config/fake"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestServer_List(t *testing.T) {
+	objs := []client.Object{
+		makeApp("my-app", "default"),
+		makeApp("other-app", "other-namespace"),
+		makeAppVersion("my-app", "1.0.0", "default", time.Now()),
+		makeWorkloadVersion("my-app", "my-workload", "1.0.0", "default"),
+		makeWorkloadVersion("my-app", "other-workload", "1.0.0", "default"),
+		&apilifecycle.KeptnTask{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-task", Namespace: "default"},
+			Spec: apilifecycle.KeptnTaskSpec{
+				Context: apilifecycle.TaskContext{AppName: "my-app", WorkloadName: "my-workload"},
+			},
+		},
+		&apilifecycle.KeptnEvaluation{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-evaluation", Namespace: "default"},
+			Spec:       apilifecycle.KeptnEvaluationSpec{AppName: "other-app"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		token     string
+		wantCode  int
+		wantNames []string
+	}{
+		{
+			name:      "apps of all namespaces",
+			path:      "/api/v1/apps",
+			wantNames: []string{"my-app", "other-app"},
+		},
+		{
+			name:      "apps of a namespace",
+			path:      "/api/v1/apps?namespace=other-namespace",
+			wantNames: []string{"other-app"},
+		},
+		{
+			name:      "app versions of an app",
+			path:      "/api/v1/appversions?app=my-app",
+			wantNames: []string{"my-app-1.0.0"},
+		},
+		{
+			name:      "workload versions of a workload",
+			path:      "/api/v1/workloadversions?app=my-app&workload=my-app-my-workload",
+			wantNames: []string{"my-app-my-workload-1.0.0"},
+		},
+		{
+			name:      "tasks of an app",
+			path:      "/api/v1/tasks?app=my-app",
+			wantNames: []string{"my-task"},
+		},
+		{
+			name:      "no evaluations of an app",
+			path:      "/api/v1/evaluations?app=my-app",
+			wantNames: []string{},
+		},
+		{
+			name:      "apps of the namespaces the user may read",
+			path:      "/api/v1/apps",
+			token:     "bob-token",
+			wantNames: []string{"my-app"},
+		},
+		{
+			name:     "apps of a namespace the user may not read",
+			path:     "/api/v1/apps?namespace=other-namespace",
+			token:    "bob-token",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:      "tasks of the namespaces the user may read",
+			path:      "/api/v1/tasks",
+			token:     "bob-token",
+			wantNames: []string{"my-task"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer(newTestClient(objs...), ctrl.Log.WithName("test"), enabledConfig(true), 8083, "")
+
+			token := tt.token
+			if token == "" {
+				token = "alice-token"
+			}
+			rec := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rec, newRequest(tt.path, token))
+			if tt.wantCode != 0 {
+				require.Equal(t, tt.wantCode, rec.Code)
+				return
+			}
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			response := ListResponse{}
+			require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			names := []string{}
+			for _, item := range response.Items {
+				names = append(names, item.Name)
+			}
+			require.ElementsMatch(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestServer_History(t *testing.T) {
+	now := time.Now()
+	fakeClient := newTestClient(
+		makeAppVersion("my-app", "1.0.0", "default", now.Add(-time.Hour)),
+		makeAppVersion("my-app", "2.0.0", "default", now),
+		makeWorkloadVersion("my-app", "my-workload", "1.0.0", "default"),
+		makeWorkloadVersion("my-app", "my-workload", "2.0.0", "default"),
+	)
+	server := NewServer(fakeClient, ctrl.Log.WithName("test"), enabledConfig(true), 8083, "")
+
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, newRequest("/api/v1/namespaces/default/apps/my-app/history", "alice-token"))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	response := HistoryResponse{}
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Items, 2)
+	require.Equal(t, "my-app-2.0.0", response.Items[0].AppVersion.Name)
+	require.Len(t, response.Items[0].WorkloadVersions, 1)
+	require.Equal(t, "my-app-my-workload-2.0.0", response.Items[0].WorkloadVersions[0].Name)
+	require.Equal(t, "my-app-1.0.0", response.Items[1].AppVersion.Name)
+	require.Len(t, response.Items[1].WorkloadVersions, 1)
+	require.Equal(t, "my-app-my-workload-1.0.0", response.Items[1].WorkloadVersions[0].Name)
+
+	rec = httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, newRequest("/api/v1/namespaces/default/apps/other-app/history", "alice-token"))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, newRequest("/api/v1/namespaces/other-namespace/apps/my-app/history", "bob-token"))
+	require.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestServer_Middleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  bool
+		token    string
+		wantCode int
+	}{
+		{
+			name:     "disabled",
+			enabled:  false,
+			token:    "alice-token",
+			wantCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "missing token",
+			enabled:  true,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "invalid token",
+			enabled:  true,
+			token:    "wrong",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "valid token",
+			enabled:  true,
+			token:    "alice-token",
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer(newTestClient(), ctrl.Log.WithName("test"), enabledConfig(tt.enabled), 8083, "")
+
+			rec := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rec, newRequest("/api/v1/apps", tt.token))
+			require.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
+
+// newTestClient authenticates alice, who may list all resources, and bob, who may only list the resources of the default namespace
+func newTestClient(objs ...client.Object) client.Client {
+	tokens := map[string]string{"alice-token": "alice", "bob-token": "bob"}
+	return testcommon.NewTestClientWithReviews(tokens, func(user string, attributes *authorizationv1.ResourceAttributes) bool {
+		return attributes.Verb == "list" && (user == "alice" || (user == "bob" && attributes.Namespace == "default"))
+	}, objs...)
+}
+
+func newRequest(path string, token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func enabledConfig(enabled bool) *fake.MockConfig {
+	return &fake.MockConfig{
+		GetRestApiEnabledFunc: func() bool {
+			return enabled
+		},
+	}
+}
+
+func makeApp(name, namespace string) *apilifecycle.KeptnApp {
+	return &apilifecycle.KeptnApp{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       apilifecycle.KeptnAppSpec{Version: "1.0.0"},
+	}
+}
+
+func makeAppVersion(appName, version, namespace string, created time.Time) *apilifecycle.KeptnAppVersion {
+	return &apilifecycle.KeptnAppVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              appName + "-" + version,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: apilifecycle.KeptnAppVersionSpec{
+			KeptnAppSpec: apilifecycle.KeptnAppSpec{
+				Version: version,
+				Workloads: []apilifecycle.KeptnWorkloadRef{
+					{Name: "my-workload", Version: version},
+				},
+			},
+			AppName: appName,
+		},
+	}
+}
+
+func makeWorkloadVersion(appName, workloadName, version, namespace string) *apilifecycle.KeptnWorkloadVersion {
+	return &apilifecycle.KeptnWorkloadVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      appName + "-" + workloadName + "-" + version,
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnWorkloadVersionSpec{
+			KeptnWorkloadSpec: apilifecycle.KeptnWorkloadSpec{
+				AppName: appName,
+				Version: version,
+			},
+			WorkloadName: appName + "-" + workloadName,
+		},
+	}
+}
@@ -41,8 +41,10 @@ import (
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/phase"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/telemetry"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/eventreceiver"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnapp"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnappcreationrequest"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnapproval"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnappversion"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnevaluation"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptntask"
@@ -51,6 +53,7 @@ import (
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnworkloadversion"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/schedulinggates"
 	controlleroptions "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/options"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/restapi"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/webhooks/pod_mutator"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
@@ -59,6 +62,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -88,8 +93,9 @@ func init() {
 }
 
 type envConfig struct {
-	PodNamespace string `envconfig:"POD_NAMESPACE" default:""`
-	PodName      string `envconfig:"POD_NAME" default:""`
+	PodNamespace       string `envconfig:"POD_NAMESPACE" default:""`
+	PodName            string `envconfig:"POD_NAME" default:""`
+	ServiceAccountName string `envconfig:"SERVICE_ACCOUNT_NAME" default:""`
 
 	KeptnAppControllerLogLevel                int `envconfig:"KEPTN_APP_CONTROLLER_LOG_LEVEL" default:"0"`
 	KeptnAppCreationRequestControllerLogLevel int `envconfig:"KEPTN_APP_CREATION_REQUEST_CONTROLLER_LOG_LEVEL" default:"0"`
@@ -105,6 +111,16 @@ type envConfig struct {
 
 	PromotionTasksEnabled bool `envconfig:"PROMOTION_TASKS_ENABLED" default:"false"`
 
+	ApprovalCallbackEnabled bool `envconfig:"APPROVAL_CALLBACK_ENABLED" default:"false"`
+	ApprovalCallbackPort    int  `envconfig:"APPROVAL_CALLBACK_PORT" default:"8082"`
+
+	RestApiPort int `envconfig:"REST_API_PORT" default:"8083"`
+
+	EventReceiverEnabled bool `envconfig:"EVENT_RECEIVER_ENABLED" default:"false"`
+	EventReceiverPort    int  `envconfig:"EVENT_RECEIVER_PORT" default:"8084"`
+
+	EndpointCertDir string `envconfig:"ENDPOINT_CERT_DIR" default:"/tmp/webhook/certs"`
+
 	CertManagerEnabled bool `envconfig:"CERT_MANAGER_ENABLED" default:"true"`
 }
 
@@ -210,6 +226,23 @@ func main() {
 		setupLog.Error(err, "failed to create CloudEvent client")
 		os.Exit(1)
 	}
+	ceDispatcher := eventsender.NewCloudEventDispatcher(
+		ctrl.Log.WithName("CloudEvent Dispatcher"),
+		ceClient,
+		mgr.GetClient(),
+		telemetry.SetUpCloudEventMeters(meter),
+	)
+	if err = mgr.Add(ceDispatcher); err != nil {
+		setupLog.Error(err, "unable to add CloudEvent dispatcher")
+		os.Exit(1)
+	}
+
+	// create clientset to read the logs of failed tasks
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create clientset")
+		os.Exit(1)
+	}
 
 	taskLogger := ctrl.Log.WithName("KeptnTask Controller").V(env.KeptnTaskControllerLogLevel)
 	taskRecorder := mgr.GetEventRecorderFor("keptntask-controller")
@@ -217,8 +250,9 @@ func main() {
 		Client:      mgr.GetClient(),
 		Scheme:      mgr.GetScheme(),
 		Log:         taskLogger,
-		EventSender: eventsender.NewEventMultiplexer(taskLogger, taskRecorder, ceClient),
+		EventSender: eventsender.NewEventMultiplexer(taskLogger, taskRecorder, ceDispatcher),
 		Meters:      keptnMeters,
+		PodClient:   clientset.CoreV1(),
 	}
 	if err = (taskReconciler).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KeptnTask")
@@ -231,7 +265,8 @@ func main() {
 		Client:      mgr.GetClient(),
 		Scheme:      mgr.GetScheme(),
 		Log:         taskDefinitionLogger,
-		EventSender: eventsender.NewEventMultiplexer(taskDefinitionLogger, taskDefinitionRecorder, ceClient),
+		EventSender: eventsender.NewEventMultiplexer(taskDefinitionLogger, taskDefinitionRecorder, ceDispatcher),
+		Config:      config.Instance(),
 	}
 	if err = (taskDefinitionReconciler).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KeptnTaskDefinition")
@@ -244,7 +279,7 @@ func main() {
 		Client:      mgr.GetClient(),
 		Scheme:      mgr.GetScheme(),
 		Log:         appLogger,
-		EventSender: eventsender.NewEventMultiplexer(appLogger, appRecorder, ceClient),
+		EventSender: eventsender.NewEventMultiplexer(appLogger, appRecorder, ceDispatcher),
 	}
 	if err = (appReconciler).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KeptnApp")
@@ -268,7 +303,7 @@ func main() {
 		Client:        mgr.GetClient(),
 		Scheme:        mgr.GetScheme(),
 		Log:           workloadLogger,
-		EventSender:   eventsender.NewEventMultiplexer(workloadLogger, workloadRecorder, ceClient),
+		EventSender:   eventsender.NewEventMultiplexer(workloadLogger, workloadRecorder, ceDispatcher),
 		TracerFactory: telemetry.GetOtelInstance(),
 	}
 	if err = (workloadReconciler).SetupWithManager(mgr); err != nil {
@@ -277,7 +312,7 @@ func main() {
 	}
 	workloadVersionLogger := ctrl.Log.WithName("KeptnWorkloadVersion Controller").V(env.KeptnWorkloadVersionControllerLogLevel)
 	workloadVersionRecorder := mgr.GetEventRecorderFor("keptnworkloadversion-controller")
-	workloadVersionEventSender := eventsender.NewEventMultiplexer(workloadVersionLogger, workloadVersionRecorder, ceClient)
+	workloadVersionEventSender := eventsender.NewEventMultiplexer(workloadVersionLogger, workloadVersionRecorder, ceDispatcher)
 
 	workloadVersionPhaseHandler := phase.NewHandler(
 		mgr.GetClient(),
@@ -303,7 +338,7 @@ func main() {
 
 	appVersionLogger := ctrl.Log.WithName("KeptnAppVersion Controller").V(env.KeptnAppVersionControllerLogLevel)
 	appVersionRecorder := mgr.GetEventRecorderFor("keptnappversion-controller")
-	appVersionEventSender := eventsender.NewEventMultiplexer(appVersionLogger, appVersionRecorder, ceClient)
+	appVersionEventSender := eventsender.NewEventMultiplexer(appVersionLogger, appVersionRecorder, ceDispatcher)
 
 	appVersionPhaseHandler := phase.NewHandler(
 		mgr.GetClient(),
@@ -328,13 +363,52 @@ func main() {
 		os.Exit(1)
 	}
 
+	if env.ApprovalCallbackEnabled {
+		approvalCallbackServer := keptnapproval.NewCallbackServer(
+			mgr.GetClient(),
+			ctrl.Log.WithName("KeptnApproval Callback"),
+			env.ApprovalCallbackPort,
+			env.EndpointCertDir,
+		)
+		if err = mgr.Add(approvalCallbackServer); err != nil {
+			setupLog.Error(err, "unable to add approval callback server")
+			os.Exit(1)
+		}
+	}
+
+	if env.EventReceiverEnabled {
+		eventReceiver := eventreceiver.NewReceiver(
+			mgr.GetClient(),
+			ctrl.Log.WithName("Cloud Events Receiver"),
+			env.EventReceiverPort,
+			env.EndpointCertDir,
+		)
+		if err = mgr.Add(eventReceiver); err != nil {
+			setupLog.Error(err, "unable to add Cloud Events receiver")
+			os.Exit(1)
+		}
+	}
+
+	// the REST API is always served, requests are only answered if it is enabled in the KeptnConfig
+	restApiServer := restapi.NewServer(
+		mgr.GetClient(),
+		ctrl.Log.WithName("REST API"),
+		config.Instance(),
+		env.RestApiPort,
+		env.EndpointCertDir,
+	)
+	if err = mgr.Add(restApiServer); err != nil {
+		setupLog.Error(err, "unable to add REST API server")
+		os.Exit(1)
+	}
+
 	evaluationLogger := ctrl.Log.WithName("KeptnEvaluation Controller").V(env.KeptnEvaluationControllerLogLevel)
 	evaluationRecorder := mgr.GetEventRecorderFor("keptnevaluation-controller")
 	evaluationReconciler := &keptnevaluation.KeptnEvaluationReconciler{
 		Client:      mgr.GetClient(),
 		Scheme:      mgr.GetScheme(),
 		Log:         evaluationLogger,
-		EventSender: eventsender.NewEventMultiplexer(evaluationLogger, evaluationRecorder, ceClient),
+		EventSender: eventsender.NewEventMultiplexer(evaluationLogger, evaluationRecorder, ceDispatcher),
 		Meters:      keptnMeters,
 	}
 	if err = (evaluationReconciler).SetupWithManager(mgr); err != nil {
@@ -377,6 +451,22 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnTaskDefinition")
 		os.Exit(1)
 	}
+	if err = (&lifecyclev1.KeptnAppContext{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnAppContext")
+		os.Exit(1)
+	}
+	if err = (&lifecyclev1.KeptnWorkload{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnWorkload")
+		os.Exit(1)
+	}
+	if err = (&lifecyclev1.KeptnEvaluationDefinition{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnEvaluationDefinition")
+		os.Exit(1)
+	}
+	if err = (&lifecyclev1.KeptnApproval{}).SetupWebhookWithManager(mgr, serviceaccount.MakeUsername(env.PodNamespace, env.ServiceAccountName)); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnApproval")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	telemetry.SetUpKeptnMeters(meter, mgr.GetClient())
@@ -421,7 +511,7 @@ func main() {
 					eventsender.NewEventMultiplexer(
 						webhookLogger,
 						webhookRecorder,
-						ceClient),
+						ceDispatcher),
 					webhookLogger,
 				),
 			},
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	fakeconfig "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config/fake"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptntaskdefinition"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/test/component/common"
@@ -36,6 +38,12 @@ var _ = BeforeSuite(func() {
 		Scheme:      k8sManager.GetScheme(),
 		EventSender: eventsender.NewK8sSender(k8sManager.GetEventRecorderFor("test-taskdefinition-controller")),
 		Log:         GinkgoLogr,
+		Config: &fakeconfig.MockConfig{
+			// the function code is served by a local test server
+			GetFunctionCodeSourcesFunc: func() config.FunctionCodeSources {
+				return config.FunctionCodeSources{Schemes: []string{"http"}, AllowPrivateAddresses: true}
+			},
+		},
 	}
 	Eventually(controller.SetupWithManager(k8sManager)).WithTimeout(30 * time.Second).WithPolling(time.Second).Should(Succeed())
 	close(readyToStart)
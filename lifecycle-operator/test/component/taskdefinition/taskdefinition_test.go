@@ -2,8 +2,12 @@ package taskdefinition_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/taskdefinition"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/test/component/common"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -230,6 +234,64 @@ var _ = Describe("Taskdefinition", Ordered, func() {
 
 			})
 
+			It("create ConfigMap from cached httpRef", func() {
+				code := "console.log(Hello);"
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte(code))
+				}))
+				defer server.Close()
+
+				By("Create TaskDefinition")
+				taskDefinition = &apilifecycle.KeptnTaskDefinition{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      taskDefinitionName,
+						Namespace: namespace,
+					},
+					Spec: apilifecycle.KeptnTaskDefinitionSpec{
+						Deno: &apilifecycle.RuntimeSpec{
+							HttpReference: apilifecycle.HttpReference{
+								Url:   server.URL,
+								Cache: true,
+							},
+						},
+					},
+				}
+
+				err := k8sClient.Create(context.TODO(), taskDefinition)
+				Expect(err).To(BeNil())
+
+				By("Check if ConfigMap was created")
+
+				configmap = &v1.ConfigMap{}
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(context.TODO(), types.NamespacedName{
+						Namespace: namespace,
+						Name:      "keptnfn-" + taskDefinitionName,
+					}, configmap)
+					g.Expect(err).To(BeNil())
+					g.Expect(configmap.Data["code"]).To(Equal(code))
+					g.Expect(configmap.Annotations[apicommon.SourceURLAnnotation]).To(Equal(server.URL))
+					g.Expect(configmap.Annotations[apicommon.SourceDigestAnnotation]).To(Equal("sha256:" + taskdefinition.Checksum([]byte(code))))
+					g.Expect(configmap.OwnerReferences).To(HaveLen(1))
+				}, "30s").Should(Succeed())
+
+				By("Check if TaskDefinition was updated")
+
+				taskDefinition2 := &apilifecycle.KeptnTaskDefinition{}
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(context.TODO(), types.NamespacedName{
+						Namespace: namespace,
+						Name:      taskDefinition.Name,
+					}, taskDefinition2)
+					g.Expect(err).To(BeNil())
+					g.Expect(taskDefinition2.Status.Function.ConfigMap).To(Equal(configmap.Name))
+
+				}, "30s").Should(Succeed())
+
+				err = k8sClient.Delete(context.TODO(), configmap)
+				common.LogErrorIfPresent(err)
+			})
+
 			AfterEach(func() {
 				err := k8sClient.Delete(context.TODO(), taskDefinition)
 				common.LogErrorIfPresent(err)
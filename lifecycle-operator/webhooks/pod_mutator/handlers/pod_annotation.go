@@ -8,6 +8,7 @@ import (
 	"github.com/go-logr/logr"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -64,6 +65,19 @@ func (p *PodAnnotationHandler) copyAnnotationsIfParentAnnotated(ctx context.Cont
 		ds := &appsv1.DaemonSet{}
 		objectContainerMetaData := p.fetchParent(ctx, types.NamespacedName{Name: podOwner.Name, Namespace: req.Namespace}, ds)
 		return copyResourceLabelsIfPresent(objectContainerMetaData, pod)
+	case "Job":
+		job := &batchv1.Job{}
+		objectContainerMetaData := p.fetchParent(ctx, types.NamespacedName{Name: podOwner.Name, Namespace: req.Namespace}, job)
+		if objectContainerMetaData == nil {
+			return false
+		}
+
+		jobOwner := GetOwnerReference(&job.ObjectMeta)
+		if jobOwner.Kind == "CronJob" {
+			cj := &batchv1.CronJob{}
+			objectContainerMetaData = p.fetchParent(ctx, types.NamespacedName{Name: jobOwner.Name, Namespace: req.Namespace}, cj)
+		}
+		return copyResourceLabelsIfPresent(objectContainerMetaData, pod)
 	default:
 		return false
 	}
@@ -95,8 +109,15 @@ func copyResourceLabelsIfPresent(sourceResource *metav1.ObjectMeta, targetPod *c
 	postDeploymentChecks, _ = GetLabelOrAnnotation(sourceResource, apicommon.PostDeploymentTaskAnnotation, "")
 	preEvaluationChecks, _ = GetLabelOrAnnotation(sourceResource, apicommon.PreDeploymentEvaluationAnnotation, "")
 	postEvaluationChecks, _ = GetLabelOrAnnotation(sourceResource, apicommon.PostDeploymentEvaluationAnnotation, "")
+	preAnalyses, _ := GetLabelOrAnnotation(sourceResource, apicommon.PreDeploymentAnalysisAnnotation, "")
+	postAnalyses, _ := GetLabelOrAnnotation(sourceResource, apicommon.PostDeploymentAnalysisAnnotation, "")
 	containerName, _ := GetLabelOrAnnotation(sourceResource, apicommon.ContainerNameAnnotation, "")
 	metadata, _ := GetLabelOrAnnotation(sourceResource, apicommon.MetadataAnnotation, "")
+	preDeploymentDependencies, _ := GetLabelOrAnnotation(sourceResource, apicommon.PreDeploymentTaskDependenciesAnnotation, "")
+	postDeploymentDependencies, _ := GetLabelOrAnnotation(sourceResource, apicommon.PostDeploymentTaskDependenciesAnnotation, "")
+	readinessCheck, _ := GetLabelOrAnnotation(sourceResource, apicommon.ReadinessCheckAnnotation, "")
+	observabilityTimeout, _ := GetLabelOrAnnotation(sourceResource, apicommon.ObservabilityTimeoutAnnotation, "")
+	analysisTimeframe, _ := GetLabelOrAnnotation(sourceResource, apicommon.AnalysisTimeframeAnnotation, "")
 
 	if gotWorkloadName {
 		setMapKey(targetPod.Annotations, apicommon.WorkloadAnnotation, workloadName)
@@ -117,7 +138,14 @@ func copyResourceLabelsIfPresent(sourceResource *metav1.ObjectMeta, targetPod *c
 		setMapKey(targetPod.Annotations, apicommon.PostDeploymentTaskAnnotation, postDeploymentChecks)
 		setMapKey(targetPod.Annotations, apicommon.PreDeploymentEvaluationAnnotation, preEvaluationChecks)
 		setMapKey(targetPod.Annotations, apicommon.PostDeploymentEvaluationAnnotation, postEvaluationChecks)
+		setMapKey(targetPod.Annotations, apicommon.PreDeploymentAnalysisAnnotation, preAnalyses)
+		setMapKey(targetPod.Annotations, apicommon.PostDeploymentAnalysisAnnotation, postAnalyses)
 		setMapKey(targetPod.Annotations, apicommon.MetadataAnnotation, metadata)
+		setMapKey(targetPod.Annotations, apicommon.PreDeploymentTaskDependenciesAnnotation, preDeploymentDependencies)
+		setMapKey(targetPod.Annotations, apicommon.PostDeploymentTaskDependenciesAnnotation, postDeploymentDependencies)
+		setMapKey(targetPod.Annotations, apicommon.ReadinessCheckAnnotation, readinessCheck)
+		setMapKey(targetPod.Annotations, apicommon.ObservabilityTimeoutAnnotation, observabilityTimeout)
+		setMapKey(targetPod.Annotations, apicommon.AnalysisTimeframeAnnotation, analysisTimeframe)
 
 		return true
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	admissionv1 "k8s.io/api/admission/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -26,6 +27,8 @@ const preDep = "some-pre-deployment-task"
 const postDep = "some-post-deployment-task"
 const preEval = "some-pre-deployment-evaluation"
 const postEval = "some-post-deployment-evaluation"
+const preAnalysis = "some-pre-deployment-analysis"
+const postAnalysis = "some-post-deployment-analysis"
 const version = "v1.0.0"
 const uid = "this-is-the-pod-uid"
 const metadata = "foo=bar"
@@ -100,7 +103,53 @@ func TestCopyAnnotationsIfParentAnnotated(t *testing.T) {
 		},
 	}
 
-	fakeClient := testcommon.NewTestClient(rsWithDpOwner, rsWithNoOwner, testDp, testSts, testDs)
+	testJob := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-job",
+			UID:       "this-is-the-job-uid",
+			Namespace: testNamespace,
+			Annotations: map[string]string{
+				apicommon.WorkloadAnnotation: workloadName,
+				apicommon.VersionAnnotation:  version,
+			},
+		},
+	}
+	testJobWithCronJobOwner := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-job-with-cronjob-owner",
+			UID:       "this-is-the-job-with-cronjob-owner-uid",
+			Namespace: testNamespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind: "CronJob",
+					Name: "test-cronjob",
+					UID:  "this-is-the-cronjob-uid",
+				},
+			},
+		},
+	}
+	testCronJob := &batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "CronJob",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cronjob",
+			UID:       "this-is-the-cronjob-uid",
+			Namespace: testNamespace,
+			Annotations: map[string]string{
+				apicommon.WorkloadAnnotation: workloadName,
+				apicommon.VersionAnnotation:  version,
+			},
+		},
+	}
+
+	fakeClient := testcommon.NewTestClient(rsWithDpOwner, rsWithNoOwner, testDp, testSts, testDs, testJob, testJobWithCronJobOwner, testCronJob)
 
 	type fields struct {
 		Client client.Client
@@ -222,6 +271,62 @@ func TestCopyAnnotationsIfParentAnnotated(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "Test fetching of job owner of pod",
+			fields: fields{
+				Log:    testr.New(t),
+				Client: fakeClient,
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: &admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Namespace: testNamespace,
+					},
+				},
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: uid,
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								Name: testJob.Name,
+								UID:  testJob.UID,
+								Kind: "Job",
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Test fetching of job owner of pod and cronjob owner of job",
+			fields: fields{
+				Log:    testr.New(t),
+				Client: fakeClient,
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: &admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Namespace: testNamespace,
+					},
+				},
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: uid,
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								Name: testJobWithCronJobOwner.Name,
+								UID:  testJobWithCronJobOwner.UID,
+								Kind: "Job",
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
 		{
 			name: "Test that method returns without doing anything when we get a pod with replicaset without owner",
 			fields: fields{
@@ -441,6 +546,9 @@ func TestCopyResourceLabelsIfPresent(t *testing.T) {
 						apicommon.PostDeploymentTaskAnnotation:       postDep,
 						apicommon.PreDeploymentEvaluationAnnotation:  preEval,
 						apicommon.PostDeploymentEvaluationAnnotation: postEval,
+						apicommon.PreDeploymentAnalysisAnnotation:    preAnalysis,
+						apicommon.PostDeploymentAnalysisAnnotation:   postAnalysis,
+						apicommon.AnalysisTimeframeAnnotation:        "10m",
 						apicommon.MetadataAnnotation:                 metadata,
 					},
 				},
@@ -463,6 +571,9 @@ func TestCopyResourceLabelsIfPresent(t *testing.T) {
 						apicommon.PostDeploymentTaskAnnotation:       postDep,
 						apicommon.PreDeploymentEvaluationAnnotation:  preEval,
 						apicommon.PostDeploymentEvaluationAnnotation: postEval,
+						apicommon.PreDeploymentAnalysisAnnotation:    preAnalysis,
+						apicommon.PostDeploymentAnalysisAnnotation:   postAnalysis,
+						apicommon.AnalysisTimeframeAnnotation:        "10m",
 						apicommon.MetadataAnnotation:                 metadata,
 					},
 				},
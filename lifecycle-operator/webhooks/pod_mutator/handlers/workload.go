@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
@@ -83,6 +85,9 @@ func generateWorkload(ctx context.Context, pod *corev1.Pod, namespace string) *a
 	postDeploymentTasks := getValuesForAnnotations(&pod.ObjectMeta, apicommon.PostDeploymentTaskAnnotation)
 	preDeploymentEvaluation := getValuesForAnnotations(&pod.ObjectMeta, apicommon.PreDeploymentEvaluationAnnotation)
 	postDeploymentEvaluation := getValuesForAnnotations(&pod.ObjectMeta, apicommon.PostDeploymentEvaluationAnnotation)
+	readinessCheck, _ := GetLabelOrAnnotation(&pod.ObjectMeta, apicommon.ReadinessCheckAnnotation, "")
+	observabilityTimeout, _ := GetLabelOrAnnotation(&pod.ObjectMeta, apicommon.ObservabilityTimeoutAnnotation, "")
+	analysisTimeframe, _ := GetLabelOrAnnotation(&pod.ObjectMeta, apicommon.AnalysisTimeframeAnnotation, "")
 	applicationName := getAppName(&pod.ObjectMeta)
 	// create TraceContext
 	// follow up with a Keptn propagator that JSON-encoded the OTel map into our own key
@@ -108,7 +113,14 @@ func generateWorkload(ctx context.Context, pod *corev1.Pod, namespace string) *a
 			PostDeploymentTasks:       postDeploymentTasks,
 			PreDeploymentEvaluations:  preDeploymentEvaluation,
 			PostDeploymentEvaluations: postDeploymentEvaluation,
+			PreDeploymentAnalyses:     getValuesForAnnotations(&pod.ObjectMeta, apicommon.PreDeploymentAnalysisAnnotation),
+			PostDeploymentAnalyses:    getValuesForAnnotations(&pod.ObjectMeta, apicommon.PostDeploymentAnalysisAnnotation),
+			PreDeploymentTaskRefs:     parseTaskDependencies(getValuesForAnnotations(&pod.ObjectMeta, apicommon.PreDeploymentTaskDependenciesAnnotation)),
+			PostDeploymentTaskRefs:    parseTaskDependencies(getValuesForAnnotations(&pod.ObjectMeta, apicommon.PostDeploymentTaskDependenciesAnnotation)),
 			Metadata:                  parseWorkloadMetadata(getValuesForAnnotations(&pod.ObjectMeta, apicommon.MetadataAnnotation)),
+			ReadinessCheck:            parseReadinessCheck(readinessCheck),
+			ObservabilityTimeout:      parseDuration(observabilityTimeout),
+			AnalysisTimeframe:         parseDuration(analysisTimeframe),
 		},
 	}
 }
@@ -125,3 +137,81 @@ func parseWorkloadMetadata(annotations []string) map[string]string {
 	}
 	return result
 }
+
+// parseTaskDependencies converts a list of <task>=<dependency> pairs into task references,
+// keeping the order in which the tasks appear first
+func parseTaskDependencies(annotations []string) []apilifecycle.TaskReference {
+	var result []apilifecycle.TaskReference
+	indexes := make(map[string]int, len(annotations))
+	for _, value := range annotations {
+		split := strings.Split(value, "=")
+
+		if len(split) != 2 || split[0] == "" || split[1] == "" {
+			continue
+		}
+		i, ok := indexes[split[0]]
+		if !ok {
+			i = len(result)
+			indexes[split[0]] = i
+			result = append(result, apilifecycle.TaskReference{Name: split[0]})
+		}
+		result[i].DependsOn = append(result[i].DependsOn, split[1])
+	}
+	return result
+}
+
+// parseReadinessCheck converts a <strategy>[=<threshold>] value into a readiness check.
+// The threshold is the minimum percentage of available replicas for the minAvailability strategy
+// and the maximum number of container restarts for the podReadiness strategy.
+// Unknown strategies and invalid thresholds are ignored.
+func parseReadinessCheck(annotation string) *apilifecycle.ReadinessCheck {
+	if annotation == "" {
+		return nil
+	}
+	strategy, threshold, hasThreshold := strings.Cut(annotation, "=")
+	readinessCheck := &apilifecycle.ReadinessCheck{
+		Strategy: apilifecycle.ReadinessStrategy(strategy),
+	}
+
+	value, err := strconv.Atoi(threshold)
+	if hasThreshold && err != nil {
+		return nil
+	}
+
+	switch readinessCheck.Strategy {
+	case apilifecycle.ReadinessStrategyReplicas, apilifecycle.ReadinessStrategyProgressDeadline:
+	case apilifecycle.ReadinessStrategyMinAvailability:
+		readinessCheck.MinAvailablePercentage = 100
+		if hasThreshold {
+			if value < 1 || value > 100 {
+				return nil
+			}
+			readinessCheck.MinAvailablePercentage = value
+		}
+	case apilifecycle.ReadinessStrategyPodReadiness:
+		maxRestarts := apilifecycle.DefaultMaxRestarts
+		if hasThreshold {
+			if value < 0 {
+				return nil
+			}
+			maxRestarts = int32(value)
+		}
+		readinessCheck.MaxRestarts = &maxRestarts
+	default:
+		return nil
+	}
+	return readinessCheck
+}
+
+// parseDuration converts a duration value such as 5m, as used for the observability timeout and analysis timeframe.
+// Invalid and non-positive durations are ignored.
+func parseDuration(annotation string) *metav1.Duration {
+	if annotation == "" {
+		return nil
+	}
+	duration, err := time.ParseDuration(annotation)
+	if err != nil || duration <= 0 {
+		return nil
+	}
+	return &metav1.Duration{Duration: duration}
+}
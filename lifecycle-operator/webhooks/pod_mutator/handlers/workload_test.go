@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr/testr"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
@@ -216,6 +217,7 @@ func TestUpdateWorkloadNoSpecChanges(t *testing.T) {
 }
 
 func TestGenerateWorkload(t *testing.T) {
+	maxRestarts := int32(5)
 	testCases := []struct {
 		name           string
 		podAnnotations map[string]string
@@ -229,7 +231,12 @@ func TestGenerateWorkload(t *testing.T) {
 				apicommon.PostDeploymentTaskAnnotation:       "task3,task4",
 				apicommon.PreDeploymentEvaluationAnnotation:  "eval1,eval2",
 				apicommon.PostDeploymentEvaluationAnnotation: "eval3,eval4",
+				apicommon.PreDeploymentAnalysisAnnotation:    "analysis1",
+				apicommon.PostDeploymentAnalysisAnnotation:   "analysis2,analysis3",
 				apicommon.K8sRecommendedAppAnnotations:       "my-app",
+				apicommon.ReadinessCheckAnnotation:           "podReadiness=5",
+				apicommon.ObservabilityTimeoutAnnotation:     "10m",
+				apicommon.AnalysisTimeframeAnnotation:        "15m",
 			},
 			expected: &apilifecycle.KeptnWorkload{
 				ObjectMeta: metav1.ObjectMeta{
@@ -253,7 +260,15 @@ func TestGenerateWorkload(t *testing.T) {
 					PostDeploymentTasks:       []string{"task3", "task4"},
 					PreDeploymentEvaluations:  []string{"eval1", "eval2"},
 					PostDeploymentEvaluations: []string{"eval3", "eval4"},
+					PreDeploymentAnalyses:     []string{"analysis1"},
+					PostDeploymentAnalyses:    []string{"analysis2", "analysis3"},
 					Metadata:                  map[string]string{},
+					ReadinessCheck: &apilifecycle.ReadinessCheck{
+						Strategy:    apilifecycle.ReadinessStrategyPodReadiness,
+						MaxRestarts: &maxRestarts,
+					},
+					ObservabilityTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+					AnalysisTimeframe:    &metav1.Duration{Duration: 15 * time.Minute},
 				},
 			},
 		},
@@ -350,3 +365,146 @@ func Test_parseWorkloadMetadata(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseTaskDependencies(t *testing.T) {
+	type args struct {
+		annotations []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []apilifecycle.TaskReference
+	}{
+		{
+			name: "valid input",
+			args: args{
+				annotations: []string{"notify=smoke-test", "cleanup=notify", "notify=load-test"},
+			},
+			want: []apilifecycle.TaskReference{
+				{Name: "notify", DependsOn: []string{"smoke-test", "load-test"}},
+				{Name: "cleanup", DependsOn: []string{"notify"}},
+			},
+		},
+		{
+			name: "invalid input",
+			args: args{
+				annotations: []string{"notify", "=smoke-test"},
+			},
+			want: nil,
+		},
+		{
+			name: "empty input",
+			args: args{
+				annotations: []string{},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseTaskDependencies(tt.args.annotations); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTaskDependencies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseReadinessCheck(t *testing.T) {
+	defaultRestarts := int32(3)
+	noRestarts := int32(0)
+	tests := []struct {
+		name       string
+		annotation string
+		want       *apilifecycle.ReadinessCheck
+	}{
+		{
+			name:       "empty",
+			annotation: "",
+			want:       nil,
+		},
+		{
+			name:       "replicas",
+			annotation: "replicas",
+			want:       &apilifecycle.ReadinessCheck{Strategy: apilifecycle.ReadinessStrategyReplicas},
+		},
+		{
+			name:       "progressDeadline",
+			annotation: "progressDeadline",
+			want:       &apilifecycle.ReadinessCheck{Strategy: apilifecycle.ReadinessStrategyProgressDeadline},
+		},
+		{
+			name:       "minAvailability with percentage",
+			annotation: "minAvailability=80",
+			want:       &apilifecycle.ReadinessCheck{Strategy: apilifecycle.ReadinessStrategyMinAvailability, MinAvailablePercentage: 80},
+		},
+		{
+			name:       "minAvailability without percentage",
+			annotation: "minAvailability",
+			want:       &apilifecycle.ReadinessCheck{Strategy: apilifecycle.ReadinessStrategyMinAvailability, MinAvailablePercentage: 100},
+		},
+		{
+			name:       "minAvailability with invalid percentage",
+			annotation: "minAvailability=120",
+			want:       nil,
+		},
+		{
+			name:       "podReadiness without restarts",
+			annotation: "podReadiness",
+			want:       &apilifecycle.ReadinessCheck{Strategy: apilifecycle.ReadinessStrategyPodReadiness, MaxRestarts: &defaultRestarts},
+		},
+		{
+			name:       "podReadiness with restarts",
+			annotation: "podReadiness=0",
+			want:       &apilifecycle.ReadinessCheck{Strategy: apilifecycle.ReadinessStrategyPodReadiness, MaxRestarts: &noRestarts},
+		},
+		{
+			name:       "invalid threshold",
+			annotation: "podReadiness=many",
+			want:       nil,
+		},
+		{
+			name:       "unknown strategy",
+			annotation: "unknown",
+			want:       nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, parseReadinessCheck(tt.annotation))
+		})
+	}
+}
+
+func Test_parseDuration(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		want       *metav1.Duration
+	}{
+		{
+			name:       "empty",
+			annotation: "",
+			want:       nil,
+		},
+		{
+			name:       "valid duration",
+			annotation: "1h30m",
+			want:       &metav1.Duration{Duration: 90 * time.Minute},
+		},
+		{
+			name:       "invalid duration",
+			annotation: "ten minutes",
+			want:       nil,
+		},
+		{
+			name:       "negative duration",
+			annotation: "-5m",
+			want:       nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, parseDuration(tt.annotation))
+		})
+	}
+}
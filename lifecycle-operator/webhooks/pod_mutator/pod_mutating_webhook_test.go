@@ -105,9 +105,9 @@ func TestPodMutatingWebhookHandleUnsupportedOwner(t *testing.T) {
 			},
 			OwnerReferences: []metav1.OwnerReference{
 				{
-					APIVersion: "batchv1",
-					Kind:       "Job",
-					Name:       "my-job",
+					APIVersion: "v1",
+					Kind:       "ReplicationController",
+					Name:       "my-replication-controller",
 					UID:        "1234",
 				},
 			},
@@ -2,4 +2,15 @@
 
 set -eu
 
-deno run --allow-net --allow-write --allow-read --allow-env=DATA,SECURE_DATA,KEPTN_CONTEXT "$SCRIPT"
+if [ -n "${SCRIPT_SHA256:-}" ]; then
+    # verify the downloaded code before executing it
+    wget -q -O /tmp/function.ts "$SCRIPT"
+    actual=$(sha256sum /tmp/function.ts | cut -d ' ' -f 1)
+    if [ "$actual" != "$SCRIPT_SHA256" ]; then
+        echo "checksum of $SCRIPT does not match: expected sha256 $SCRIPT_SHA256, got $actual" >&2
+        exit 1
+    fi
+    SCRIPT=/tmp/function.ts
+fi
+
+deno run --allow-net --allow-write --allow-read --allow-env=DATA,SECURE_DATA,KEPTN_CONTEXT,OUTPUT_FILE "$SCRIPT"
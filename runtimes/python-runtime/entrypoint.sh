@@ -2,7 +2,18 @@
 
 regex='(https?|ftp|file)://[-[:alnum:]\+&@#/%?=~_|!:,.;]*[-[:alnum:]\+&@#/%=~_|]'
 
-if [[ $SCRIPT =~ $regex ]]
+if [[ -n "$SCRIPT_SHA256" ]]
+then
+    # verify the downloaded code before executing it
+    curl -s -o /tmp/function.py $SCRIPT
+    actual=$(sha256sum /tmp/function.py | cut -d ' ' -f 1)
+    if [[ "$actual" != "$SCRIPT_SHA256" ]]
+    then
+        echo "checksum of $SCRIPT does not match: expected sha256 $SCRIPT_SHA256, got $actual" >&2
+        exit 1
+    fi
+    python3 $CMD_ARGS /tmp/function.py
+elif [[ $SCRIPT =~ $regex ]]
 then
     curl -s $SCRIPT | python3 $CMD_ARGS -
 else
@@ -0,0 +1,32 @@
+#!/bin/bash
+
+set -euo pipefail
+
+regex='(https?|ftp|file)://[-[:alnum:]\+&@#/%?=~_|!:,.;]*[-[:alnum:]\+&@#/%=~_|]'
+
+SCRIPT="${SCRIPT:-}"
+SCRIPT_SHA256="${SCRIPT_SHA256:-}"
+# CMD_ARGS holds a list of options for bash, e.g. "-x -e"
+read -r -a args <<< "${CMD_ARGS:-}"
+
+if [[ -n "$SCRIPT_SHA256" ]]
+then
+    # verify the downloaded code before executing it
+    curl -fsSL -o /tmp/function.sh "$SCRIPT"
+    actual=$(sha256sum /tmp/function.sh | cut -d ' ' -f 1)
+    if [[ "$actual" != "$SCRIPT_SHA256" ]]
+    then
+        echo "checksum of $SCRIPT does not match: expected sha256 $SCRIPT_SHA256, got $actual" >&2
+        exit 1
+    fi
+    bash "${args[@]}" /tmp/function.sh
+elif [[ $SCRIPT =~ $regex ]]
+then
+    # download the script first, so that a failed download is not executed as an empty script
+    curl -fsSL -o /tmp/function.sh "$SCRIPT"
+    bash "${args[@]}" /tmp/function.sh
+else
+    # a local script may be followed by its arguments, e.g. "args.sh -i test.txt"
+    read -r -a script <<< "$SCRIPT"
+    bash "${args[@]}" "${script[@]}"
+fi
@@ -0,0 +1,10 @@
+#!/bin/bash
+
+while getopts "i:" opt; do
+    case $opt in
+        i) inputfile=$OPTARG ;;
+        *) exit 1 ;;
+    esac
+done
+
+echo "Input file is $inputfile"
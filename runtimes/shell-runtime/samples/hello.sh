@@ -0,0 +1,4 @@
+#!/bin/bash
+
+echo "Hello, World!"
+env
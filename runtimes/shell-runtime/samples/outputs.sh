@@ -0,0 +1,5 @@
+#!/bin/bash
+
+VERSION=$(echo "$KEPTN_CONTEXT" | jq -r '.workloadVersion')
+
+jq -n --arg version "$VERSION" '{"checkedVersion": $version}' > "$OUTPUT_FILE"
@@ -0,0 +1,6 @@
+#!/bin/bash
+
+USER=$(echo "$DATA" | jq -r '.user')
+PASSWORD=$SECURE_DATA
+
+echo "$USER $PASSWORD"